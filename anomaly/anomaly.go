@@ -0,0 +1,178 @@
+package anomaly
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Kinds of anomaly
+const (
+	// KindUnusualAmount is a charge far above what its payee usually charges
+	KindUnusualAmount = "UNUSUAL_AMOUNT"
+	// KindNewPayee is a first charge of a payee, far above the usual charges of its category
+	KindNewPayee = "NEW_PAYEE"
+	// KindDuplicate is a charge of the same payee and amount as another one a few days before
+	KindDuplicate = "DUPLICATE"
+)
+
+// minHistory is the number of past charges needed to tell what is usual
+const minHistory = 3
+
+// DuplicateWindow is how close two identical charges must be to look like a duplicate
+const DuplicateWindow = 3 * 24 * time.Hour
+
+var (
+	// outlierScore is the modified z-score over which a charge is unusual
+	outlierScore = decimal.RequireFromString("3.5")
+	// madScale turns the median absolute deviation into a standard deviation of a normal distribution
+	madScale = decimal.RequireFromString("0.6745")
+	// outlierRatio is how far over the median a charge must be when every past charge was the same
+	outlierRatio = decimal.RequireFromString("1.5")
+)
+
+// Line is what the detector knows about a line, amounts of charges being negative
+type Line struct {
+	ID         int64
+	Title      string
+	Payee      string
+	CategoryID int64
+	Amount     decimal.Decimal
+	DueDate    time.Time
+}
+
+// Flag is an anomaly found on a line
+type Flag struct {
+	LineID int64
+	Kind   string
+	Reason string
+}
+
+// Detect flags the unusual charges of a history of lines.
+// Each line is only compared to the lines before it, so a flag doesn't change as the history grows.
+func Detect(lines []Line) []Flag {
+	flags := []Flag{}
+
+	sorted := make([]Line, 0, len(lines))
+	for _, line := range lines {
+		if line.Amount.IsNegative() {
+			sorted = append(sorted, line)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].DueDate.Equal(sorted[j].DueDate) {
+			return sorted[i].DueDate.Before(sorted[j].DueDate)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	payees := map[string][]Line{}
+	categories := map[int64][]decimal.Decimal{}
+	for _, line := range sorted {
+		payee := payeeKey(line)
+		amount := line.Amount.Abs()
+		past := payees[payee]
+
+		for i := len(past) - 1; i >= 0 && line.DueDate.Sub(past[i].DueDate) <= DuplicateWindow; i-- {
+			if past[i].Amount.Equal(line.Amount) {
+				flags = append(flags, Flag{
+					LineID: line.ID,
+					Kind:   KindDuplicate,
+					Reason: fmt.Sprintf("same amount as line %d of %s", past[i].ID, past[i].DueDate.Format("2006-01-02")),
+				})
+				break
+			}
+		}
+
+		if len(past) >= minHistory {
+			amounts := make([]decimal.Decimal, 0, len(past))
+			for _, pastLine := range past {
+				amounts = append(amounts, pastLine.Amount.Abs())
+			}
+			if median, ok := outlier(amount, amounts); ok {
+				flags = append(flags, Flag{
+					LineID: line.ID,
+					Kind:   KindUnusualAmount,
+					Reason: fmt.Sprintf("%s while %s usually charges %s", amount.StringFixed(2), displayPayee(line), median.StringFixed(2)),
+				})
+			}
+		} else if len(past) == 0 && len(categories[line.CategoryID]) >= minHistory {
+			if median, ok := outlier(amount, categories[line.CategoryID]); ok {
+				flags = append(flags, Flag{
+					LineID: line.ID,
+					Kind:   KindNewPayee,
+					Reason: fmt.Sprintf("first charge of %s is %s while the category usually is %s", displayPayee(line), amount.StringFixed(2), median.StringFixed(2)),
+				})
+			}
+		}
+
+		payees[payee] = append(past, line)
+		categories[line.CategoryID] = append(categories[line.CategoryID], amount)
+	}
+
+	return flags
+}
+
+// outlier tells if an amount is far above past amounts using their median and median absolute deviation,
+// which a few odd amounts don't move. It also returns the median.
+func outlier(amount decimal.Decimal, past []decimal.Decimal) (decimal.Decimal, bool) {
+	median := medianOf(past)
+	if !amount.GreaterThan(median) {
+		return median, false
+	}
+
+	deviations := make([]decimal.Decimal, 0, len(past))
+	for _, value := range past {
+		deviations = append(deviations, value.Sub(median).Abs())
+	}
+	mad := medianOf(deviations)
+	if mad.IsZero() {
+		return median, amount.GreaterThanOrEqual(median.Mul(outlierRatio))
+	}
+
+	score := madScale.Mul(amount.Sub(median)).Div(mad)
+	return median, score.GreaterThan(outlierScore)
+}
+
+// medianOf returns the middle value, or the mean of the two middle values, zero without values
+func medianOf(values []decimal.Decimal) decimal.Decimal {
+	if len(values) == 0 {
+		return decimal.Zero
+	}
+
+	sorted := append([]decimal.Decimal(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+	return sorted[middle-1].Add(sorted[middle]).Div(decimal.NewFromInt(2))
+}
+
+// payeeKey groups the lines of a payee, using the words of the title of lines without payee
+// as bank titles often end with a date or a reference
+func payeeKey(line Line) string {
+	if payee := strings.TrimSpace(line.Payee); payee != "" {
+		return "payee:" + strings.ToLower(payee)
+	}
+
+	var words []string
+	for _, word := range strings.Fields(strings.ToLower(line.Title)) {
+		if strings.IndexFunc(word, unicode.IsDigit) < 0 {
+			words = append(words, word)
+		}
+	}
+	return "title:" + strings.Join(words, " ")
+}
+
+func displayPayee(line Line) string {
+	if payee := strings.TrimSpace(line.Payee); payee != "" {
+		return payee
+	}
+	return line.Title
+}
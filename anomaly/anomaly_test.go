@@ -0,0 +1,107 @@
+package anomaly
+
+import (
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	phone     = int64(1)
+	groceries = int64(2)
+)
+
+func line(id int64, title string, categoryID int64, amount string, day int) Line {
+	return Line{
+		ID:         id,
+		Title:      title,
+		CategoryID: categoryID,
+		Amount:     decimal.RequireFromString(amount),
+		DueDate:    time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, day),
+	}
+}
+
+func kinds(flags []Flag, lineID int64) []string {
+	var result []string
+	for _, flag := range flags {
+		if flag.LineID == lineID {
+			result = append(result, flag.Kind)
+		}
+	}
+	return result
+}
+
+func TestDetectUnusualAmount(t *testing.T) {
+	lines := []Line{
+		line(1, "FREE MOBILE 01/24", phone, "-19.99", 0),
+		line(2, "FREE MOBILE 02/24", phone, "-19.99", 31),
+		line(3, "FREE MOBILE 03/24", phone, "-19.99", 60),
+		line(4, "FREE MOBILE 04/24", phone, "-39.98", 91),
+		line(5, "FREE MOBILE 05/24", phone, "-19.99", 121),
+	}
+
+	flags := Detect(lines)
+	require.Len(t, flags, 1)
+	require.Equal(t, int64(4), flags[0].LineID)
+	require.Equal(t, KindUnusualAmount, flags[0].Kind)
+	require.Contains(t, flags[0].Reason, "19.99")
+
+	// Small changes around the usual amount are expected
+	lines = []Line{
+		line(1, "EDF", phone, "-60", 0),
+		line(2, "EDF", phone, "-64", 31),
+		line(3, "EDF", phone, "-58", 60),
+		line(4, "EDF", phone, "-66", 91),
+	}
+	require.Empty(t, Detect(lines))
+}
+
+func TestDetectNewPayee(t *testing.T) {
+	lines := []Line{
+		line(1, "LIDL", groceries, "-40", 0),
+		line(2, "CARREFOUR", groceries, "-55", 3),
+		line(3, "LIDL", groceries, "-35", 7),
+		line(4, "AUCHAN", groceries, "-480", 10),
+		line(5, "MONOPRIX", groceries, "-45", 12),
+	}
+
+	flags := Detect(lines)
+	require.Len(t, flags, 1)
+	require.Equal(t, int64(4), flags[0].LineID)
+	require.Equal(t, KindNewPayee, flags[0].Kind)
+}
+
+func TestDetectDuplicate(t *testing.T) {
+	lines := []Line{
+		line(1, "AMAZON", groceries, "-25.90", 0),
+		line(2, "AMAZON", groceries, "-25.90", 2),
+		line(3, "AMAZON", groceries, "-25.90", 10),
+		line(4, "AMAZON", groceries, "-12", 11),
+	}
+	lines[1].Payee = "Amazon"
+	lines[0].Payee = "amazon"
+
+	flags := Detect(lines)
+	require.Equal(t, []string{KindDuplicate}, kinds(flags, 2))
+	require.Empty(t, kinds(flags, 3))
+	require.Empty(t, kinds(flags, 4))
+}
+
+func TestDetectIgnoresIncome(t *testing.T) {
+	lines := []Line{
+		line(1, "SALAIRE", phone, "2500", 0),
+		line(2, "SALAIRE", phone, "2500", 1),
+		line(3, "SALAIRE", phone, "2500", 31),
+		line(4, "SALAIRE", phone, "2500", 61),
+		line(5, "SALAIRE", phone, "9000", 91),
+	}
+	require.Empty(t, Detect(lines))
+}
+
+func TestMedian(t *testing.T) {
+	require.True(t, decimal.Zero.Equal(medianOf(nil)))
+	require.True(t, decimal.NewFromInt(3).Equal(medianOf([]decimal.Decimal{decimal.NewFromInt(5), decimal.NewFromInt(1), decimal.NewFromInt(3)})))
+	require.True(t, decimal.RequireFromString("2.5").Equal(medianOf([]decimal.Decimal{decimal.NewFromInt(4), decimal.NewFromInt(1), decimal.NewFromInt(2), decimal.NewFromInt(3)})))
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+)
+
+type listAnomaliesRequest struct {
+	PageID           int32 `form:"page_id" binding:"required,min=1"`
+	PageSize         int32 `form:"page_size" binding:"required,min=5,max=10"`
+	IncludeDismissed bool  `form:"dismissed"`
+}
+
+// listAnomalies returns the unusual charges found in the lines of the authenticated user, the latest first
+func (server *Server) listAnomalies(ctx *gin.Context) {
+	var req listAnomaliesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ListLineAnomaliesParams{
+		Owner:            authPayload.Username,
+		IncludeDismissed: req.IncludeDismissed,
+		Limit:            req.PageSize,
+		Offset:           (req.PageID - 1) * req.PageSize,
+	}
+
+	anomalies, err := server.store.ListLineAnomalies(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, anomalies)
+}
+
+type dismissAnomalyRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// dismissAnomaly hides an anomaly the user looked at, it isn't reported again
+func (server *Server) dismissAnomaly(ctx *gin.Context) {
+	var req dismissAnomalyRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	lineAnomaly, err := server.store.GetLineAnomaly(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if lineAnomaly.Owner != authPayload.Username {
+		err := errors.New("anomaly doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	lineAnomaly, err = server.store.DismissLineAnomaly(ctx, lineAnomaly.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lineAnomaly)
+}
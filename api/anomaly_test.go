@@ -0,0 +1,284 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/moth13/finance_tracker/anomaly"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomLineAnomaly(owner string, kind string) db.LineAnomaly {
+	return db.LineAnomaly{
+		ID:     util.RandomInt(1, 1000),
+		Owner:  owner,
+		LineID: util.RandomInt(1, 1000),
+		Kind:   kind,
+		Reason: util.RandomString(20),
+	}
+}
+
+func TestListAnomaliesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	anomalies := []db.ListLineAnomaliesRow{
+		{ID: util.RandomInt(1, 1000), LineID: util.RandomInt(1, 1000), Kind: anomaly.KindDuplicate, Amount: util.RandomMoney()},
+		{ID: util.RandomInt(1, 1000), LineID: util.RandomInt(1, 1000), Kind: anomaly.KindUnusualAmount, Amount: util.RandomMoney()},
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"page_id": {"1"}, "page_size": {"5"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				arg := db.ListLineAnomaliesParams{
+					Owner:  user.Username,
+					Limit:  5,
+					Offset: 0,
+				}
+				store.EXPECT().
+					ListLineAnomalies(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(anomalies, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.ListLineAnomaliesRow
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 2)
+				require.Equal(t, anomalies[0].ID, got[0].ID)
+				require.Equal(t, anomaly.KindDuplicate, got[0].Kind)
+			},
+		},
+		{
+			name:  "IncludeDismissed",
+			query: url.Values{"page_id": {"2"}, "page_size": {"5"}, "dismissed": {"true"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				arg := db.ListLineAnomaliesParams{
+					Owner:            user.Username,
+					IncludeDismissed: true,
+					Limit:            5,
+					Offset:           5,
+				}
+				store.EXPECT().
+					ListLineAnomalies(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(anomalies, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidPageSize",
+			query: url.Values{"page_id": {"1"}, "page_size": {"50"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLineAnomalies(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{"page_id": {"1"}, "page_size": {"5"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLineAnomalies(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: url.Values{"page_id": {"1"}, "page_size": {"5"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLineAnomalies(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/api/insights/anomalies?" + tc.query.Encode()
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDismissAnomalyAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	lineAnomaly := randomLineAnomaly(user.Username, anomaly.KindNewPayee)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLineAnomaly(gomock.Any(), gomock.Eq(lineAnomaly.ID)).
+					Times(1).
+					Return(lineAnomaly, nil)
+				dismissed := lineAnomaly
+				dismissed.Dismissed = true
+				store.EXPECT().
+					DismissLineAnomaly(gomock.Any(), gomock.Eq(lineAnomaly.ID)).
+					Times(1).
+					Return(dismissed, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.LineAnomaly
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, got.Dismissed)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLineAnomaly(gomock.Any(), gomock.Eq(lineAnomaly.ID)).
+					Times(1).
+					Return(lineAnomaly, nil)
+				store.EXPECT().
+					DismissLineAnomaly(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLineAnomaly(gomock.Any(), gomock.Eq(lineAnomaly.ID)).
+					Times(1).
+					Return(db.LineAnomaly{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLineAnomaly(gomock.Any(), gomock.Eq(lineAnomaly.ID)).
+					Times(1).
+					Return(lineAnomaly, nil)
+				store.EXPECT().
+					DismissLineAnomaly(gomock.Any(), gomock.Eq(lineAnomaly.ID)).
+					Times(1).
+					Return(db.LineAnomaly{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/insights/anomalies/%d/dismiss", lineAnomaly.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+)
+
+func (server *Server) getBackup(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	backup, err := server.store.BackupTx(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("%s-backup-%s.json", authPayload.Username, backup.CreatedAt.Format("20060102"))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.JSON(http.StatusOK, backup)
+}
+
+func (server *Server) restoreBackup(ctx *gin.Context) {
+	var backup db.Backup
+	if err := ctx.ShouldBindJSON(&backup); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.RestoreBackupTxParams{
+		Owner:  authPayload.Username,
+		Backup: backup,
+	}
+
+	result, err := server.store.RestoreBackupTx(ctx, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrUnsupportedBackupVersion), errors.Is(err, db.ErrInvalidBackup):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, db.ErrRestoreTargetNotEmpty):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+	server.suggester.invalidate(arg.Owner)
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/stretchr/testify/require"
+)
+
+func randomBackup(user db.User) db.Backup {
+	account := randomAccount(user.Username)
+	category := randomCategory(user.Username)
+
+	return db.Backup{
+		Version:   db.BackupVersion,
+		CreatedAt: time.Now().UTC(),
+		User: db.BackupUser{
+			Username: user.Username,
+			FullName: user.FullName,
+			Email:    user.Email,
+			Currency: user.Currency,
+		},
+		Accounts:   []db.Account{account},
+		Categories: []db.Category{category},
+	}
+}
+
+func TestGetBackupAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	backup := randomBackup(user)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					BackupTx(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(backup, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), user.Username+"-backup-")
+
+				var got db.Backup
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.BackupVersion, got.Version)
+				require.Equal(t, user.Email, got.User.Email)
+				require.Len(t, got.Accounts, 1)
+				require.NotContains(t, recorder.Body.String(), "hashed_password")
+			},
+		},
+		{
+			name: "NoAuthorization",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					BackupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					BackupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Backup{}, fmt.Errorf("connection lost"))
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/backup", nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRestoreBackupAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	backup := randomBackup(otherUser)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreBackupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.RestoreBackupTxParams) (db.RestoreBackupTxResult, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, otherUser.Username, arg.Backup.User.Username)
+						require.Len(t, arg.Backup.Accounts, 1)
+						return db.RestoreBackupTxResult{Accounts: 1, Categories: 1}, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnsupportedVersion",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreBackupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RestoreBackupTxResult{}, fmt.Errorf("%w 2", db.ErrUnsupportedBackupVersion))
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TargetNotEmpty",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreBackupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RestoreBackupTxResult{}, db.ErrRestoreTargetNotEmpty)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreBackupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(backup)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/backup", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
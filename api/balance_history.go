@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	decimal "github.com/shopspring/decimal"
+)
+
+// maxBalanceHistoryDays is the longest balance history returned at once
+const maxBalanceHistoryDays = 731
+
+// errBalanceHistoryDays is returned when a balance history covers too many days
+var errBalanceHistoryDays = errors.New("balance history must not cover more than 731 days")
+
+type balanceHistoryUriRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type balanceHistoryRequest struct {
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To   time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// accountBalance is the balance of an account at the end of a day, its initial balance included
+type accountBalance struct {
+	Date         time.Time       `json:"date"`
+	Balance      decimal.Decimal `json:"balance"`
+	FinalBalance decimal.Decimal `json:"final_balance"`
+}
+
+// getAccountBalances returns the end of day balances of an account between two dates, both included
+func (server *Server) getAccountBalances(ctx *gin.Context) {
+	var uriReq balanceHistoryUriRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req balanceHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.To.Before(req.From) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportPeriod))
+		return
+	}
+	if req.To.Sub(req.From) >= maxBalanceHistoryDays*24*time.Hour {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errBalanceHistoryDays))
+		return
+	}
+
+	account, ok := server.getOwnedAccount(ctx, uriReq.ID)
+	if !ok {
+		return
+	}
+
+	snapshots, err := server.store.BalanceHistoryTx(ctx, db.BalanceHistoryTxParams{
+		AccountID: account.ID,
+		From:      req.From,
+		To:        req.To,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	balances := make([]accountBalance, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		balances = append(balances, accountBalance{
+			Date:         snapshot.Date,
+			Balance:      account.InitBalance.Add(snapshot.Balance),
+			FinalBalance: account.InitBalance.Add(snapshot.FinalBalance),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, balances)
+}
+
+type accountHistoryRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// getAccountHistory returns the lines of an account, the latest first, with the running balances after each of them
+func (server *Server) getAccountHistory(ctx *gin.Context) {
+	var uriReq balanceHistoryUriRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req accountHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, ok := server.getOwnedAccount(ctx, uriReq.ID)
+	if !ok {
+		return
+	}
+
+	lines, err := server.store.ListAccountHistory(ctx, db.ListAccountHistoryParams{
+		AccountID: account.ID,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lines)
+}
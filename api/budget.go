@@ -0,0 +1,247 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	decimal "github.com/shopspring/decimal"
+)
+
+// errBudgetAmount is returned for budgets which are not a positive amount
+var errBudgetAmount = errors.New("budget amount must be positive")
+
+// budgetStatus answers a failed budget write, a category has a single budget per month and recurrence
+func budgetStatus(err error) int {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+type createBudgetRequest struct {
+	CategoryID int64           `json:"category_id" binding:"required,min=1"`
+	MonthID    int64           `json:"month_id" binding:"required,min=1"`
+	Recurring  bool            `json:"recurring"`
+	Amount     decimal.Decimal `json:"amount" binding:"required"`
+}
+
+func (server *Server) createBudget(ctx *gin.Context) {
+	var req createBudgetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !req.Amount.IsPositive() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errBudgetAmount))
+		return
+	}
+
+	if _, ok := server.getOwnedCategory(ctx, req.CategoryID); !ok {
+		return
+	}
+	if _, ok := server.getOwnedMonth(ctx, req.MonthID); !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateBudgetParams{
+		Owner:      authPayload.Username,
+		CategoryID: req.CategoryID,
+		MonthID:    req.MonthID,
+		Recurring:  req.Recurring,
+		Amount:     req.Amount,
+	}
+
+	budget, err := server.store.CreateBudget(ctx, arg)
+	if err != nil {
+		ctx.JSON(budgetStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budget)
+}
+
+type budgetIDRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getOwnedBudget reads a budget, answering the request itself when the budget can't be used
+func (server *Server) getOwnedBudget(ctx *gin.Context, id int64) (db.Budget, bool) {
+	budget, err := server.store.GetBudget(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return budget, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return budget, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if budget.Owner != authPayload.Username {
+		err := errors.New("budget doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return budget, false
+	}
+
+	return budget, true
+}
+
+func (server *Server) getBudget(ctx *gin.Context) {
+	var req budgetIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	budget, ok := server.getOwnedBudget(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budget)
+}
+
+type listBudgetsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listBudgets(ctx *gin.Context) {
+	var req listBudgetsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ListBudgetsParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	budgets, err := server.store.ListBudgets(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budgets)
+}
+
+type updateBudgetJSONRequest struct {
+	MonthID   *int64              `json:"month_id" binding:"omitempty,min=1"`
+	Recurring *bool               `json:"recurring"`
+	Amount    decimal.NullDecimal `json:"amount"`
+}
+
+// updateBudget changes the amount or the months of a budget, its category is kept
+func (server *Server) updateBudget(ctx *gin.Context) {
+	var reqURI budgetIDRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var reqJSON updateBudgetJSONRequest
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if reqJSON.Amount.Valid && !reqJSON.Amount.Decimal.IsPositive() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errBudgetAmount))
+		return
+	}
+
+	budget, ok := server.getOwnedBudget(ctx, reqURI.ID)
+	if !ok {
+		return
+	}
+
+	arg := db.UpdateBudgetParams{
+		ID:        budget.ID,
+		MonthID:   budget.MonthID,
+		Recurring: budget.Recurring,
+		Amount:    budget.Amount,
+	}
+
+	// Overload when needs it
+	if reqJSON.MonthID != nil {
+		if _, ok := server.getOwnedMonth(ctx, *reqJSON.MonthID); !ok {
+			return
+		}
+		arg.MonthID = *reqJSON.MonthID
+	}
+
+	if reqJSON.Recurring != nil {
+		arg.Recurring = *reqJSON.Recurring
+	}
+
+	if reqJSON.Amount.Valid {
+		arg.Amount = reqJSON.Amount.Decimal
+	}
+
+	budget, err := server.store.UpdateBudget(ctx, arg)
+	if err != nil {
+		ctx.JSON(budgetStatus(err), errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, budget)
+}
+
+func (server *Server) deleteBudget(ctx *gin.Context) {
+	var req budgetIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.getOwnedBudget(ctx, req.ID); !ok {
+		return
+	}
+
+	if err := server.store.DeleteBudget(ctx, req.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Budget %d has been deleted", req.ID)})
+}
+
+type getMonthBudgetRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getMonthBudget compares the budgets applying to a month with its checked and projected lines
+func (server *Server) getMonthBudget(ctx *gin.Context) {
+	var req getMonthBudgetRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	month, ok := server.getOwnedMonth(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	report, err := server.store.MonthBudgetTx(ctx, db.MonthBudgetTxParams{
+		Owner:   month.Owner,
+		MonthID: month.ID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,553 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func randomBudget(owner string, category db.Category, month db.Month) db.Budget {
+	return db.Budget{
+		ID:         util.RandomInt(1, 1000),
+		Owner:      owner,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Recurring:  util.RandomBool(),
+		Amount:     decimal.NewFromInt(util.RandomInt(10, 1000)),
+	}
+}
+
+func TestCreateBudgetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	category := randomCategory(user.Username)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	otherMonth := randomMonth(otherUser.Username, randomYear(otherUser.Username))
+	budget := randomBudget(user.Username, category, month)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"category_id": category.ID,
+				"month_id":    month.ID,
+				"recurring":   budget.Recurring,
+				"amount":      budget.Amount,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				arg := db.CreateBudgetParams{
+					Owner:      user.Username,
+					CategoryID: category.ID,
+					MonthID:    month.ID,
+					Recurring:  budget.Recurring,
+					Amount:     budget.Amount,
+				}
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(budget, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Budget
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, budget.ID, got.ID)
+				require.True(t, budget.Amount.Equal(got.Amount))
+			},
+		},
+		{
+			name: "NegativeAmount",
+			body: gin.H{
+				"category_id": category.ID,
+				"month_id":    month.ID,
+				"amount":      "-10",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedMonth",
+			body: gin.H{
+				"category_id": category.ID,
+				"month_id":    otherMonth.ID,
+				"amount":      budget.Amount,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(otherMonth.ID)).
+					Times(1).
+					Return(otherMonth, nil)
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "CategoryNotFound",
+			body: gin.H{
+				"category_id": category.ID,
+				"month_id":    month.ID,
+				"amount":      budget.Amount,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyBudgeted",
+			body: gin.H{
+				"category_id": category.ID,
+				"month_id":    month.ID,
+				"amount":      budget.Amount,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Budget{}, &pgconn.PgError{Code: "23505"})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			body: gin.H{
+				"category_id": category.ID,
+				"month_id":    month.ID,
+				"amount":      budget.Amount,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/budgets", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateBudgetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	budget := randomBudget(user.Username, randomCategory(user.Username), month)
+	amount := budget.Amount.Add(decimal.NewFromInt(50))
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"amount": amount},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudget(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				arg := db.UpdateBudgetParams{
+					ID:        budget.ID,
+					MonthID:   budget.MonthID,
+					Recurring: budget.Recurring,
+					Amount:    amount,
+				}
+				updated := budget
+				updated.Amount = amount
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ZeroAmount",
+			body: gin.H{"amount": "0"},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: gin.H{"amount": amount},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudget(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/budgets/%d", budget.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteBudgetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	budget := randomBudget(user.Username, randomCategory(user.Username), month)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudget(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					DeleteBudget(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudget(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(db.Budget{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/budgets/%d", budget.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetMonthBudgetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	category := randomCategory(user.Username)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	budget := randomBudget(user.Username, category, month)
+	report := db.MonthBudget{
+		MonthID: month.ID,
+		Budgets: []db.BudgetProgress{{
+			Budget:      budget,
+			Category:    category,
+			CategoryIDs: []int64{category.ID},
+			Spent:       decimal.NewFromInt(5),
+			Projected:   decimal.NewFromInt(8),
+			Remaining:   budget.Amount.Sub(decimal.NewFromInt(5)),
+		}},
+		Budgeted: budget.Amount,
+		Spent:    decimal.NewFromInt(5),
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					MonthBudgetTx(gomock.Any(), gomock.Eq(db.MonthBudgetTxParams{Owner: user.Username, MonthID: month.ID})).
+					Times(1).
+					Return(report, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.MonthBudget
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, month.ID, got.MonthID)
+				require.Len(t, got.Budgets, 1)
+				require.Equal(t, budget.ID, got.Budgets[0].Budget.ID)
+				require.True(t, report.Budgets[0].Remaining.Equal(got.Budgets[0].Remaining))
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					MonthBudgetTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(db.Month{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					MonthBudgetTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MonthBudget{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/months/%d/budget", month.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
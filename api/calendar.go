@@ -0,0 +1,243 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/exporter"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+)
+
+const (
+	// calendarDefaultMonths is how far ahead the feed looks when no horizon is given
+	calendarDefaultMonths = 3
+	calendarExtension     = ".ics"
+	calendarTokenBytes    = 32
+)
+
+type calendarTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// rotateCalendarToken creates the calendar feed token of the user, replacing the previous one
+func (server *Server) rotateCalendarToken(ctx *gin.Context) {
+	secret := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	calendarToken := base64.RawURLEncoding.EncodeToString(secret)
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpsertCalendarTokenParams{
+		Owner:     authPayload.Username,
+		TokenHash: hashCalendarToken(calendarToken),
+	}
+
+	if _, err := server.store.UpsertCalendarToken(ctx, arg); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, calendarTokenResponse{
+		Token: calendarToken,
+		URL:   "/calendar/" + calendarToken + calendarExtension,
+	})
+}
+
+// revokeCalendarToken disables the calendar feed of the user
+func (server *Server) revokeCalendarToken(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if err := server.store.DeleteCalendarToken(ctx, authPayload.Username); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Calendar token has been revoked"})
+}
+
+type calendarFeedUriRequest struct {
+	File string `uri:"file" binding:"required"`
+}
+
+type calendarFeedRequest struct {
+	Months int `form:"months" binding:"omitempty,min=1,max=24"`
+}
+
+// getCalendarFeed renders upcoming reclines and unchecked lines, the secret token is the only authentication
+func (server *Server) getCalendarFeed(ctx *gin.Context) {
+	var uriReq calendarFeedUriRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req calendarFeedRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Months == 0 {
+		req.Months = calendarDefaultMonths
+	}
+
+	calendarToken, ok := strings.CutSuffix(uriReq.File, calendarExtension)
+	if !ok || calendarToken == "" {
+		err := fmt.Errorf("calendar feed must end with %s", calendarExtension)
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	feed, err := server.store.GetCalendarTokenByHash(ctx, hashCalendarToken(calendarToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, feed.Owner)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	until := from.AddDate(0, req.Months, 0)
+
+	reclines, err := server.store.ListExplicitRecLines(ctx, feed.Owner)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines, err := server.store.ListUpcomingLines(ctx, db.ListUpcomingLinesParams{
+		Owner:    feed.Owner,
+		FromDate: from,
+		ToDate:   until,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	calendar := exporter.Calendar{Name: "Finance tracker"}
+	for _, recline := range reclines {
+		calendar.Events = append(calendar.Events, reclineEvents(recline, user.Currency, from, until)...)
+	}
+	for _, line := range lines {
+		calendar.Events = append(calendar.Events, exporter.CalendarEvent{
+			UID:         fmt.Sprintf("line-%d@finance_tracker", line.ID),
+			Date:        line.DueDate,
+			Summary:     eventSummary(line.Title, line.Amount.StringFixed(2), user.Currency, line.Account),
+			Description: eventDescription(line.Category, line.Description),
+		})
+	}
+
+	ctx.Header("Content-Type", "text/calendar; charset=utf-8")
+	ctx.Status(http.StatusOK)
+	if err := exporter.WriteCalendar(ctx.Writer, calendar, now); err != nil {
+		ctx.Error(err)
+	}
+}
+
+// reclineEvents returns the occurrences of a recline between from and until. A single
+// event with a recurrence rule is used unless calendars would skip some occurrences,
+// as a monthly rule on the 31st does, in which case every occurrence is listed.
+func reclineEvents(recline db.ListExplicitRecLinesRow, currency string, from, until time.Time) []exporter.CalendarEvent {
+	summary := eventSummary(recline.Title, recline.Amount.StringFixed(2), currency, recline.Account)
+	description := eventDescription(recline.Category, recline.Description)
+
+	if !util.IsSupportedRecurrency(recline.Recurrency) {
+		if recline.DueDate.Before(from) || !recline.DueDate.Before(until) {
+			return nil
+		}
+		return []exporter.CalendarEvent{{
+			UID:         fmt.Sprintf("recline-%d@finance_tracker", recline.ID),
+			Date:        recline.DueDate,
+			Summary:     summary,
+			Description: description,
+		}}
+	}
+
+	// Skip the occurrences already past
+	n := 0
+	for util.AddRecurrency(recline.DueDate, recline.Recurrency, n).Before(from) {
+		n++
+	}
+	first := util.AddRecurrency(recline.DueDate, recline.Recurrency, n)
+	if !first.Before(until) {
+		return nil
+	}
+
+	if freq, ok := recurrenceRule(recline.Recurrency, recline.DueDate); ok {
+		return []exporter.CalendarEvent{{
+			UID:         fmt.Sprintf("recline-%d@finance_tracker", recline.ID),
+			Date:        first,
+			Summary:     summary,
+			Description: description,
+			RRule:       fmt.Sprintf("FREQ=%s;UNTIL=%s", freq, exporter.ICalDate(until.AddDate(0, 0, -1))),
+		}}
+	}
+
+	var events []exporter.CalendarEvent
+	for date := first; date.Before(until); date = util.AddRecurrency(recline.DueDate, recline.Recurrency, n) {
+		events = append(events, exporter.CalendarEvent{
+			UID:         fmt.Sprintf("recline-%d-%s@finance_tracker", recline.ID, exporter.ICalDate(date)),
+			Date:        date,
+			Summary:     summary,
+			Description: description,
+		})
+		n++
+	}
+
+	return events
+}
+
+// recurrenceRule returns the RRULE frequency of a recurrency when calendars compute the same dates
+func recurrenceRule(recurrency string, dueDate time.Time) (string, bool) {
+	switch recurrency {
+	case util.WEEKLY:
+		return "WEEKLY", true
+	case util.MONTHLY:
+		return "MONTHLY", dueDate.Day() <= 28
+	case util.ANNUAL:
+		return "YEARLY", dueDate.Month() != time.February || dueDate.Day() != 29
+	}
+
+	return "", false
+}
+
+func eventSummary(title, amount, currency, account string) string {
+	return fmt.Sprintf("%s %s %s (%s)", title, amount, currency, account)
+}
+
+func eventDescription(category, description string) string {
+	if description == "" {
+		return category
+	}
+
+	return category + "\n" + description
+}
+
+// hashCalendarToken returns the value stored in place of the secret token
+func hashCalendarToken(calendarToken string) string {
+	sum := sha256.Sum256([]byte(calendarToken))
+	return hex.EncodeToString(sum[:])
+}
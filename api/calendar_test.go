@@ -0,0 +1,271 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeedAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	calendarToken := util.RandomString(32)
+	feed := db.CalendarToken{
+		Owner:     user.Username,
+		TokenHash: hashCalendarToken(calendarToken),
+	}
+
+	now := time.Now().UTC()
+	recline := db.ListExplicitRecLinesRow{
+		ID:         util.RandomInt(1, 1000),
+		Title:      "Rent",
+		Account:    "Checking",
+		Category:   "Housing",
+		Amount:     decimal.RequireFromString("-800"),
+		Recurrency: util.WEEKLY,
+		DueDate:    time.Date(now.Year()-1, now.Month(), 1, 0, 0, 0, 0, time.UTC),
+	}
+	line := db.ListUpcomingLinesRow{
+		ID:       util.RandomInt(1, 1000),
+		Title:    "Insurance",
+		Account:  "Checking",
+		Category: "Car",
+		Amount:   decimal.RequireFromString("-42.5"),
+		DueDate:  now.AddDate(0, 0, 3),
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		file          string
+		buildStubds   func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			file: calendarToken + ".ics",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCalendarTokenByHash(gomock.Any(), gomock.Eq(feed.TokenHash)).
+					Times(1).
+					Return(feed, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListExplicitRecLines(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.ListExplicitRecLinesRow{recline}, nil)
+				store.EXPECT().
+					ListUpcomingLines(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.ListUpcomingLinesParams) ([]db.ListUpcomingLinesRow, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, arg.FromDate.AddDate(0, calendarDefaultMonths, 0), arg.ToDate)
+						return []db.ListUpcomingLinesRow{line}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/calendar")
+
+				body := recorder.Body.String()
+				require.Contains(t, body, fmt.Sprintf("UID:recline-%d@finance_tracker\r\n", recline.ID))
+				require.Contains(t, body, "RRULE:FREQ=WEEKLY;UNTIL=")
+				require.Contains(t, body, fmt.Sprintf("SUMMARY:Rent -800.00 %s (Checking)\r\n", user.Currency))
+				require.Contains(t, body, fmt.Sprintf("UID:line-%d@finance_tracker\r\n", line.ID))
+				require.Contains(t, body, fmt.Sprintf("SUMMARY:Insurance -42.50 %s (Checking)\r\n", user.Currency))
+			},
+		},
+		{
+			name: "UnknownToken",
+			file: calendarToken + ".ics",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCalendarTokenByHash(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CalendarToken{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListExplicitRecLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotAnICSFile",
+			file: calendarToken,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCalendarTokenByHash(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/calendar/"+tc.file, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCalendarTokenAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		method        string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Rotate",
+			method: http.MethodPost,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertCalendarToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.UpsertCalendarTokenParams) (db.CalendarToken, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Len(t, arg.TokenHash, 64)
+						return db.CalendarToken{Owner: arg.Owner, TokenHash: arg.TokenHash}, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got calendarTokenResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.NotEmpty(t, got.Token)
+				require.Equal(t, "/calendar/"+got.Token+".ics", got.URL)
+			},
+		},
+		{
+			name:   "Revoke",
+			method: http.MethodDelete,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteCalendarToken(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "NoAuthorization",
+			method: http.MethodPost,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertCalendarToken(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(tc.method, "/api/calendar/token", nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReclineEvents(t *testing.T) {
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	until := from.AddDate(0, 3, 0)
+
+	recline := db.ListExplicitRecLinesRow{
+		ID:         7,
+		Title:      "Rent",
+		Account:    "Checking",
+		Amount:     decimal.RequireFromString("-800"),
+		Recurrency: util.MONTHLY,
+		DueDate:    time.Date(2023, 11, 5, 0, 0, 0, 0, time.UTC),
+	}
+
+	events := reclineEvents(recline, "EUR", from, until)
+	require.Len(t, events, 1)
+	require.Equal(t, time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), events[0].Date)
+	require.Equal(t, "FREQ=MONTHLY;UNTIL=20240414", events[0].RRule)
+
+	// A rule on the 31st would skip short months, every occurrence is listed instead
+	recline.DueDate = time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	events = reclineEvents(recline, "EUR", from, until)
+	require.Len(t, events, 3)
+	require.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), events[0].Date)
+	require.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), events[1].Date)
+	require.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), events[2].Date)
+	require.Empty(t, events[0].RRule)
+	require.NotEqual(t, events[0].UID, events[1].UID)
+
+	// Occurrences beyond the horizon are left out
+	recline.Recurrency = util.ANNUAL
+	recline.DueDate = time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	require.Empty(t, reclineEvents(recline, "EUR", from, until))
+}
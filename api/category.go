@@ -9,10 +9,31 @@ import (
 	"github.com/gin-gonic/gin"
 	db "github.com/moth13/finance_tracker/db/sqlc"
 	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
 )
 
 type createCategoryRequest struct {
-	Title string `json:"title" binding:"required"`
+	Title    string `json:"title" binding:"required"`
+	ParentID *int64 `json:"parent_id" binding:"omitempty,min=1"`
+	Kind     string `json:"kind"`
+	Color    string `json:"color" binding:"omitempty,hexcolor"`
+	Icon     string `json:"icon"`
+}
+
+// validCategoryStyle checks the kind and icon of a category, answering the request itself when they are invalid
+func validCategoryStyle(ctx *gin.Context, kind string, icon string) bool {
+	if !util.IsSupportedCategoryKind(kind) {
+		err := fmt.Errorf("unsupported category kind %q", kind)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+	if !util.IsValidIconKey(icon) {
+		err := fmt.Errorf("invalid icon key %q", icon)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+
+	return true
 }
 
 func (server *Server) createCategory(ctx *gin.Context) {
@@ -22,10 +43,28 @@ func (server *Server) createCategory(ctx *gin.Context) {
 		return
 	}
 
+	// Categories are expenses unless told otherwise
+	if req.Kind == "" {
+		req.Kind = util.EXPENSE
+	}
+	if !validCategoryStyle(ctx, req.Kind, req.Icon) {
+		return
+	}
+
+	if req.ParentID != nil {
+		if _, ok := server.getOwnedCategory(ctx, *req.ParentID); !ok {
+			return
+		}
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	arg := db.CreateCategoryParams{
-		Owner: authPayload.Username,
-		Title: req.Title,
+		Owner:    authPayload.Username,
+		Title:    req.Title,
+		ParentID: req.ParentID,
+		Kind:     req.Kind,
+		Color:    req.Color,
+		Icon:     req.Icon,
 	}
 
 	category, err := server.store.CreateCategory(ctx, arg)
@@ -37,6 +76,28 @@ func (server *Server) createCategory(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, category)
 }
 
+// getOwnedCategory reads a category, answering the request itself when the category can't be used
+func (server *Server) getOwnedCategory(ctx *gin.Context, id int64) (db.Category, bool) {
+	category, err := server.store.GetCategory(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return category, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return category, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if category.Owner != authPayload.Username {
+		err := errors.New("category doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return category, false
+	}
+
+	return category, true
+}
+
 type getCategoryRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
@@ -95,26 +156,308 @@ func (server *Server) listCategories(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, categories)
 }
 
+type updateCategoryIDRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateCategoryJSONRequest struct {
+	Title *string `json:"title" binding:"omitempty,min=1"`
+	Kind  *string `json:"kind"`
+	Color *string `json:"color" binding:"omitempty,hexcolor|len=0"`
+	Icon  *string `json:"icon"`
+}
+
+func (server *Server) updateCategory(ctx *gin.Context) {
+	var reqURI updateCategoryIDRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var reqJSON updateCategoryJSONRequest
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, ok := server.getOwnedCategory(ctx, reqURI.ID)
+	if !ok {
+		return
+	}
+
+	arg := db.UpdateCategoryParams{
+		ID:    category.ID,
+		Title: category.Title,
+		Kind:  category.Kind,
+		Color: category.Color,
+		Icon:  category.Icon,
+	}
+
+	// Overload when needs it, an empty color or icon goes back to the default one
+	if reqJSON.Title != nil {
+		arg.Title = *reqJSON.Title
+	}
+
+	if reqJSON.Kind != nil {
+		arg.Kind = *reqJSON.Kind
+	}
+
+	if reqJSON.Color != nil {
+		arg.Color = *reqJSON.Color
+	}
+
+	if reqJSON.Icon != nil {
+		arg.Icon = *reqJSON.Icon
+	}
+
+	if !validCategoryStyle(ctx, arg.Kind, arg.Icon) {
+		return
+	}
+
+	category, err := server.store.UpdateCategory(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+type mergeCategoryIDRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type mergeCategoryRequest struct {
+	TargetID int64 `json:"target_id" binding:"required,min=1"`
+}
+
+// mergeCategory moves everything using a category to another one and deletes it
+func (server *Server) mergeCategory(ctx *gin.Context) {
+	var reqURI mergeCategoryIDRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req mergeCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.MergeCategoryTxParams{
+		SourceID: reqURI.ID,
+		TargetID: req.TargetID,
+	}
+	if !server.validCategoryReplacement(ctx, arg.SourceID, arg.TargetID) {
+		return
+	}
+
+	result, err := server.store.MergeCategoryTx(ctx, arg)
+	if err != nil {
+		server.categoryMergeError(ctx, err)
+		return
+	}
+	server.suggester.invalidate(result.Category.Owner)
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// validCategoryReplacement checks that a category can be replaced by another one of the same user
+func (server *Server) validCategoryReplacement(ctx *gin.Context, id int64, replacementID int64) bool {
+	if id == replacementID {
+		err := errors.New("a category can't be replaced by itself")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+
+	if _, ok := server.getOwnedCategory(ctx, id); !ok {
+		return false
+	}
+	_, ok := server.getOwnedCategory(ctx, replacementID)
+	return ok
+}
+
+func (server *Server) categoryMergeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, db.ErrCategoryCycle):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	case errors.Is(err, db.ErrCategoryInUse):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
 type deleteCategoryRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
+type deleteCategoryQueryRequest struct {
+	ReplacementID *int64 `form:"replacement_id" binding:"omitempty,min=1"`
+}
+
+// deleteCategory deletes a category, one still in use needs a replacement which receives its lines, reclines and rules
 func (server *Server) deleteCategory(ctx *gin.Context) {
 	var req deleteCategoryRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	var reqQuery deleteCategoryQueryRequest
+	if err := ctx.ShouldBindQuery(&reqQuery); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if reqQuery.ReplacementID != nil {
+		if !server.validCategoryReplacement(ctx, req.ID, *reqQuery.ReplacementID) {
+			return
+		}
+	} else if _, ok := server.getOwnedCategory(ctx, req.ID); !ok {
+		return
+	}
+
+	arg := db.DeleteCategoryTxParams{
+		ID:            req.ID,
+		ReplacementID: reqQuery.ReplacementID,
+	}
 
-	err := server.store.DeleteCategory(ctx, req.ID)
+	result, err := server.store.DeleteCategoryTx(ctx, arg)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		server.categoryMergeError(ctx, err)
+		return
+	}
+	if reqQuery.ReplacementID != nil {
+		server.suggester.invalidate(result.Category.Owner)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Category %d has been deleted", req.ID)})
+}
+
+type categoryTreeRequest struct {
+	AccountID *int64 `form:"account_id" binding:"omitempty,min=1"`
+	MonthID   *int64 `form:"month_id" binding:"omitempty,min=1"`
+	YearID    *int64 `form:"year_id" binding:"omitempty,min=1"`
+}
+
+// getCategoryTree lists the categories as a tree, parents totalling the lines of their children
+func (server *Server) getCategoryTree(ctx *gin.Context) {
+	var req categoryTreeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CategoryTreeTxParams{
+		Owner:     authPayload.Username,
+		AccountID: req.AccountID,
+		MonthID:   req.MonthID,
+		YearID:    req.YearID,
+	}
+
+	tree, err := server.store.CategoryTreeTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tree)
+}
+
+type moveCategoryIDRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type moveCategoryRequest struct {
+	// ParentID is the new parent, null moves the category to the top of the tree
+	ParentID *int64 `json:"parent_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) moveCategory(ctx *gin.Context) {
+	var reqURI moveCategoryIDRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req moveCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.getOwnedCategory(ctx, reqURI.ID); !ok {
+		return
+	}
+	if req.ParentID != nil {
+		if _, ok := server.getOwnedCategory(ctx, *req.ParentID); !ok {
+			return
+		}
+	}
+
+	arg := db.MoveCategoryTxParams{
+		ID:       reqURI.ID,
+		ParentID: req.ParentID,
+	}
+
+	category, err := server.store.MoveCategoryTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrCategoryCycle) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Category %d has been deleted", req.ID)})
+	ctx.JSON(http.StatusOK, category)
+}
+
+type seedCategoriesRequest struct {
+	// Locale defaults to the one of the authenticated user
+	Locale string `form:"locale"`
+	Reset  bool   `form:"reset"`
+}
+
+// seedCategories gives back the default categories the authenticated user is missing, a reset also restores their look
+func (server *Server) seedCategories(ctx *gin.Context) {
+	var req seedCategoriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if req.Locale == "" {
+		user, err := server.store.GetUser(ctx, authPayload.Username)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		req.Locale = user.Locale
+	}
+	if !util.IsSupportedLocale(req.Locale) {
+		err := fmt.Errorf("unsupported locale %q", req.Locale)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.SeedCategoriesTxParams{
+		Owner:  authPayload.Username,
+		Locale: req.Locale,
+		Reset:  req.Reset,
+	}
+
+	result, err := server.store.SeedCategoriesTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
 }
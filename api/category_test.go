@@ -11,17 +11,25 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	mockdb "github.com/moth13/finance_tracker/db/mock"
 	db "github.com/moth13/finance_tracker/db/sqlc"
 	"github.com/moth13/finance_tracker/token"
 	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
 func TestCreateCategoryAPI(t *testing.T) {
 	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
 	category := randomCategory(user.Username)
+	category.Kind = util.INCOME
+	category.Color = "#2e7d32"
+	category.Icon = "salary"
+	child := randomChildCategory(user.Username, category)
+	otherCategory := randomCategory(otherUser.Username)
 
 	// Test cases definition
 	testCases := []struct {
@@ -35,11 +43,17 @@ func TestCreateCategoryAPI(t *testing.T) {
 			name: "OK",
 			body: createCategoryRequest{
 				Title: category.Title,
+				Kind:  category.Kind,
+				Color: category.Color,
+				Icon:  category.Icon,
 			},
 			buildStubds: func(store *mockdb.MockStore) {
 				arg := db.CreateCategoryParams{
 					Owner: category.Owner,
 					Title: category.Title,
+					Kind:  category.Kind,
+					Color: category.Color,
+					Icon:  category.Icon,
 				}
 
 				store.EXPECT().
@@ -55,6 +69,113 @@ func TestCreateCategoryAPI(t *testing.T) {
 				requireBodyMatchCategory(t, recorder.Body, category)
 			},
 		},
+		{
+			name: "WithParent",
+			body: createCategoryRequest{
+				Title:    child.Title,
+				ParentID: &category.ID,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+
+				arg := db.CreateCategoryParams{
+					Owner:    child.Owner,
+					Title:    child.Title,
+					ParentID: &category.ID,
+					Kind:     util.EXPENSE,
+				}
+				store.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(child, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategory(t, recorder.Body, child)
+			},
+		},
+		{
+			name: "UnauthorizedParent",
+			body: createCategoryRequest{
+				Title:    child.Title,
+				ParentID: &otherCategory.ID,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(otherCategory.ID)).
+					Times(1).
+					Return(otherCategory, nil)
+				store.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidKind",
+			body: createCategoryRequest{
+				Title: category.Title,
+				Kind:  "GIFT",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidColor",
+			body: createCategoryRequest{
+				Title: category.Title,
+				Color: "green",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidIcon",
+			body: createCategoryRequest{
+				Title: category.Title,
+				Icon:  "Piggy Bank",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "InvalidTitle",
 			body: createCategoryRequest{
@@ -62,7 +183,851 @@ func TestCreateCategoryAPI(t *testing.T) {
 			},
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateCategory(gomock.Any(), gomock.Any()).
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/api/categories"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteCategoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	category := randomCategory(user.Username)
+	replacement := randomCategory(user.Username)
+	replacement.ID = category.ID + 1
+	otherCategory := randomCategory(otherUser.Username)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		categoryID    int64
+		query         string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			categoryID: category.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Eq(db.DeleteCategoryTxParams{ID: category.ID})).
+					Times(1).
+					Return(db.MergeCategoryTxResult{}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "InUse",
+			categoryID: category.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MergeCategoryTxResult{}, db.ErrCategoryInUse)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "WithReplacement",
+			categoryID: category.ID,
+			query:      fmt.Sprintf("replacement_id=%d", replacement.ID),
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(replacement.ID)).
+					Times(1).
+					Return(replacement, nil)
+
+				arg := db.DeleteCategoryTxParams{
+					ID:            category.ID,
+					ReplacementID: &replacement.ID,
+				}
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.MergeCategoryTxResult{Category: replacement, Lines: 3}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "SelfReplacement",
+			categoryID: category.ID,
+			query:      fmt.Sprintf("replacement_id=%d", category.ID),
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "UnauthorizedReplacement",
+			categoryID: category.ID,
+			query:      fmt.Sprintf("replacement_id=%d", otherCategory.ID),
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(otherCategory.ID)).
+					Times(1).
+					Return(otherCategory, nil)
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "Unauthorized",
+			categoryID: category.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			categoryID: -1,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "NotFound",
+			categoryID: category.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalServerError",
+			categoryID: category.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					DeleteCategoryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MergeCategoryTxResult{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/categories/%d?%s", tc.categoryID, tc.query)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateCategoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	category := randomCategory(user.Username)
+	updated := category
+	updated.Title = util.RandomTitle()
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		categoryID    int64
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			categoryID: category.ID,
+			body:       gin.H{"title": updated.Title},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+
+				arg := db.UpdateCategoryParams{
+					ID:    category.ID,
+					Title: updated.Title,
+					Kind:  category.Kind,
+				}
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategory(t, recorder.Body, updated)
+			},
+		},
+		{
+			name:       "Style",
+			categoryID: category.ID,
+			body:       gin.H{"kind": util.SAVINGS, "color": "#6a1b9a", "icon": "piggy-bank"},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+
+				arg := db.UpdateCategoryParams{
+					ID:    category.ID,
+					Title: category.Title,
+					Kind:  util.SAVINGS,
+					Color: "#6a1b9a",
+					Icon:  "piggy-bank",
+				}
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Category{ID: category.ID, Owner: category.Owner, Title: category.Title, Kind: arg.Kind, Color: arg.Color, Icon: arg.Icon}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidKind",
+			categoryID: category.ID,
+			body:       gin.H{"kind": "GIFT"},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "EmptyTitle",
+			categoryID: category.ID,
+			body:       gin.H{"title": ""},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "Unauthorized",
+			categoryID: category.ID,
+			body:       gin.H{"title": updated.Title},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "NotFound",
+			categoryID: category.ID,
+			body:       gin.H{"title": updated.Title},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalServerError",
+			categoryID: category.ID,
+			body:       gin.H{"title": updated.Title},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Category{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/categories/%d", tc.categoryID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestMergeCategoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	source := randomCategory(user.Username)
+	target := randomCategory(user.Username)
+	target.ID = source.ID + 1
+	otherCategory := randomCategory(otherUser.Username)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"target_id": target.ID},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(source.ID)).
+					Times(1).
+					Return(source, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(target.ID)).
+					Times(1).
+					Return(target, nil)
+
+				arg := db.MergeCategoryTxParams{
+					SourceID: source.ID,
+					TargetID: target.ID,
+				}
+				store.EXPECT().
+					MergeCategoryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.MergeCategoryTxResult{Category: target, Lines: 4, RecLines: 1}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.MergeCategoryTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+				require.Equal(t, target.ID, result.Category.ID)
+				require.Equal(t, int64(4), result.Lines)
+				require.Equal(t, int64(1), result.RecLines)
+			},
+		},
+		{
+			name: "Cycle",
+			body: gin.H{"target_id": target.ID},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(source.ID)).
+					Times(1).
+					Return(source, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(target.ID)).
+					Times(1).
+					Return(target, nil)
+				store.EXPECT().
+					MergeCategoryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MergeCategoryTxResult{}, db.ErrCategoryCycle)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SameCategory",
+			body: gin.H{"target_id": source.ID},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MergeCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingTarget",
+			body: gin.H{},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MergeCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedTarget",
+			body: gin.H{"target_id": otherCategory.ID},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(source.ID)).
+					Times(1).
+					Return(source, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(otherCategory.ID)).
+					Times(1).
+					Return(otherCategory, nil)
+				store.EXPECT().
+					MergeCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalServerError",
+			body: gin.H{"target_id": target.ID},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ any, id int64) (db.Category, error) {
+						if id == source.ID {
+							return source, nil
+						}
+						return target, nil
+					})
+				store.EXPECT().
+					MergeCategoryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MergeCategoryTxResult{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/categories/%d/merge", source.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetCategoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user.Username)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		categoryID    int64
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			categoryID: category.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategory(t, recorder.Body, category)
+			},
+		},
+		{
+			name:       "NotFound",
+			categoryID: category.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Category{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalServerError",
+			categoryID: category.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Category{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidCategoryID",
+			categoryID: 0,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/categories/%d", tc.categoryID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListCategoriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	n := 5
+	categories := make([]db.Category, n)
+	for i := 0; i < n; i++ {
+		categories[i] = randomCategory(user.Username)
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		query         listCategoriesRequest
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: listCategoriesRequest{
+				PageID:   1,
+				PageSize: int32(n),
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				arg := db.ListCategoriesParams{
+					Owner:  user.Username,
+					Offset: 0,
+					Limit:  int32(n),
+				}
+				store.EXPECT().
+					ListCategories(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(categories, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategories(t, recorder.Body, categories)
+			},
+		},
+		{
+			name: "InternalError",
+			query: listCategoriesRequest{
+				PageID:   1,
+				PageSize: int32(n),
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListCategories(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Category{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPageSize",
+			query: listCategoriesRequest{
+				PageID:   1,
+				PageSize: 2,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListCategories(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPageIndex",
+			query: listCategoriesRequest{
+				PageID:   -1,
+				PageSize: int32(n),
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListCategories(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -89,12 +1054,13 @@ func TestCreateCategoryAPI(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			data, err := json.Marshal(tc.body)
+			request, err := http.NewRequest(http.MethodGet, "/api/categories", nil)
 			require.NoError(t, err)
 
-			url := "/api/categories"
-			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
-			require.NoError(t, err)
+			q := request.URL.Query()
+			q.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			q.Add("page_size", fmt.Sprintf("%d", tc.query.PageSize))
+			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
 			server.router.ServeHTTP(recorder, request)
@@ -103,40 +1069,110 @@ func TestCreateCategoryAPI(t *testing.T) {
 	}
 }
 
-func TestDeleteCategoryAPI(t *testing.T) {
+func randomCategory(owner string) db.Category {
+	return db.Category{
+		ID:    util.RandomInt(1, 1000),
+		Owner: owner,
+		Title: util.RandomTitle(),
+		Kind:  util.EXPENSE,
+	}
+}
+
+func randomChildCategory(owner string, parent db.Category) db.Category {
+	category := randomCategory(owner)
+	category.ID = parent.ID + 1
+	category.ParentID = &parent.ID
+	return category
+}
+
+func requireBodyMatchCategory(t *testing.T, body *bytes.Buffer, category db.Category) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotCategory db.Category
+	err = json.Unmarshal(data, &gotCategory)
+	require.NoError(t, err)
+
+	// Have to check each field as decimal.Decimal have to be compared each other directly
+	require.Equal(t, category.ID, gotCategory.ID)
+	require.Equal(t, category.Owner, gotCategory.Owner)
+	require.Equal(t, category.Title, gotCategory.Title)
+	require.Equal(t, category.ParentID, gotCategory.ParentID)
+	require.Equal(t, category.Kind, gotCategory.Kind)
+	require.Equal(t, category.Color, gotCategory.Color)
+	require.Equal(t, category.Icon, gotCategory.Icon)
+}
+
+func requireBodyMatchCategories(t *testing.T, body *bytes.Buffer, categories []db.Category) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotCategories []db.Category
+	err = json.Unmarshal(data, &gotCategories)
+	require.NoError(t, err)
+
+	for i, category := range categories {
+		gotCategory := gotCategories[i]
+		// Have to check each field as decimal.Decimal have to be compared each other directly
+		require.Equal(t, category.ID, gotCategory.ID)
+		require.Equal(t, category.Owner, gotCategory.Owner)
+		require.Equal(t, category.Title, gotCategory.Title)
+
+	}
+}
+
+func TestGetCategoryTreeAPI(t *testing.T) {
 	user, _ := randomUser(t)
-	category := randomCategory(user.Username)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	parent := randomCategory(user.Username)
+	child := randomChildCategory(user.Username, parent)
+	tree := db.BuildCategoryTree([]db.Category{parent, child}, map[int64]decimal.Decimal{
+		parent.ID: decimal.NewFromInt(-10),
+		child.ID:  decimal.NewFromInt(-90),
+	})
 
 	// Test cases definition
 	testCases := []struct {
 		name          string
-		categoryID    int64
+		query         string
 		buildStubds   func(store *mockdb.MockStore)
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:       "OK",
-			categoryID: category.ID,
+			name:  "OK",
+			query: fmt.Sprintf("month_id=%d", month.ID),
 			buildStubds: func(store *mockdb.MockStore) {
+				arg := db.CategoryTreeTxParams{
+					Owner:   user.Username,
+					MonthID: &month.ID,
+				}
 				store.EXPECT().
-					DeleteCategory(gomock.Any(), gomock.Eq(category.ID)).
+					CategoryTreeTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(nil)
+					Return(tree, nil)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotTree []*db.CategoryNode
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &gotTree))
+				require.Len(t, gotTree, 1)
+				require.Equal(t, parent.ID, gotTree[0].ID)
+				require.True(t, gotTree[0].Total.Equal(decimal.NewFromInt(-100)))
+				require.Len(t, gotTree[0].Children, 1)
+				require.Equal(t, child.ID, gotTree[0].Children[0].ID)
 			},
 		},
 		{
-			name:       "InvalidID",
-			categoryID: -1,
+			name:  "InvalidMonthID",
+			query: "month_id=0",
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteCategory(gomock.Any(), gomock.Eq(category.ID)).
+					CategoryTreeTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -147,29 +1183,27 @@ func TestDeleteCategoryAPI(t *testing.T) {
 			},
 		},
 		{
-			name:       "NotFound",
-			categoryID: category.ID,
+			name:  "NoAuthorization",
+			query: "",
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteCategory(gomock.Any(), gomock.Any()).
-					Times(1).
-					Return(sql.ErrNoRows)
+					CategoryTreeTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 		{
-			name:       "InternalServerError",
-			categoryID: category.ID,
+			name:  "InternalError",
+			query: "",
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					DeleteCategory(gomock.Any(), gomock.Any()).
+					CategoryTreeTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(sql.ErrConnDone)
+					Return(nil, sql.ErrConnDone)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
@@ -195,8 +1229,8 @@ func TestDeleteCategoryAPI(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/api/categories/%d", tc.categoryID)
-			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			url := "/api/categories/tree?" + tc.query
+			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -206,14 +1240,21 @@ func TestDeleteCategoryAPI(t *testing.T) {
 	}
 }
 
-func TestGetCategoryAPI(t *testing.T) {
+func TestMoveCategoryAPI(t *testing.T) {
 	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	parent := randomCategory(user.Username)
 	category := randomCategory(user.Username)
+	category.ID = parent.ID + 1
+	moved := category
+	moved.ParentID = &parent.ID
+	otherCategory := randomCategory(otherUser.Username)
 
 	// Test cases definition
 	testCases := []struct {
 		name          string
 		categoryID    int64
+		body          gin.H
 		buildStubds   func(store *mockdb.MockStore)
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
@@ -221,10 +1262,46 @@ func TestGetCategoryAPI(t *testing.T) {
 		{
 			name:       "OK",
 			categoryID: category.ID,
+			body:       gin.H{"parent_id": parent.ID},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(parent.ID)).
+					Times(1).
+					Return(parent, nil)
+
+				arg := db.MoveCategoryTxParams{
+					ID:       category.ID,
+					ParentID: &parent.ID,
+				}
+				store.EXPECT().
+					MoveCategoryTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(moved, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategory(t, recorder.Body, moved)
+			},
+		},
+		{
+			name:       "ToTop",
+			categoryID: category.ID,
+			body:       gin.H{"parent_id": nil},
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
 					Times(1).
+					Return(moved, nil)
+				store.EXPECT().
+					MoveCategoryTx(gomock.Any(), gomock.Eq(db.MoveCategoryTxParams{ID: category.ID})).
+					Times(1).
 					Return(category, nil)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -236,43 +1313,81 @@ func TestGetCategoryAPI(t *testing.T) {
 			},
 		},
 		{
-			name:       "NotFound",
+			name:       "Cycle",
+			categoryID: parent.ID,
+			body:       gin.H{"parent_id": category.ID},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(parent.ID)).
+					Times(1).
+					Return(parent, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(moved, nil)
+				store.EXPECT().
+					MoveCategoryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Category{}, db.ErrCategoryCycle)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "UnauthorizedParent",
 			categoryID: category.ID,
+			body:       gin.H{"parent_id": otherCategory.ID},
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetCategory(gomock.Any(), gomock.Any()).
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
 					Times(1).
-					Return(db.Category{}, sql.ErrNoRows)
+					Return(category, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(otherCategory.ID)).
+					Times(1).
+					Return(otherCategory, nil)
+				store.EXPECT().
+					MoveCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, recorder.Code)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 		{
-			name:       "InternalServerError",
+			name:       "NotFound",
 			categoryID: category.ID,
+			body:       gin.H{"parent_id": parent.ID},
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetCategory(gomock.Any(), gomock.Any()).
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
 					Times(1).
-					Return(db.Category{}, sql.ErrConnDone)
+					Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().
+					MoveCategoryTx(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 		{
-			name:       "InvalidCategoryID",
-			categoryID: 0,
+			name:       "InvalidParentID",
+			categoryID: category.ID,
+			body:       gin.H{"parent_id": 0},
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetCategory(gomock.Any(), gomock.Any()).
+					MoveCategoryTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -299,8 +1414,11 @@ func TestGetCategoryAPI(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/api/categories/%d", tc.categoryID)
-			request, err := http.NewRequest(http.MethodGet, url, nil)
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/categories/%d/move", tc.categoryID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -310,75 +1428,79 @@ func TestGetCategoryAPI(t *testing.T) {
 	}
 }
 
-func TestListCategoriesAPI(t *testing.T) {
+func TestSeedCategoriesAPI(t *testing.T) {
 	user, _ := randomUser(t)
-	n := 5
-	categories := make([]db.Category, n)
-	for i := 0; i < n; i++ {
-		categories[i] = randomCategory(user.Username)
-	}
+	user.Locale = util.FR
+	category := randomCategory(user.Username)
 
 	// Test cases definition
 	testCases := []struct {
 		name          string
-		query         listCategoriesRequest
+		query         string
 		buildStubds   func(store *mockdb.MockStore)
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name: "OK",
-			query: listCategoriesRequest{
-				PageID:   1,
-				PageSize: int32(n),
-			},
+			name: "UserLocale",
 			buildStubds: func(store *mockdb.MockStore) {
-				arg := db.ListCategoriesParams{
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				arg := db.SeedCategoriesTxParams{
 					Owner:  user.Username,
-					Offset: 0,
-					Limit:  int32(n),
+					Locale: util.FR,
 				}
 				store.EXPECT().
-					ListCategories(gomock.Any(), gomock.Eq(arg)).
+					SeedCategoriesTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(categories, nil)
+					Return(db.SeedCategoriesTxResult{Created: []db.Category{category}, Restored: []db.Category{}}, nil)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchCategories(t, recorder.Body, categories)
+
+				var result db.SeedCategoriesTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+				require.Len(t, result.Created, 1)
+				require.Equal(t, category.ID, result.Created[0].ID)
 			},
 		},
 		{
-			name: "InternalError",
-			query: listCategoriesRequest{
-				PageID:   1,
-				PageSize: int32(n),
-			},
+			name:  "Reset",
+			query: "locale=en&reset=true",
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					ListCategories(gomock.Any(), gomock.Any()).
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				arg := db.SeedCategoriesTxParams{
+					Owner:  user.Username,
+					Locale: util.EN,
+					Reset:  true,
+				}
+				store.EXPECT().
+					SeedCategoriesTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return([]db.Category{}, sql.ErrConnDone)
+					Return(db.SeedCategoriesTxResult{Created: []db.Category{}, Restored: []db.Category{category}}, nil)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name: "InvalidPageSize",
-			query: listCategoriesRequest{
-				PageID:   1,
-				PageSize: 2,
-			},
+			name:  "UnsupportedLocale",
+			query: "locale=de",
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					ListCategories(gomock.Any(), gomock.Any()).
+					SeedCategoriesTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -389,21 +1511,32 @@ func TestListCategoriesAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageIndex",
-			query: listCategoriesRequest{
-				PageID:   -1,
-				PageSize: int32(n),
-			},
+			name: "NoAuthorization",
 			buildStubds: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					ListCategories(gomock.Any(), gomock.Any()).
+					SeedCategoriesTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "locale=fr",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SeedCategoriesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SeedCategoriesTxResult{}, sql.ErrConnDone)
+			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 	}
@@ -423,57 +1556,13 @@ func TestListCategoriesAPI(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			request, err := http.NewRequest(http.MethodGet, "/api/categories", nil)
+			url := "/api/categories/defaults?" + tc.query
+			request, err := http.NewRequest(http.MethodPost, url, nil)
 			require.NoError(t, err)
 
-			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.PageSize))
-			request.URL.RawQuery = q.Encode()
-
 			tc.setupAuth(t, request, server.tokenMaker)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(t, recorder)
 		})
 	}
 }
-
-func randomCategory(owner string) db.Category {
-	return db.Category{
-		ID:    util.RandomInt(1, 1000),
-		Owner: owner,
-		Title: util.RandomTitle(),
-	}
-}
-
-func requireBodyMatchCategory(t *testing.T, body *bytes.Buffer, category db.Category) {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
-
-	var gotCategory db.Category
-	err = json.Unmarshal(data, &gotCategory)
-	require.NoError(t, err)
-
-	// Have to check each field as decimal.Decimal have to be compared each other directly
-	require.Equal(t, category.ID, gotCategory.ID)
-	require.Equal(t, category.Owner, gotCategory.Owner)
-	require.Equal(t, category.Title, gotCategory.Title)
-}
-
-func requireBodyMatchCategories(t *testing.T, body *bytes.Buffer, categories []db.Category) {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
-
-	var gotCategories []db.Category
-	err = json.Unmarshal(data, &gotCategories)
-	require.NoError(t, err)
-
-	for i, category := range categories {
-		gotCategory := gotCategories[i]
-		// Have to check each field as decimal.Decimal have to be compared each other directly
-		require.Equal(t, category.ID, gotCategory.ID)
-		require.Equal(t, category.Owner, gotCategory.Owner)
-		require.Equal(t, category.Title, gotCategory.Title)
-
-	}
-}
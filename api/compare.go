@@ -0,0 +1,227 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/views"
+	"github.com/moth13/finance_tracker/views/components"
+)
+
+type comparePeriodsRequest struct {
+	BaseID    int64 `form:"base_id" binding:"required,min=1"`
+	CompareID int64 `form:"compare_id" binding:"required,min=1"`
+}
+
+// compareMonths compares the lines of a month to those of a base month
+func (server *Server) compareMonths(ctx *gin.Context) {
+	var req comparePeriodsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	base, ok := server.getOwnedMonth(ctx, req.BaseID)
+	if !ok {
+		return
+	}
+	compare, ok := server.getOwnedMonth(ctx, req.CompareID)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CompareMonthsTxParams{
+		Owner:   authPayload.Username,
+		Base:    base,
+		Compare: compare,
+	}
+
+	comparison, err := server.store.CompareMonthsTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comparison)
+}
+
+// compareYears compares the lines of a year to those of a base year
+func (server *Server) compareYears(ctx *gin.Context) {
+	var req comparePeriodsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	base, ok := server.getOwnedYear(ctx, req.BaseID)
+	if !ok {
+		return
+	}
+	compare, ok := server.getOwnedYear(ctx, req.CompareID)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CompareYearsTxParams{
+		Owner:   authPayload.Username,
+		Base:    base,
+		Compare: compare,
+	}
+
+	comparison, err := server.store.CompareYearsTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comparison)
+}
+
+// comparePage lets two months or two years be picked and compared
+func (server *Server) comparePage(ctx *gin.Context) {
+	months, err := server.store.ListMonths(ctx, db.ListMonthsParams{Owner: viewOwner, Limit: exportPageSize})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	years, err := server.store.ListYears(ctx, db.ListYearsParams{Owner: viewOwner, Limit: exportPageSize})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var options views.CompareOptions
+	for _, month := range months {
+		options.Months = append(options.Months, views.PeriodOption{ID: month.ID, Title: month.Title})
+	}
+	for _, year := range years {
+		options.Years = append(options.Years, views.PeriodOption{ID: year.ID, Title: year.Title})
+	}
+
+	err = server.render(ctx, http.StatusOK, views.Layout(views.Compare(options), "compare", "/views/compare"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
+
+// getViewCompareMonths renders the comparison of two months into the compare page
+func (server *Server) getViewCompareMonths(ctx *gin.Context) {
+	var req comparePeriodsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var months [2]db.Month
+	for i, id := range []int64{req.BaseID, req.CompareID} {
+		month, err := server.store.GetMonth(ctx, id)
+		if err == nil && month.Owner != viewOwner {
+			err = sql.ErrNoRows
+		}
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		months[i] = month
+	}
+
+	comparison, err := server.store.CompareMonthsTx(ctx, db.CompareMonthsTxParams{
+		Owner:   viewOwner,
+		Base:    months[0],
+		Compare: months[1],
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err := server.render(ctx, http.StatusOK, components.ComparisonComponent(viewComparison(comparison))); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
+
+// getViewCompareYears renders the comparison of two years into the compare page
+func (server *Server) getViewCompareYears(ctx *gin.Context) {
+	var req comparePeriodsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var years [2]db.Year
+	for i, id := range []int64{req.BaseID, req.CompareID} {
+		year, err := server.store.GetYear(ctx, id)
+		if err == nil && year.Owner != viewOwner {
+			err = sql.ErrNoRows
+		}
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		years[i] = year
+	}
+
+	comparison, err := server.store.CompareYearsTx(ctx, db.CompareYearsTxParams{
+		Owner:   viewOwner,
+		Base:    years[0],
+		Compare: years[1],
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err := server.render(ctx, http.StatusOK, components.ComparisonComponent(viewComparison(comparison))); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
+
+// viewComparison turns a comparison into the rows of its view
+func viewComparison(comparison db.PeriodComparison) components.Comparison {
+	row := func(label, status string, amount db.ComparedAmount) components.ComparisonRow {
+		return components.ComparisonRow{
+			Label:   label,
+			Status:  status,
+			Base:    amount.Base,
+			Compare: amount.Compare,
+			Delta:   amount.Delta,
+			Percent: amount.Percent,
+		}
+	}
+
+	result := components.Comparison{
+		Base:    comparison.Base.Title,
+		Compare: comparison.Compare.Title,
+		Totals: []components.ComparisonRow{
+			row("Income", "", comparison.Income),
+			row("Expense", "", comparison.Expense),
+			row("Net", "", comparison.Net),
+		},
+	}
+	for _, category := range comparison.Categories {
+		result.Categories = append(result.Categories, row(category.Category, category.Status, category.ComparedAmount))
+	}
+	for _, line := range comparison.Lines {
+		result.Lines = append(result.Lines, row(fmt.Sprintf("%s (%s)", line.Title, line.Category), "", line.ComparedAmount))
+	}
+
+	return result
+}
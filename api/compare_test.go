@@ -0,0 +1,401 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareMonthsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	year := randomYear(user.Username)
+	base := randomMonth(user.Username, year)
+	compare := randomMonth(user.Username, year)
+	compare.ID = base.ID + 1
+	comparison := db.BuildPeriodComparison(db.ComparedPeriod{ID: base.ID}, db.ComparedPeriod{ID: compare.ID}, nil, []db.SumLinesByTitleRow{{
+		CategoryID: 1,
+		Title:      "Groceries",
+		Count:      2,
+		Expense:    decimal.NewFromInt(120),
+	}})
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		baseID        int64
+		compareID     int64
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(base.ID)).
+					Times(1).
+					Return(base, nil)
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(compare.ID)).
+					Times(1).
+					Return(compare, nil)
+				arg := db.CompareMonthsTxParams{
+					Owner:   user.Username,
+					Base:    base,
+					Compare: compare,
+				}
+				store.EXPECT().
+					CompareMonthsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(comparison, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.PeriodComparison
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, compare.ID, got.Compare.ID)
+				require.True(t, decimal.NewFromInt(120).Equal(got.Expense.Delta))
+				require.Len(t, got.New, 1)
+				require.Equal(t, db.CategoryNew, got.New[0].Status)
+			},
+		},
+		{
+			name:      "NotFound",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(base.ID)).
+					Times(1).
+					Return(base, nil)
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(compare.ID)).
+					Times(1).
+					Return(db.Month{}, sql.ErrNoRows)
+				store.EXPECT().
+					CompareMonthsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "UnauthorizedMonth",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(base.ID)).
+					Times(1).
+					Return(base, nil)
+				store.EXPECT().
+					CompareMonthsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidID",
+			baseID:    0,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CompareMonthsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ any, id int64) (db.Month, error) {
+						if id == base.ID {
+							return base, nil
+						}
+						return compare, nil
+					})
+				store.EXPECT().
+					CompareMonthsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PeriodComparison{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CompareMonthsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s?base_id=%d&compare_id=%d", "/api/reports/months/compare", tc.baseID, tc.compareID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCompareYearsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	base := randomYear(user.Username)
+	compare := randomYear(user.Username)
+	compare.ID = base.ID + 1
+	comparison := db.BuildPeriodComparison(db.ComparedPeriod{ID: base.ID}, db.ComparedPeriod{ID: compare.ID}, nil, []db.SumLinesByTitleRow{{
+		CategoryID: 1,
+		Title:      "Groceries",
+		Count:      2,
+		Expense:    decimal.NewFromInt(120),
+	}})
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		baseID        int64
+		compareID     int64
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetYear(gomock.Any(), gomock.Eq(base.ID)).
+					Times(1).
+					Return(base, nil)
+				store.EXPECT().
+					GetYear(gomock.Any(), gomock.Eq(compare.ID)).
+					Times(1).
+					Return(compare, nil)
+				arg := db.CompareYearsTxParams{
+					Owner:   user.Username,
+					Base:    base,
+					Compare: compare,
+				}
+				store.EXPECT().
+					CompareYearsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(comparison, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.PeriodComparison
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, compare.ID, got.Compare.ID)
+				require.True(t, decimal.NewFromInt(120).Equal(got.Expense.Delta))
+				require.Len(t, got.New, 1)
+				require.Equal(t, db.CategoryNew, got.New[0].Status)
+			},
+		},
+		{
+			name:      "NotFound",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetYear(gomock.Any(), gomock.Eq(base.ID)).
+					Times(1).
+					Return(base, nil)
+				store.EXPECT().
+					GetYear(gomock.Any(), gomock.Eq(compare.ID)).
+					Times(1).
+					Return(db.Year{}, sql.ErrNoRows)
+				store.EXPECT().
+					CompareYearsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "UnauthorizedYear",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetYear(gomock.Any(), gomock.Eq(base.ID)).
+					Times(1).
+					Return(base, nil)
+				store.EXPECT().
+					CompareYearsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidID",
+			baseID:    0,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetYear(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CompareYearsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetYear(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ any, id int64) (db.Year, error) {
+						if id == base.ID {
+							return base, nil
+						}
+						return compare, nil
+					})
+				store.EXPECT().
+					CompareYearsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PeriodComparison{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			baseID:    base.ID,
+			compareID: compare.ID,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CompareYearsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("%s?base_id=%d&compare_id=%d", "/api/reports/years/compare", tc.baseID, tc.compareID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
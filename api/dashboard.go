@@ -0,0 +1,156 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/views"
+	"github.com/moth13/finance_tracker/views/charts"
+	"github.com/moth13/finance_tracker/views/components"
+	decimal "github.com/shopspring/decimal"
+)
+
+// maxDashboardCategories is the number of categories the expense donut shows, the others being summed
+const maxDashboardCategories = 6
+
+type dashboardRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To   time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// dashboardPage shows the charts of the last 12 months
+func (server *Server) dashboardPage(ctx *gin.Context) {
+	ranges := dashboardRanges(time.Now())
+	dashboard, err := server.viewDashboard(ctx, ranges, ranges[0].From, ranges[0].To)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.render(ctx, http.StatusOK, views.Layout(views.Dashboard(dashboard), "dashboard", "/views/dashboard"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
+
+// getViewDashboardCharts renders the charts of a range of dates into the dashboard page
+func (server *Server) getViewDashboardCharts(ctx *gin.Context) {
+	var req dashboardRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	ranges := dashboardRanges(time.Now())
+	if req.From.IsZero() {
+		req.From = ranges[0].From
+	}
+	if req.To.IsZero() {
+		req.To = ranges[0].To
+	}
+	if req.To.Before(req.From) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportPeriod))
+		return
+	}
+	if req.To.Sub(req.From) >= maxNetWorthDays*24*time.Hour {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errNetWorthDays))
+		return
+	}
+
+	dashboard, err := server.viewDashboard(ctx, ranges, req.From, req.To)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err := server.render(ctx, http.StatusOK, components.DashboardCharts(dashboard)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
+
+// dashboardRanges returns the preset ranges of the dashboard, the last 12 months being the default one
+func dashboardRanges(now time.Time) []components.DashboardRange {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return []components.DashboardRange{
+		{Label: "12 months", From: time.Date(now.Year(), now.Month()-11, 1, 0, 0, 0, 0, time.UTC), To: today},
+		{Label: "3 months", From: time.Date(now.Year(), now.Month()-2, 1, 0, 0, 0, 0, time.UTC), To: today},
+		{Label: "30 days", From: today.AddDate(0, 0, -29), To: today},
+		{Label: "This year", From: time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), To: today},
+	}
+}
+
+// viewDashboard builds the net worth, monthly income and expense, and expense by category charts of a range of dates
+func (server *Server) viewDashboard(ctx *gin.Context, ranges []components.DashboardRange, from, to time.Time) (components.Dashboard, error) {
+	dashboard := components.Dashboard{From: from, To: to, Ranges: ranges}
+
+	netWorth, err := server.store.NetWorthTx(ctx, db.NetWorthTxParams{
+		Owner:   viewOwner,
+		From:    dashboard.From,
+		To:      dashboard.To,
+		GroupBy: db.NetWorthByDay,
+	})
+	if err != nil {
+		return dashboard, err
+	}
+
+	report, err := server.store.CategoryReportTx(ctx, db.CategoryReportTxParams{
+		Owner:   viewOwner,
+		From:    dashboard.From,
+		To:      dashboard.To,
+		GroupBy: db.ReportByMonth,
+	})
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.Balances = charts.Line{ID: "dashboard-balances", Title: "Net worth at the end of each day"}
+	for _, point := range netWorth.Points {
+		dashboard.Balances.Points = append(dashboard.Balances.Points, charts.Point{Date: point.Date, Value: point.NetWorth})
+	}
+
+	dashboard.Months = charts.Bars{ID: "dashboard-months", Title: "Income and expense of each month"}
+	expenses := map[int64]*charts.Slice{}
+	for _, period := range report.Periods {
+		dashboard.Months.Bars = append(dashboard.Months.Bars, charts.Bar{
+			Label: period.From.Format("Jan 06"),
+			In:    period.Income,
+			Out:   period.Expense,
+		})
+		for _, category := range period.Categories {
+			if !category.Expense.IsPositive() {
+				continue
+			}
+			slice, ok := expenses[category.CategoryID]
+			if !ok {
+				slice = &charts.Slice{Label: category.Category}
+				expenses[category.CategoryID] = slice
+			}
+			slice.Value = slice.Value.Add(category.Expense)
+		}
+	}
+
+	dashboard.Categories = charts.Donut{ID: "dashboard-categories", Title: "Expense by category"}
+	for _, slice := range expenses {
+		dashboard.Categories.Slices = append(dashboard.Categories.Slices, *slice)
+	}
+	sort.Slice(dashboard.Categories.Slices, func(i, j int) bool {
+		a, b := dashboard.Categories.Slices[i], dashboard.Categories.Slices[j]
+		if !a.Value.Equal(b.Value) {
+			return a.Value.GreaterThan(b.Value)
+		}
+		return a.Label < b.Label
+	})
+	if len(dashboard.Categories.Slices) > maxDashboardCategories {
+		other := charts.Slice{Label: "Other", Value: decimal.Zero}
+		for _, slice := range dashboard.Categories.Slices[maxDashboardCategories-1:] {
+			other.Value = other.Value.Add(slice.Value)
+		}
+		dashboard.Categories.Slices = append(dashboard.Categories.Slices[:maxDashboardCategories-1], other)
+	}
+
+	return dashboard, nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+type envelopeURIRequest struct {
+	MonthID    int64 `uri:"id" binding:"required,min=1"`
+	CategoryID int64 `uri:"category_id" binding:"required,min=1"`
+}
+
+type assignEnvelopeRequest struct {
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// assignEnvelope sets the income put in the envelope of a category for a month, replacing the previous assignment
+func (server *Server) assignEnvelope(ctx *gin.Context) {
+	var reqURI envelopeURIRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req assignEnvelopeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	month, ok := server.getOwnedMonth(ctx, reqURI.MonthID)
+	if !ok {
+		return
+	}
+	category, ok := server.getOwnedCategory(ctx, reqURI.CategoryID)
+	if !ok {
+		return
+	}
+	if category.Kind == util.INCOME {
+		err := errors.New("income categories fill envelopes, they don't have one")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	assignment, err := server.store.SetEnvelopeAssignment(ctx, db.SetEnvelopeAssignmentParams{
+		Owner:      month.Owner,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Amount:     req.Amount,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, assignment)
+}
+
+type getMonthEnvelopesRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getMonthEnvelopes returns the envelopes of a month and the income left to assign
+func (server *Server) getMonthEnvelopes(ctx *gin.Context) {
+	var req getMonthEnvelopesRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	month, ok := server.getOwnedMonth(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	envelopes, err := server.store.MonthEnvelopesTx(ctx, db.MonthEnvelopesTxParams{
+		Owner:   month.Owner,
+		MonthID: month.ID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, envelopes)
+}
@@ -0,0 +1,309 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignEnvelopeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	category := randomCategory(user.Username)
+	income := randomCategory(user.Username)
+	income.Kind = util.INCOME
+	assignment := db.EnvelopeAssignment{
+		ID:         util.RandomInt(1, 1000),
+		Owner:      user.Username,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Amount:     decimal.NewFromInt(util.RandomInt(10, 1000)),
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		categoryID    int64
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			categoryID: category.ID,
+			body:       gin.H{"amount": assignment.Amount},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				arg := db.SetEnvelopeAssignmentParams{
+					Owner:      user.Username,
+					CategoryID: category.ID,
+					MonthID:    month.ID,
+					Amount:     assignment.Amount,
+				}
+				store.EXPECT().
+					SetEnvelopeAssignment(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(assignment, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.EnvelopeAssignment
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, assignment.ID, got.ID)
+				require.True(t, assignment.Amount.Equal(got.Amount))
+			},
+		},
+		{
+			name:       "IncomeCategory",
+			categoryID: income.ID,
+			body:       gin.H{"amount": assignment.Amount},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(income.ID)).
+					Times(1).
+					Return(income, nil)
+				store.EXPECT().
+					SetEnvelopeAssignment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "UnauthorizedUser",
+			categoryID: category.ID,
+			body:       gin.H{"amount": assignment.Amount},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					SetEnvelopeAssignment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidCategoryID",
+			categoryID: 0,
+			body:       gin.H{"amount": assignment.Amount},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			categoryID: category.ID,
+			body:       gin.H{"amount": assignment.Amount},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					SetEnvelopeAssignment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.EnvelopeAssignment{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/months/%d/envelopes/%d", month.ID, tc.categoryID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetMonthEnvelopesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	category := randomCategory(user.Username)
+	envelopes := db.MonthEnvelopes{
+		MonthID:      month.ID,
+		Income:       decimal.NewFromInt(2000),
+		Assigned:     decimal.NewFromInt(300),
+		ToBeBudgeted: decimal.NewFromInt(1700),
+		Envelopes: []db.Envelope{{
+			Category:  category,
+			Carried:   decimal.NewFromInt(-20),
+			Assigned:  decimal.NewFromInt(300),
+			Activity:  decimal.NewFromInt(-250),
+			Available: decimal.NewFromInt(30),
+		}},
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					MonthEnvelopesTx(gomock.Any(), gomock.Eq(db.MonthEnvelopesTxParams{Owner: user.Username, MonthID: month.ID})).
+					Times(1).
+					Return(envelopes, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.MonthEnvelopes
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, envelopes.ToBeBudgeted.Equal(got.ToBeBudgeted))
+				require.Len(t, got.Envelopes, 1)
+				require.True(t, envelopes.Envelopes[0].Carried.Equal(got.Envelopes[0].Carried))
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(month, nil)
+				store.EXPECT().
+					MonthEnvelopesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+					Times(1).
+					Return(db.Month{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/months/%d/envelopes", month.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/exporter"
+	"github.com/moth13/finance_tracker/token"
+)
+
+// exportPageSize is the number of lines read from the database per batch while exporting
+const exportPageSize = 500
+
+type exportLinesRequest struct {
+	Format string `form:"format" binding:"required"`
+	Locale string `form:"locale"`
+	lineFilters
+}
+
+func (server *Server) exportLines(ctx *gin.Context) {
+	var req exportLinesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	format := strings.ToUpper(req.Format)
+	if !exporter.IsSupportedFormat(format) {
+		err := fmt.Errorf("unsupported export format %s", req.Format)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Locale != "" && !exporter.IsSupportedLocale(req.Locale) {
+		err := fmt.Errorf("unsupported locale %s", req.Locale)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ListExplicitLinesParams{
+		Owner:      authPayload.Username,
+		AccountID:  req.AccountID,
+		MonthID:    req.MonthID,
+		YearID:     req.YearID,
+		CategoryID: req.CategoryID,
+		Limit:      exportPageSize,
+		Offset:     0,
+	}
+
+	// The first batch is read before any byte is sent so a failure can still be reported
+	lines, err := server.store.ListExplicitLines(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := "lines." + strings.ToLower(format)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Header("Content-Type", exporter.ContentType(format))
+	ctx.Status(http.StatusOK)
+
+	writer, err := exporter.NewLineWriter(format, ctx.Writer, req.Locale)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	for len(lines) > 0 {
+		for _, line := range lines {
+			err := writer.Write(exportLine(line))
+			if err != nil {
+				ctx.Error(err)
+				return
+			}
+		}
+		ctx.Writer.Flush()
+
+		if len(lines) < exportPageSize {
+			break
+		}
+
+		arg.Offset += exportPageSize
+		lines, err = server.store.ListExplicitLines(ctx, arg)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		ctx.Error(err)
+	}
+}
+
+type exportJournalRequest struct {
+	Format string `form:"format" binding:"required"`
+}
+
+func (server *Server) exportJournal(ctx *gin.Context) {
+	var req exportJournalRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	format := strings.ToUpper(req.Format)
+	if !exporter.IsSupportedJournalFormat(format) {
+		err := fmt.Errorf("unsupported export format %s", req.Format)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if strings.TrimSpace(user.Currency) == "" {
+		err := errors.New("a currency is required on the user profile to export a journal")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	journal := exporter.Journal{Currency: user.Currency}
+
+	accountArg := db.ListAccountsParams{
+		Owner: authPayload.Username,
+		Limit: exportPageSize,
+	}
+	for {
+		accounts, err := server.store.ListAccounts(ctx, accountArg)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, account := range accounts {
+			journal.Accounts = append(journal.Accounts, exporter.JournalAccount{
+				Title:       account.Title,
+				InitBalance: account.InitBalance,
+			})
+		}
+		if len(accounts) < exportPageSize {
+			break
+		}
+		accountArg.Offset += exportPageSize
+	}
+
+	lineArg := db.ListExplicitLinesParams{
+		Owner: authPayload.Username,
+		Limit: exportPageSize,
+	}
+	for {
+		lines, err := server.store.ListExplicitLines(ctx, lineArg)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, line := range lines {
+			journal.Lines = append(journal.Lines, exportLine(line))
+		}
+		if len(lines) < exportPageSize {
+			break
+		}
+		lineArg.Offset += exportPageSize
+	}
+
+	// The journal is built before any byte is sent so a failure can still be reported
+	var body bytes.Buffer
+	if err := exporter.WriteJournal(&body, format, journal); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := "finances." + exporter.JournalExtension(format)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", body.Bytes())
+}
+
+// exportLine converts a listed line into the exporter representation
+func exportLine(line db.ListExplicitLinesRow) exporter.Line {
+	return exporter.Line{
+		ID:           line.ID,
+		Date:         line.DueDate,
+		Title:        line.Title,
+		Account:      line.Account,
+		Month:        line.Month,
+		Category:     line.Category,
+		CategoryKind: line.CategoryKind,
+		Amount:       line.Amount,
+		Checked:      line.Checked,
+		Description:  line.Description,
+	}
+}
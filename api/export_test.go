@@ -0,0 +1,341 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/exporter"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomExplicitLine(owner string) db.ListExplicitLinesRow {
+	return db.ListExplicitLinesRow{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    owner,
+		Title:    util.RandomString(6),
+		Account:  util.RandomString(6),
+		Month:    util.RandomString(6),
+		Category: util.RandomString(6),
+		Amount:   util.RandomMoney(),
+		DueDate:  time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestExportLinesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	n := 3
+	lines := make([]db.ListExplicitLinesRow, n)
+	for i := 0; i < n; i++ {
+		lines[i] = randomExplicitLine(user.Username)
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		query         map[string]string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "JSON",
+			query: map[string]string{
+				"format":     "json",
+				"account_id": fmt.Sprintf("%d", account.ID),
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				arg := db.ListExplicitLinesParams{
+					Owner:     user.Username,
+					AccountID: &account.ID,
+					Limit:     exportPageSize,
+					Offset:    0,
+				}
+				store.EXPECT().
+					ListExplicitLines(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(lines, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "lines.json")
+
+				var got []exporter.Line
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, n)
+				for i, line := range lines {
+					require.Equal(t, line.ID, got[i].ID)
+					require.Equal(t, line.Title, got[i].Title)
+					require.True(t, line.Amount.Equal(got[i].Amount))
+				}
+			},
+		},
+		{
+			name: "CSV",
+			query: map[string]string{
+				"format": "csv",
+				"locale": "fr",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExplicitLines(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(lines[:1], nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/csv")
+				require.Contains(t, recorder.Body.String(), "id;date;title")
+				require.Contains(t, recorder.Body.String(), ";2024-03-12;"+lines[0].Title+";")
+			},
+		},
+		{
+			name: "UnsupportedFormat",
+			query: map[string]string{
+				"format": "ods",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExplicitLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnsupportedLocale",
+			query: map[string]string{
+				"format": "csv",
+				"locale": "xx",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExplicitLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			query: map[string]string{
+				"format": "json",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExplicitLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			query: map[string]string{
+				"format": "xlsx",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExplicitLines(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListExplicitLinesRow{}, fmt.Errorf("connection lost"))
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/exports/lines", nil)
+			require.NoError(t, err)
+
+			q := request.URL.Query()
+			for key, value := range tc.query {
+				q.Add(key, value)
+			}
+			request.URL.RawQuery = q.Encode()
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestExportJournalAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	line := randomExplicitLine(user.Username)
+	line.Account = account.Title
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		format        string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			format: "hledger",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Eq(db.ListAccountsParams{Owner: user.Username, Limit: exportPageSize})).
+					Times(1).
+					Return([]db.Account{account}, nil)
+				store.EXPECT().
+					ListExplicitLines(gomock.Any(), gomock.Eq(db.ListExplicitLinesParams{Owner: user.Username, Limit: exportPageSize})).
+					Times(1).
+					Return([]db.ListExplicitLinesRow{line}, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "finances.journal")
+				require.Contains(t, recorder.Body.String(), "commodity "+user.Currency)
+				require.Contains(t, recorder.Body.String(), "account Assets:"+account.Title)
+				require.Contains(t, recorder.Body.String(), "2024-03-12 "+line.Title)
+			},
+		},
+		{
+			name:   "UnsupportedFormat",
+			format: "gnucash",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "NoCurrency",
+			format: "ledger",
+			buildStubds: func(store *mockdb.MockStore) {
+				noCurrency := user
+				noCurrency.Currency = ""
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(noCurrency, nil)
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+			},
+		},
+		{
+			name:   "InternalError",
+			format: "beancount",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Account{}, fmt.Errorf("connection lost"))
+				store.EXPECT().
+					ListExplicitLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/api/exports/journal", nil)
+			require.NoError(t, err)
+
+			q := request.URL.Query()
+			q.Add("format", tc.format)
+			request.URL.RawQuery = q.Encode()
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
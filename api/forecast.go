@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/views"
+	"github.com/moth13/finance_tracker/views/components"
+)
+
+// defaultForecastDays is the length of a forecast when none is asked
+const defaultForecastDays = 90
+
+type forecastRequest struct {
+	Days      int    `form:"days" binding:"omitempty,min=1,max=365"`
+	AccountID *int64 `form:"account_id" binding:"omitempty,min=1"`
+}
+
+// getForecast projects the daily balance of the accounts over the next days
+func (server *Server) getForecast(ctx *gin.Context) {
+	var req forecastRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Days == 0 {
+		req.Days = defaultForecastDays
+	}
+
+	if req.AccountID != nil {
+		if _, ok := server.getOwnedAccount(ctx, *req.AccountID); !ok {
+			return
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ForecastTxParams{
+		Owner:     authPayload.Username,
+		AccountID: req.AccountID,
+		From:      time.Now(),
+		Days:      req.Days,
+	}
+
+	forecast, err := server.store.ForecastTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, forecast)
+}
+
+func (server *Server) forecastPage(ctx *gin.Context) {
+	var req forecastRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Days == 0 {
+		req.Days = defaultForecastDays
+	}
+
+	forecast, err := server.store.ForecastTx(ctx, db.ForecastTxParams{
+		Owner: viewOwner,
+		From:  time.Now(),
+		Days:  req.Days,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	viewForecast := components.Forecast{
+		Days:   req.Days,
+		Lowest: components.BalancePoint(forecast.Lowest),
+	}
+	for _, day := range forecast.Days {
+		viewForecast.Balances = append(viewForecast.Balances, components.BalancePoint(day))
+	}
+	for _, account := range forecast.Accounts {
+		viewForecast.Accounts = append(viewForecast.Accounts, components.ForecastAccount{
+			Title:  account.Title,
+			Start:  account.Start,
+			End:    account.Days[len(account.Days)-1].Balance,
+			Lowest: components.BalancePoint(account.Lowest),
+		})
+	}
+
+	err = server.render(ctx, http.StatusOK, views.Layout(views.Forecast(viewForecast), "forecast", "/views/forecast"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	decimal "github.com/shopspring/decimal"
+)
+
+// errGoalAmount is returned for goals which don't target a positive amount
+var errGoalAmount = errors.New("goal target amount must be positive")
+
+type createGoalRequest struct {
+	Title            string          `json:"title" binding:"required"`
+	AccountID        int64           `json:"account_id" binding:"required,min=1"`
+	CategoryID       *int64          `json:"category_id" binding:"omitempty,min=1"`
+	TargetAmount     decimal.Decimal `json:"target_amount" binding:"required"`
+	TargetDate       time.Time       `json:"target_date" binding:"required"`
+	PlanContribution bool            `json:"plan_contribution"`
+}
+
+// createGoal creates a savings goal, planning its monthly contribution in a recline when asked for
+func (server *Server) createGoal(ctx *gin.Context) {
+	var req createGoalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !req.TargetAmount.IsPositive() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errGoalAmount))
+		return
+	}
+	if req.PlanContribution && req.CategoryID == nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrGoalContributionCategory))
+		return
+	}
+
+	if _, ok := server.getOwnedAccount(ctx, req.AccountID); !ok {
+		return
+	}
+	if req.CategoryID != nil {
+		if _, ok := server.getOwnedCategory(ctx, *req.CategoryID); !ok {
+			return
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateGoalTxParams{
+		Owner:            authPayload.Username,
+		Title:            req.Title,
+		AccountID:        req.AccountID,
+		CategoryID:       req.CategoryID,
+		TargetAmount:     req.TargetAmount,
+		TargetDate:       req.TargetDate,
+		PlanContribution: req.PlanContribution,
+		Date:             time.Now(),
+	}
+
+	result, err := server.store.CreateGoalTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type goalIDRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getOwnedGoal reads a goal, answering the request itself when the goal can't be used
+func (server *Server) getOwnedGoal(ctx *gin.Context, id int64) (db.Goal, bool) {
+	goal, err := server.store.GetGoal(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return goal, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return goal, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if goal.Owner != authPayload.Username {
+		err := errors.New("goal doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return goal, false
+	}
+
+	return goal, true
+}
+
+// getGoal returns a goal with its progress
+func (server *Server) getGoal(ctx *gin.Context) {
+	var req goalIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	goal, ok := server.getOwnedGoal(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	progress, err := server.store.GoalProgressTx(ctx, db.GoalProgressTxParams{
+		Goal: goal,
+		Date: time.Now(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+}
+
+// listGoals returns every goal of the authenticated user with its progress
+func (server *Server) listGoals(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	goals, err := server.store.ListGoalProgressTx(ctx, db.ListGoalProgressTxParams{
+		Owner: authPayload.Username,
+		Date:  time.Now(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, goals)
+}
+
+type updateGoalJSONRequest struct {
+	Title        *string             `json:"title" binding:"omitempty,min=1"`
+	AccountID    *int64              `json:"account_id" binding:"omitempty,min=1"`
+	CategoryID   *int64              `json:"category_id" binding:"omitempty,min=1"`
+	TargetAmount decimal.NullDecimal `json:"target_amount"`
+	TargetDate   *time.Time          `json:"target_date"`
+}
+
+// updateGoal changes a goal, its planned contribution is left as is
+func (server *Server) updateGoal(ctx *gin.Context) {
+	var reqURI goalIDRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var reqJSON updateGoalJSONRequest
+	if err := ctx.ShouldBindJSON(&reqJSON); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if reqJSON.TargetAmount.Valid && !reqJSON.TargetAmount.Decimal.IsPositive() {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errGoalAmount))
+		return
+	}
+
+	goal, ok := server.getOwnedGoal(ctx, reqURI.ID)
+	if !ok {
+		return
+	}
+
+	arg := db.UpdateGoalParams{
+		ID:           goal.ID,
+		Title:        goal.Title,
+		AccountID:    goal.AccountID,
+		CategoryID:   goal.CategoryID,
+		TargetAmount: goal.TargetAmount,
+		TargetDate:   goal.TargetDate,
+	}
+
+	// Overload when needs it
+	if reqJSON.Title != nil {
+		arg.Title = *reqJSON.Title
+	}
+
+	if reqJSON.AccountID != nil {
+		if _, ok := server.getOwnedAccount(ctx, *reqJSON.AccountID); !ok {
+			return
+		}
+		arg.AccountID = *reqJSON.AccountID
+	}
+
+	if reqJSON.CategoryID != nil {
+		if _, ok := server.getOwnedCategory(ctx, *reqJSON.CategoryID); !ok {
+			return
+		}
+		arg.CategoryID = reqJSON.CategoryID
+	}
+
+	if reqJSON.TargetAmount.Valid {
+		arg.TargetAmount = reqJSON.TargetAmount.Decimal
+	}
+
+	if reqJSON.TargetDate != nil {
+		arg.TargetDate = *reqJSON.TargetDate
+	}
+
+	goal, err := server.store.UpdateGoal(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, goal)
+}
+
+// deleteGoal deletes a goal, its planned contribution stays a recline of its own
+func (server *Server) deleteGoal(ctx *gin.Context) {
+	var req goalIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.getOwnedGoal(ctx, req.ID); !ok {
+		return
+	}
+
+	if err := server.store.DeleteGoal(ctx, req.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Goal %d has been deleted", req.ID)})
+}
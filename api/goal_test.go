@@ -0,0 +1,439 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func randomGoal(owner string, account db.Account, category db.Category) db.Goal {
+	return db.Goal{
+		ID:           util.RandomInt(1, 1000),
+		Owner:        owner,
+		Title:        util.RandomTitle(),
+		AccountID:    account.ID,
+		CategoryID:   &category.ID,
+		TargetAmount: decimal.NewFromInt(util.RandomInt(1000, 10000)),
+		TargetDate:   time.Now().AddDate(1, 0, 0).UTC().Truncate(time.Second),
+	}
+}
+
+func TestCreateGoalAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	category := randomCategory(user.Username)
+	goal := randomGoal(user.Username, account, category)
+	progress := db.GoalProgress{
+		Goal:                goal,
+		Remaining:           goal.TargetAmount,
+		MonthsLeft:          13,
+		MonthlyContribution: goal.TargetAmount.Div(decimal.NewFromInt(13)).RoundCeil(2),
+		Status:              db.GoalOnTrack,
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"title":             goal.Title,
+				"account_id":        account.ID,
+				"category_id":       category.ID,
+				"target_amount":     goal.TargetAmount,
+				"target_date":       goal.TargetDate,
+				"plan_contribution": true,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					CreateGoalTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateGoalTxParams) (db.CreateGoalTxResult, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, goal.Title, arg.Title)
+						require.Equal(t, category.ID, *arg.CategoryID)
+						require.True(t, goal.TargetAmount.Equal(arg.TargetAmount))
+						require.True(t, arg.PlanContribution)
+						require.WithinDuration(t, time.Now(), arg.Date, time.Minute)
+						return db.CreateGoalTxResult{Progress: progress}, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.CreateGoalTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, goal.ID, got.Progress.Goal.ID)
+				require.True(t, progress.MonthlyContribution.Equal(got.Progress.MonthlyContribution))
+			},
+		},
+		{
+			name: "PlanWithoutCategory",
+			body: gin.H{
+				"title":             goal.Title,
+				"account_id":        account.ID,
+				"target_amount":     goal.TargetAmount,
+				"target_date":       goal.TargetDate,
+				"plan_contribution": true,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateGoalTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidTargetAmount",
+			body: gin.H{
+				"title":         goal.Title,
+				"account_id":    account.ID,
+				"target_amount": -100,
+				"target_date":   goal.TargetDate,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateGoalTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedAccount",
+			body: gin.H{
+				"title":         goal.Title,
+				"account_id":    account.ID,
+				"target_amount": goal.TargetAmount,
+				"target_date":   goal.TargetDate,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					CreateGoalTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			body: gin.H{
+				"title":         goal.Title,
+				"account_id":    account.ID,
+				"target_amount": goal.TargetAmount,
+				"target_date":   goal.TargetDate,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateGoalTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/goals", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetGoalAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	goal := randomGoal(user.Username, randomAccount(user.Username), randomCategory(user.Username))
+	progress := db.GoalProgress{
+		Goal:   goal,
+		Saved:  decimal.NewFromInt(250),
+		Status: db.GoalBehind,
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGoal(gomock.Any(), gomock.Eq(goal.ID)).
+					Times(1).
+					Return(goal, nil)
+				store.EXPECT().
+					GoalProgressTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(progress, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.GoalProgress
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, goal.ID, got.Goal.ID)
+				require.Equal(t, db.GoalBehind, got.Status)
+				require.True(t, progress.Saved.Equal(got.Saved))
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGoal(gomock.Any(), gomock.Eq(goal.ID)).
+					Times(1).
+					Return(goal, nil)
+				store.EXPECT().
+					GoalProgressTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGoal(gomock.Any(), gomock.Eq(goal.ID)).
+					Times(1).
+					Return(db.Goal{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/goals/%d", goal.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateGoalAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	goal := randomGoal(user.Username, randomAccount(user.Username), randomCategory(user.Username))
+	targetAmount := goal.TargetAmount.Add(decimal.NewFromInt(500))
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"target_amount": targetAmount},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGoal(gomock.Any(), gomock.Eq(goal.ID)).
+					Times(1).
+					Return(goal, nil)
+				arg := db.UpdateGoalParams{
+					ID:           goal.ID,
+					Title:        goal.Title,
+					AccountID:    goal.AccountID,
+					CategoryID:   goal.CategoryID,
+					TargetAmount: targetAmount,
+					TargetDate:   goal.TargetDate,
+				}
+				updated := goal
+				updated.TargetAmount = targetAmount
+				store.EXPECT().
+					UpdateGoal(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Goal
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, targetAmount.Equal(got.TargetAmount))
+			},
+		},
+		{
+			name: "InvalidTargetAmount",
+			body: gin.H{"target_amount": 0},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateGoal(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/api/goals/%d", goal.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListGoalsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	category := randomCategory(user.Username)
+	goals := []db.GoalProgress{
+		{Goal: randomGoal(user.Username, account, category), Status: db.GoalOnTrack},
+		{Goal: randomGoal(user.Username, account, category), Status: db.GoalAchieved},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListGoalProgressTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg db.ListGoalProgressTxParams) ([]db.GoalProgress, error) {
+			require.Equal(t, user.Username, arg.Owner)
+			return goals, nil
+		})
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/goals", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.GoalProgress
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Len(t, got, 2)
+	require.Equal(t, db.GoalAchieved, got[1].Status)
+}
@@ -3,56 +3,109 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/moth13/finance_tracker/db/sqlc"
 	"github.com/moth13/finance_tracker/views"
 	"github.com/moth13/finance_tracker/views/components"
+	decimal "github.com/shopspring/decimal"
 )
 
-func (server *Server) homePage(ctx *gin.Context) {
+// homeLinesLimit is the number of lines the home page shows
+const homeLinesLimit = 10
 
-	arg := db.ListExplicitLinesParams{
-		Limit:  10,
-		Offset: 0,
-		Owner:  "jose",
-	}
+// viewOwner is the user the views are served for
+const viewOwner = "jose"
 
-	var viewInfos views.Infos
+type homeRequest struct {
+	AccountID int64 `form:"account_id" binding:"omitempty,min=1"`
+}
 
-	lines, err := server.store.ListExplicitLines(ctx, arg)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+func (server *Server) homePage(ctx *gin.Context) {
+	var req homeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	account, err := server.store.GetAccount(ctx, 1)
+	viewInfos := views.Infos{AccountID: req.AccountID}
+
+	accountID := req.AccountID
+	if accountID == 0 {
+		accountID = 1
+	}
+	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		log.Println(err)
 		log.Println(sql.ErrNoRows)
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
-	} else {
-		viewInfos.Balance = account.Balance
-		viewInfos.FinalBalance = account.FinalBalance
 	}
-
-	for _, line := range lines {
-		viewsTodo := &components.Line{
-			Id:          line.Title,
-			DbID:        line.ID,
-			Description: line.Description,
-			Title:       line.Title,
-			Amount:      line.Amount,
-			DueDate:     line.DueDate,
-			Checked:     line.Checked,
-			Account:     line.Account,
-			Month:       line.Month,
-			Category:    line.Category,
+	if req.AccountID > 0 {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
 		}
-		viewInfos.Lines = append(viewInfos.Lines, viewsTodo)
+		if account.Owner != viewOwner {
+			err := errors.New("account doesn't belong to the user")
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+	}
+	viewInfos.Balance = account.Balance
+	viewInfos.FinalBalance = account.FinalBalance
+
+	month, budget, err := server.currentMonthBudget(ctx, viewOwner)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if month.ID > 0 && account.ID > 0 {
+		viewInfos.StatementURL = fmt.Sprintf("/views/accounts/%d/statement.pdf?month_id=%d", account.ID, month.ID)
+	}
+
+	viewInfos.Lines, err = server.viewLines(ctx, viewOwner, req.AccountID, budget)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	accounts, err := server.store.ListAccounts(ctx, db.ListAccountsParams{
+		Owner:  viewOwner,
+		Limit:  homeLinesLimit,
+		Offset: 0,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for _, account := range accounts {
+		viewInfos.Accounts = append(viewInfos.Accounts, views.AccountOption{ID: account.ID, Title: account.Title})
+	}
+
+	goals, err := server.store.ListGoalProgressTx(ctx, db.ListGoalProgressTxParams{
+		Owner: viewOwner,
+		Date:  time.Now(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for _, goal := range goals {
+		viewInfos.Goals = append(viewInfos.Goals, &components.Goal{
+			Title:               goal.Goal.Title,
+			Saved:               goal.Saved,
+			Target:              goal.Goal.TargetAmount,
+			TargetDate:          goal.Goal.TargetDate,
+			Percent:             goal.Percent,
+			MonthlyContribution: goal.MonthlyContribution,
+			Status:              goal.Status,
+		})
 	}
 
 	err = server.render(ctx, http.StatusOK, views.Layout(views.Line(viewInfos), "home", "/"))
@@ -61,3 +114,163 @@ func (server *Server) homePage(ctx *gin.Context) {
 		return
 	}
 }
+
+// getViewLineTable renders the line table of the home page, filtered by account when one is given
+func (server *Server) getViewLineTable(ctx *gin.Context) {
+	var req homeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.AccountID > 0 {
+		account, err := server.store.GetAccount(ctx, req.AccountID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if account.Owner != viewOwner {
+			err := errors.New("account doesn't belong to the user")
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+	}
+
+	_, budget, err := server.currentMonthBudget(ctx, viewOwner)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines, err := server.viewLines(ctx, viewOwner, req.AccountID, budget)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.render(ctx, http.StatusOK, components.LineTable(lines, req.AccountID > 0))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
+
+// currentMonthBudget returns the month of today and its budget, both empty when there is no such month
+func (server *Server) currentMonthBudget(ctx *gin.Context, owner string) (db.Month, db.MonthBudget, error) {
+	var budget db.MonthBudget
+
+	month, err := server.store.GetMonthByDate(ctx, db.GetMonthByDateParams{
+		Owner: owner,
+		Date:  time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return month, budget, nil
+		}
+		return month, budget, err
+	}
+
+	budget, err = server.store.MonthBudgetTx(ctx, db.MonthBudgetTxParams{
+		Owner:   owner,
+		MonthID: month.ID,
+	})
+	return month, budget, err
+}
+
+// viewLines returns the latest lines of a user, or of one of their accounts with its running balances
+func (server *Server) viewLines(ctx *gin.Context, owner string, accountID int64, budget db.MonthBudget) ([]*components.Line, error) {
+	var result []*components.Line
+
+	if accountID > 0 {
+		lines, err := server.store.ListAccountHistory(ctx, db.ListAccountHistoryParams{
+			AccountID: accountID,
+			Limit:     homeLinesLimit,
+			Offset:    0,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		for _, line := range lines {
+			viewsTodo := &components.Line{
+				Id:               line.Title,
+				DbID:             line.ID,
+				Description:      line.Description,
+				Title:            line.Title,
+				Amount:           line.Amount,
+				DueDate:          line.DueDate,
+				Checked:          line.Checked,
+				Account:          line.Account,
+				Month:            line.Month,
+				Category:         line.Category,
+				CategoryKind:     line.CategoryKind,
+				CategoryColor:    line.CategoryColor,
+				CategoryIcon:     line.CategoryIcon,
+				ClearedBalance:   decimal.NewNullDecimal(line.ClearedBalance),
+				ProjectedBalance: decimal.NewNullDecimal(line.ProjectedBalance),
+			}
+			if progress, ok := lineBudget(budget, line.CategoryID); ok {
+				viewsTodo.Budget = decimal.NewNullDecimal(progress.Budget.Amount)
+				viewsTodo.BudgetSpent = progress.Spent
+			}
+			result = append(result, viewsTodo)
+		}
+		return result, nil
+	}
+
+	lines, err := server.store.ListExplicitLines(ctx, db.ListExplicitLinesParams{
+		Limit:  homeLinesLimit,
+		Offset: 0,
+		Owner:  owner,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return result, err
+	}
+
+	for _, line := range lines {
+		viewsTodo := &components.Line{
+			Id:            line.Title,
+			DbID:          line.ID,
+			Description:   line.Description,
+			Title:         line.Title,
+			Amount:        line.Amount,
+			DueDate:       line.DueDate,
+			Checked:       line.Checked,
+			Account:       line.Account,
+			Month:         line.Month,
+			Category:      line.Category,
+			CategoryKind:  line.CategoryKind,
+			CategoryColor: line.CategoryColor,
+			CategoryIcon:  line.CategoryIcon,
+		}
+		if progress, ok := lineBudget(budget, line.CategoryID); ok {
+			viewsTodo.Budget = decimal.NewNullDecimal(progress.Budget.Amount)
+			viewsTodo.BudgetSpent = progress.Spent
+		}
+		result = append(result, viewsTodo)
+	}
+
+	return result, nil
+}
+
+// lineBudget returns the budget of the category of a line, or of its closest budgeted parent
+func lineBudget(budget db.MonthBudget, categoryID int64) (db.BudgetProgress, bool) {
+	var result db.BudgetProgress
+	found := false
+	for _, progress := range budget.Budgets {
+		if !slices.Contains(progress.CategoryIDs, categoryID) {
+			continue
+		}
+		// The closest budget covers the smallest part of the tree
+		if !found || len(progress.CategoryIDs) < len(result.CategoryIDs) {
+			result = progress
+			found = true
+		}
+	}
+
+	return result, found
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/importer"
+	"github.com/moth13/finance_tracker/token"
+)
+
+type importLinesRequest struct {
+	AccountID  int64  `form:"account_id" binding:"required,min=1"`
+	CategoryID int64  `form:"category_id" binding:"required,min=1"`
+	Format     string `form:"format" binding:"required"`
+	DryRun     bool   `form:"dry_run"`
+	DayFirst   bool   `form:"day_first"`
+}
+
+// importPreviewLine is a line read from an import file with the categories suggested for it
+type importPreviewLine struct {
+	db.ImportLineParams
+	Suggestions []categorySuggestion `json:"suggestions"`
+}
+
+// importPreviewResult is what a dry run import would create
+type importPreviewResult struct {
+	DryRun bool                `json:"dry_run"`
+	Lines  []importPreviewLine `json:"lines"`
+}
+
+func (server *Server) importLines(ctx *gin.Context) {
+	var req importLinesRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	format := strings.ToUpper(req.Format)
+	if !importer.IsSupportedFormat(format) {
+		err := fmt.Errorf("unsupported import format %s", req.Format)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, req.AccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.getOwnedCategory(ctx, req.CategoryID); !ok {
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	transactions, err := importer.Parse(format, file, importer.Options{DayFirst: req.DayFirst})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ImportLinesTxParams{
+		Owner:      authPayload.Username,
+		AccountID:  account.ID,
+		CategoryID: req.CategoryID,
+		Lines:      make([]db.ImportLineParams, 0, len(transactions)),
+	}
+
+	for _, transaction := range transactions {
+		arg.Lines = append(arg.Lines, db.ImportLineParams{
+			Title:       transaction.Title,
+			Amount:      transaction.Amount,
+			Checked:     transaction.Checked,
+			Description: transaction.Description,
+			DueDate:     transaction.Date,
+			Category:    transaction.Category,
+			Reference:   transaction.Reference,
+		})
+	}
+
+	if req.DryRun {
+		server.previewImportLines(ctx, arg)
+		return
+	}
+
+	result, err := server.store.ImportLinesTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for _, line := range result.Lines {
+		server.suggester.learn(line)
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// previewImportLines answers the lines an import would create along with suggested categories, nothing is written
+func (server *Server) previewImportLines(ctx *gin.Context, arg db.ImportLinesTxParams) {
+	result := importPreviewResult{
+		DryRun: true,
+		Lines:  make([]importPreviewLine, 0, len(arg.Lines)),
+	}
+
+	titles := map[int64]string{}
+	for _, line := range arg.Lines {
+		sample := lineSample(line.Title, line.Amount, arg.AccountID)
+		suggestions, err := server.suggestCategories(ctx, arg.Owner, sample, titles)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		result.Lines = append(result.Lines, importPreviewLine{
+			ImportLineParams: line,
+			Suggestions:      suggestions,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+type importBookRequest struct {
+	Format            string `form:"format" binding:"required"`
+	DryRun            bool   `form:"dry_run"`
+	DayFirst          bool   `form:"day_first"`
+	FlattenCategories bool   `form:"flatten_categories"`
+}
+
+func (server *Server) importBook(ctx *gin.Context) {
+	var req importBookRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	format := strings.ToUpper(req.Format)
+	if !importer.IsSupportedBookFormat(format) {
+		err := fmt.Errorf("unsupported import format %s", req.Format)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	book, err := importer.ParseBook(format, file, importer.Options{
+		DayFirst:          req.DayFirst,
+		FlattenCategories: req.FlattenCategories,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ImportBookTxParams{
+		Owner:    authPayload.Username,
+		DryRun:   req.DryRun,
+		Accounts: make([]db.ImportAccountParams, 0, len(book.Accounts)),
+		Lines:    make([]db.ImportLineParams, 0, len(book.Transactions)),
+		RecLines: make([]db.ImportRecLineParams, 0, len(book.Scheduled)),
+	}
+
+	for _, account := range book.Accounts {
+		arg.Accounts = append(arg.Accounts, db.ImportAccountParams{
+			Title:       account.Title,
+			Description: account.Description,
+			InitBalance: account.InitBalance,
+		})
+	}
+
+	for _, transaction := range book.Transactions {
+		arg.Lines = append(arg.Lines, db.ImportLineParams{
+			Account:     transaction.Account,
+			Title:       transaction.Title,
+			Amount:      transaction.Amount,
+			Checked:     transaction.Checked,
+			Description: transaction.Description,
+			DueDate:     transaction.Date,
+			Category:    transaction.Category,
+			Reference:   transaction.Reference,
+		})
+	}
+
+	for _, scheduled := range book.Scheduled {
+		arg.RecLines = append(arg.RecLines, db.ImportRecLineParams{
+			Account:     scheduled.Account,
+			Title:       scheduled.Title,
+			Description: scheduled.Description,
+			Category:    scheduled.Category,
+			Amount:      scheduled.Amount,
+			Recurrency:  scheduled.Recurrency,
+			DueDate:     scheduled.DueDate,
+		})
+	}
+
+	result, err := server.store.ImportBookTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !arg.DryRun {
+		server.suggester.invalidate(arg.Owner)
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,339 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/stretchr/testify/require"
+)
+
+const importQIFFile = "!Type:Bank\nD12/03/2024\nT-57.30\nPSuperU\nLCourse\n^\n"
+
+func TestImportLinesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	category := randomCategory(user.Username)
+	history := db.Line{ID: 1, Owner: user.Username, Title: "SuperU", AccountID: account.ID, CategoryID: category.ID}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		format        string
+		dryRun        bool
+		content       string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			format:  "qif",
+			content: importQIFFile,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					ImportLinesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.ImportLinesTxParams) (db.ImportLinesTxResult, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, account.ID, arg.AccountID)
+						require.Equal(t, category.ID, arg.CategoryID)
+						require.Len(t, arg.Lines, 1)
+						require.Equal(t, "SuperU", arg.Lines[0].Title)
+						require.Equal(t, "Course", arg.Lines[0].Category)
+						return db.ImportLinesTxResult{}, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "DryRun",
+			format:  "qif",
+			dryRun:  true,
+			content: importQIFFile,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					ListLines(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Line{history}, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(2).
+					Return(category, nil)
+				store.EXPECT().
+					ImportLinesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result importPreviewResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+				require.True(t, result.DryRun)
+				require.Len(t, result.Lines, 1)
+				require.Equal(t, "SuperU", result.Lines[0].Title)
+				require.Len(t, result.Lines[0].Suggestions, 1)
+				require.Equal(t, category.ID, result.Lines[0].Suggestions[0].CategoryID)
+				require.Equal(t, category.Title, result.Lines[0].Suggestions[0].Category)
+				require.InDelta(t, 1, result.Lines[0].Suggestions[0].Confidence, 1e-9)
+			},
+		},
+		{
+			name:    "UnsupportedFormat",
+			format:  "ofx",
+			content: importQIFFile,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportLinesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidFile",
+			format:  "qif",
+			content: "!Type:Bank\nDnot a date\nT-1\n^\n",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					ImportLinesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "UnauthorizedAccount",
+			format:  "qif",
+			content: importQIFFile,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					ImportLinesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:    "UnauthorizedCategory",
+			format:  "qif",
+			content: importQIFFile,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(randomCategory(otherUser.Username), nil)
+				store.EXPECT().
+					ImportLinesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			require.NoError(t, writer.WriteField("account_id", fmt.Sprintf("%d", account.ID)))
+			require.NoError(t, writer.WriteField("category_id", fmt.Sprintf("%d", category.ID)))
+			require.NoError(t, writer.WriteField("format", tc.format))
+			if tc.dryRun {
+				require.NoError(t, writer.WriteField("dry_run", "true"))
+			}
+			part, err := writer.CreateFormFile("file", "export.qif")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(tc.content))
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			request, err := http.NewRequest(http.MethodPost, "/api/imports/lines", body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+const importYNABFile = `"Account","Flag","Date","Payee","Category Group/Category","Category Group","Category","Memo","Outflow","Inflow","Cleared"
+"Checking","","12/03/2024","SuperU","Everyday: Groceries","Everyday","Groceries","","€57.30","€0.00","Cleared"
+`
+
+func TestImportBookAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		fields        map[string]string
+		content       string
+		buildStubds   func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "DryRun",
+			fields:  map[string]string{"format": "ynab", "dry_run": "true", "flatten_categories": "true"},
+			content: importYNABFile,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportBookTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.ImportBookTxParams) (db.ImportBookTxResult, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.True(t, arg.DryRun)
+						require.Len(t, arg.Accounts, 1)
+						require.Len(t, arg.Lines, 1)
+						require.Equal(t, "Checking", arg.Lines[0].Account)
+						require.Equal(t, "Groceries", arg.Lines[0].Category)
+						return db.ImportBookTxResult{DryRun: true, Lines: 1}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "UnsupportedFormat",
+			fields:  map[string]string{"format": "qif"},
+			content: importYNABFile,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportBookTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalError",
+			fields:  map[string]string{"format": "ynab"},
+			content: importYNABFile,
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportBookTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ImportBookTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			for key, value := range tc.fields {
+				require.NoError(t, writer.WriteField(key, value))
+			}
+			part, err := writer.CreateFormFile("file", "register.csv")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(tc.content))
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			request, err := http.NewRequest(http.MethodPost, "/api/imports/apps", body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -48,9 +48,15 @@ func (server *Server) createLine(ctx *gin.Context) {
 
 	result, err := server.store.AddLineTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrCategoryKindMismatch) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	server.suggester.learn(result.Line)
+	server.deliverBudgetAlerts(ctx, result.Line.Owner, result.Alerts)
 
 	ctx.JSON(http.StatusOK, result)
 }
@@ -85,9 +91,18 @@ func (server *Server) getLine(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, line)
 }
 
+// lineFilters narrows lines to an account, a period or a category
+type lineFilters struct {
+	AccountID  *int64 `form:"account_id" binding:"omitempty,min=1"`
+	MonthID    *int64 `form:"month_id" binding:"omitempty,min=1"`
+	YearID     *int64 `form:"year_id" binding:"omitempty,min=1"`
+	CategoryID *int64 `form:"category_id" binding:"omitempty,min=1"`
+}
+
 type listLinesRequest struct {
 	PageID   int32 `form:"page_id" binding:"required,min=1"`
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	lineFilters
 }
 
 func (server *Server) listLines(ctx *gin.Context) {
@@ -99,9 +114,13 @@ func (server *Server) listLines(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	arg := db.ListLinesParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
-		Owner:  authPayload.Username,
+		Limit:      req.PageSize,
+		Offset:     (req.PageID - 1) * req.PageSize,
+		Owner:      authPayload.Username,
+		AccountID:  req.AccountID,
+		MonthID:    req.MonthID,
+		YearID:     req.YearID,
+		CategoryID: req.CategoryID,
 	}
 
 	lines, err := server.store.ListLines(ctx, arg)
@@ -142,6 +161,7 @@ func (server *Server) deleteLine(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	server.suggester.forget(result.Line)
 
 	ctx.JSON(http.StatusOK, result)
 }
@@ -175,7 +195,16 @@ func (server *Server) updateLine(ctx *gin.Context) {
 	}
 
 	arg := db.UpdateLineTxParams{
-		ID: reqURI.ID,
+		ID:          reqURI.ID,
+		Title:       reqJSON.Title,
+		AccountID:   reqJSON.AccountID,
+		MonthID:     reqJSON.MonthID,
+		YearID:      reqJSON.YearID,
+		CategoryID:  reqJSON.CategoryID,
+		Amount:      reqJSON.Amount,
+		Checked:     reqJSON.Checked,
+		Description: reqJSON.Description,
+		DueDate:     reqJSON.DueDate,
 	}
 
 	result, err := server.store.UpdateLineTx(ctx, arg)
@@ -185,9 +214,17 @@ func (server *Server) updateLine(ctx *gin.Context) {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrCategoryKindMismatch) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	// Relearn the line, its category may have changed
+	server.suggester.forget(result.Previous)
+	server.suggester.learn(result.Line)
+	server.deliverBudgetAlerts(ctx, result.Line.Owner, result.Alerts)
 
 	ctx.JSON(http.StatusOK, result)
 }
@@ -88,6 +88,32 @@ func TestCreateLineAPI(t *testing.T) {
 				requireBodyMatchAddingLine(t, recorder.Body, result)
 			},
 		},
+		{
+			name: "CategoryKindMismatch",
+			body: createLineRequest{
+				Title:       line.Title,
+				AccountID:   line.AccountID,
+				MonthID:     line.MonthID,
+				YearID:      line.YearID,
+				CategoryID:  line.CategoryID,
+				Amount:      line.Amount,
+				Checked:     &line.Checked,
+				Description: line.Description,
+				DueDate:     line.DueDate,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AddLineTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AddLineTxResult{}, db.ErrCategoryKindMismatch)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 	}
 
 	// Checking cases
@@ -209,6 +209,28 @@ func (server *Server) updateMonth(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, month)
 }
 
+// getOwnedMonth reads a month, answering the request itself when the month can't be used
+func (server *Server) getOwnedMonth(ctx *gin.Context, id int64) (db.Month, bool) {
+	month, err := server.store.GetMonth(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return month, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return month, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if month.Owner != authPayload.Username {
+		err := errors.New("month doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return month, false
+	}
+
+	return month, true
+}
+
 // func (server *Server) validMonth(ctx *gin.Context, monthID int64) (db.Month, bool) {
 // 	month, err := server.store.GetMonth(ctx, monthID)
 // 	if err != nil {
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/views"
+	"github.com/moth13/finance_tracker/views/components"
+)
+
+// maxNetWorthDays is the longest net worth computed day by day
+const maxNetWorthDays = 731
+
+// errNetWorthDays is returned when a net worth by day covers too many days
+var errNetWorthDays = errors.New("net worth by day must not cover more than 731 days")
+
+type netWorthRequest struct {
+	From    time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To      time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	GroupBy string    `form:"group_by" binding:"omitempty,oneof=day month"`
+}
+
+// getNetWorth returns the net worth at the end of each day or month between two dates, both included
+func (server *Server) getNetWorth(ctx *gin.Context) {
+	var req netWorthRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.To.Before(req.From) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportPeriod))
+		return
+	}
+	if req.GroupBy == "" {
+		req.GroupBy = db.NetWorthByMonth
+	}
+	if req.GroupBy == db.NetWorthByDay && req.To.Sub(req.From) >= maxNetWorthDays*24*time.Hour {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errNetWorthDays))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.NetWorthTxParams{
+		Owner:   authPayload.Username,
+		From:    req.From,
+		To:      req.To,
+		GroupBy: req.GroupBy,
+	}
+
+	netWorth, err := server.store.NetWorthTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, netWorth)
+}
+
+type netWorthPageRequest struct {
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=day month"`
+}
+
+// netWorthPage shows the net worth of the last 90 days, or of the last 12 months by default
+func (server *Server) netWorthPage(ctx *gin.Context) {
+	var req netWorthPageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	arg := db.NetWorthTxParams{
+		Owner:   viewOwner,
+		From:    time.Date(now.Year(), now.Month()-11, 1, 0, 0, 0, 0, time.UTC),
+		To:      now,
+		GroupBy: db.NetWorthByMonth,
+	}
+	if req.GroupBy == db.NetWorthByDay {
+		arg.From = now.AddDate(0, 0, -89)
+		arg.GroupBy = db.NetWorthByDay
+	}
+
+	netWorth, err := server.store.NetWorthTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	viewNetWorth := components.NetWorth{GroupBy: netWorth.GroupBy}
+	for _, point := range netWorth.Points {
+		viewNetWorth.Balances = append(viewNetWorth.Balances, components.BalancePoint{
+			Date:    point.Date,
+			Balance: point.NetWorth,
+		})
+	}
+	if len(netWorth.Points) > 0 {
+		last := netWorth.Points[len(netWorth.Points)-1]
+		viewNetWorth.NetWorth = last.NetWorth
+		for _, account := range last.Accounts {
+			viewNetWorth.Accounts = append(viewNetWorth.Accounts, components.NetWorthAccount{
+				Title:   account.Title,
+				Balance: account.Balance,
+			})
+		}
+	}
+
+	err = server.render(ctx, http.StatusOK, views.Layout(views.NetWorth(viewNetWorth), "net worth", "/views/networth"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
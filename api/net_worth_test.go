@@ -0,0 +1,187 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNetWorthAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	netWorth := db.BuildNetWorth(from, to, db.NetWorthByMonth, []db.Account{account}, []db.SumLinesByAccountAndPeriodRow{{
+		AccountID: account.ID,
+		Period:    from,
+		Amount:    decimal.NewFromInt(120),
+	}})
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					NetWorthTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.NetWorthTxParams) (db.NetWorth, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.True(t, from.Equal(arg.From))
+						require.True(t, to.Equal(arg.To))
+						require.Equal(t, db.NetWorthByMonth, arg.GroupBy)
+						return netWorth, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.NetWorth
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Points, 3)
+				require.True(t, account.InitBalance.Add(decimal.NewFromInt(120)).Equal(got.Points[2].NetWorth))
+				require.Equal(t, account.ID, got.Points[2].Accounts[0].AccountID)
+			},
+		},
+		{
+			name:  "ByDay",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}, "group_by": {"day"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					NetWorthTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.NetWorthTxParams) (db.NetWorth, error) {
+						require.Equal(t, db.NetWorthByDay, arg.GroupBy)
+						return netWorth, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "TooManyDays",
+			query: url.Values{"from": {"2020-01-01"}, "to": {"2024-03-31"}, "group_by": {"day"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					NetWorthTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidGroupBy",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}, "group_by": {"year"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					NetWorthTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "EndBeforeStart",
+			query: url.Values{"from": {"2024-03-31"}, "to": {"2024-01-01"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					NetWorthTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					NetWorthTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.NetWorth{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					NetWorthTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/api/reports/networth?" + tc.query.Encode()
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
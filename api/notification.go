@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/notifier"
+	"github.com/moth13/finance_tracker/token"
+)
+
+// budgetAlertEmail writes the email of a budget alert, naming its month as lines may be added to past months
+func budgetAlertEmail(user db.User, category db.Category, month db.Month, alert db.BudgetAlert) notifier.Email {
+	subject := fmt.Sprintf("%s reached %d%% of its budget", category.Title, alert.Threshold)
+	if alert.Threshold >= 100 {
+		subject = fmt.Sprintf("%s is over budget", category.Title)
+	}
+
+	return notifier.Email{
+		To:      user.Email,
+		Subject: subject,
+		Body: fmt.Sprintf("Hello %s,\n\n%s of the %s %s budget are spent in %s.\n",
+			user.FullName, alert.Spent.StringFixed(2), alert.Amount.StringFixed(2), category.Title, month.Title),
+	}
+}
+
+// deliverBudgetAlerts emails new budget alerts to users who asked for it, alerts are always listed in the notifications.
+// The line is saved already, failing to deliver is only logged.
+func (server *Server) deliverBudgetAlerts(ctx context.Context, owner string, alerts []db.BudgetAlert) {
+	if len(alerts) == 0 || server.mailer == nil {
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, owner)
+	if err != nil {
+		log.Printf("cannot read user %s to email budget alerts: %v", owner, err)
+		return
+	}
+	if !user.BudgetAlertEmail {
+		return
+	}
+
+	emails := make([]notifier.Email, 0, len(alerts))
+	for _, alert := range alerts {
+		category, err := server.store.GetCategory(ctx, alert.CategoryID)
+		if err != nil {
+			log.Printf("cannot read category %d to email budget alert: %v", alert.CategoryID, err)
+			continue
+		}
+		month, err := server.store.GetMonth(ctx, alert.MonthID)
+		if err != nil {
+			log.Printf("cannot read month %d to email budget alert: %v", alert.MonthID, err)
+			continue
+		}
+		emails = append(emails, budgetAlertEmail(user, category, month, alert))
+	}
+
+	// Don't make the request wait for the SMTP server
+	go func() {
+		for _, email := range emails {
+			if err := server.mailer.Send(email); err != nil {
+				log.Println(err)
+			}
+		}
+	}()
+}
+
+type listNotificationsRequest struct {
+	PageID     int32 `form:"page_id" binding:"required,min=1"`
+	PageSize   int32 `form:"page_size" binding:"required,min=5,max=10"`
+	UnreadOnly bool  `form:"unread"`
+}
+
+// listNotifications returns the budget alerts of the authenticated user, the latest first
+func (server *Server) listNotifications(ctx *gin.Context) {
+	var req listNotificationsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ListBudgetAlertsParams{
+		Owner:      authPayload.Username,
+		UnreadOnly: req.UnreadOnly,
+		Limit:      req.PageSize,
+		Offset:     (req.PageID - 1) * req.PageSize,
+	}
+
+	alerts, err := server.store.ListBudgetAlerts(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alerts)
+}
+
+type readNotificationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// readNotification marks a budget alert as read
+func (server *Server) readNotification(ctx *gin.Context) {
+	var req readNotificationRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	alert, err := server.store.GetBudgetAlert(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if alert.Owner != authPayload.Username {
+		err := errors.New("notification doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	alert, err = server.store.MarkBudgetAlertRead(ctx, alert.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alert)
+}
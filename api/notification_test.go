@@ -0,0 +1,318 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/notifier"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func randomBudgetAlert(owner string, budget db.Budget, threshold int32) db.BudgetAlert {
+	return db.BudgetAlert{
+		ID:         util.RandomInt(1, 1000),
+		Owner:      owner,
+		BudgetID:   budget.ID,
+		CategoryID: budget.CategoryID,
+		MonthID:    budget.MonthID,
+		Threshold:  threshold,
+		Amount:     budget.Amount,
+		Spent:      budget.Amount.Mul(decimal.NewFromInt32(threshold)).Div(decimal.NewFromInt(100)),
+	}
+}
+
+func TestListNotificationsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	budget := randomBudget(user.Username, randomCategory(user.Username), month)
+	alerts := []db.BudgetAlert{
+		randomBudgetAlert(user.Username, budget, 100),
+		randomBudgetAlert(user.Username, budget, 80),
+	}
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"page_id": {"1"}, "page_size": {"5"}, "unread": {"true"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				arg := db.ListBudgetAlertsParams{
+					Owner:      user.Username,
+					UnreadOnly: true,
+					Limit:      5,
+					Offset:     0,
+				}
+				store.EXPECT().
+					ListBudgetAlerts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(alerts, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.BudgetAlert
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 2)
+				require.Equal(t, alerts[0].ID, got[0].ID)
+			},
+		},
+		{
+			name:  "InvalidPageSize",
+			query: url.Values{"page_id": {"1"}, "page_size": {"50"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListBudgetAlerts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: url.Values{"page_id": {"1"}, "page_size": {"5"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListBudgetAlerts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/api/notifications?" + tc.query.Encode()
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReadNotificationAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	budget := randomBudget(user.Username, randomCategory(user.Username), month)
+	alert := randomBudgetAlert(user.Username, budget, 80)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetAlert(gomock.Any(), gomock.Eq(alert.ID)).
+					Times(1).
+					Return(alert, nil)
+				read := alert
+				read.Read = true
+				store.EXPECT().
+					MarkBudgetAlertRead(gomock.Any(), gomock.Eq(alert.ID)).
+					Times(1).
+					Return(read, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.BudgetAlert
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, got.Read)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetAlert(gomock.Any(), gomock.Eq(alert.ID)).
+					Times(1).
+					Return(alert, nil)
+				store.EXPECT().
+					MarkBudgetAlertRead(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetAlert(gomock.Any(), gomock.Eq(alert.ID)).
+					Times(1).
+					Return(db.BudgetAlert{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/notifications/%d/read", alert.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// fakeSender records the emails it is asked to send
+type fakeSender struct {
+	emails chan notifier.Email
+}
+
+func (sender *fakeSender) Send(email notifier.Email) error {
+	sender.emails <- email
+	return nil
+}
+
+func TestDeliverBudgetAlerts(t *testing.T) {
+	user, _ := randomUser(t)
+	user.BudgetAlertEmail = true
+	category := randomCategory(user.Username)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	budget := randomBudget(user.Username, category, month)
+	alerts := []db.BudgetAlert{
+		randomBudgetAlert(user.Username, budget, 80),
+		randomBudgetAlert(user.Username, budget, 100),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	sender := &fakeSender{emails: make(chan notifier.Email, len(alerts))}
+	server.mailer = sender
+
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+		Times(2).
+		Return(category, nil)
+	store.EXPECT().
+		GetMonth(gomock.Any(), gomock.Eq(month.ID)).
+		Times(2).
+		Return(month, nil)
+
+	server.deliverBudgetAlerts(context.Background(), user.Username, alerts)
+
+	for _, alert := range alerts {
+		select {
+		case email := <-sender.emails:
+			require.Equal(t, user.Email, email.To)
+			require.Contains(t, email.Subject, category.Title)
+			require.Contains(t, email.Body, alert.Spent.StringFixed(2))
+			require.Contains(t, email.Body, month.Title)
+		case <-time.After(time.Second):
+			t.Fatal("budget alert was not emailed")
+		}
+	}
+
+	// Users who didn't ask for emails only get the notifications
+	user.BudgetAlertEmail = false
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+
+	server.deliverBudgetAlerts(context.Background(), user.Username, alerts)
+	require.Empty(t, sender.emails)
+}
+
+func TestBudgetAlertEmail(t *testing.T) {
+	user, _ := randomUser(t)
+	category := randomCategory(user.Username)
+	month := randomMonth(user.Username, randomYear(user.Username))
+	budget := db.Budget{CategoryID: category.ID, MonthID: month.ID, Amount: decimal.NewFromInt(200)}
+
+	email := budgetAlertEmail(user, category, month, randomBudgetAlert(user.Username, budget, 80))
+	require.Equal(t, fmt.Sprintf("%s reached 80%% of its budget", category.Title), email.Subject)
+	require.Contains(t, email.Body, "160.00 of the 200.00")
+	require.Contains(t, email.Body, "spent in "+month.Title)
+
+	email = budgetAlertEmail(user, category, month, randomBudgetAlert(user.Username, budget, 100))
+	require.Equal(t, fmt.Sprintf("%s is over budget", category.Title), email.Subject)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+)
+
+// errReportPeriod is returned when a report ends before it starts
+var errReportPeriod = errors.New("report must not end before it starts")
+
+type categoryReportRequest struct {
+	From      time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To        time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	AccountID *int64    `form:"account_id" binding:"omitempty,min=1"`
+	GroupBy   string    `form:"group_by" binding:"omitempty,oneof=month year"`
+}
+
+// getCategoryReport returns the income and expense of each category between two dates, both included
+func (server *Server) getCategoryReport(ctx *gin.Context) {
+	var req categoryReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.To.Before(req.From) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportPeriod))
+		return
+	}
+
+	if req.AccountID != nil {
+		if _, ok := server.getOwnedAccount(ctx, *req.AccountID); !ok {
+			return
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CategoryReportTxParams{
+		Owner:     authPayload.Username,
+		From:      req.From,
+		To:        req.To,
+		AccountID: req.AccountID,
+		GroupBy:   req.GroupBy,
+	}
+
+	report, err := server.store.CategoryReportTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
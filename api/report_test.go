@@ -0,0 +1,202 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCategoryReportAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	category := randomCategory(user.Username)
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	report := db.BuildCategoryReport(from, to, db.ReportByMonth, []db.ReportLinesByCategoryRow{{
+		Period:       from,
+		CategoryID:   category.ID,
+		Category:     category.Title,
+		CategoryKind: category.Kind,
+		Count:        3,
+		Expense:      decimal.NewFromInt(120),
+		ExpenseShare: decimal.NewFromInt(100),
+	}}, nil)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}, "group_by": {"month"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CategoryReportTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CategoryReportTxParams) (db.CategoryReport, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.True(t, from.Equal(arg.From))
+						require.True(t, to.Equal(arg.To))
+						require.Nil(t, arg.AccountID)
+						require.Equal(t, db.ReportByMonth, arg.GroupBy)
+						return report, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.CategoryReport
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, report.Expense.Equal(got.Expense))
+				require.Len(t, got.Periods, 1)
+				require.Equal(t, category.ID, got.Periods[0].Categories[0].CategoryID)
+			},
+		},
+		{
+			name:  "AccountFilter",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}, "account_id": {"1"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(int64(1))).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					CategoryReportTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CategoryReportTxParams) (db.CategoryReport, error) {
+						require.Equal(t, int64(1), *arg.AccountID)
+						return report, nil
+					})
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "UnauthorizedAccount",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}, "account_id": {"1"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(int64(1))).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					CategoryReportTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidGroupBy",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}, "group_by": {"week"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CategoryReportTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "EndBeforeStart",
+			query: url.Values{"from": {"2024-03-31"}, "to": {"2024-01-01"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CategoryReportTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CategoryReportTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CategoryReport{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: url.Values{"from": {"2024-01-01"}, "to": {"2024-03-31"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CategoryReportTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/api/reports/categories?" + tc.query.Encode()
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/rules"
+	"github.com/moth13/finance_tracker/token"
+	decimal "github.com/shopspring/decimal"
+)
+
+// ruleRequest describes a whole rule, conditions and actions left out are not used
+type ruleRequest struct {
+	Title          string              `json:"title" binding:"required"`
+	Priority       int32               `json:"priority"`
+	MatchTitle     *string             `json:"match_title"`
+	MatchMinAmount decimal.NullDecimal `json:"match_min_amount"`
+	MatchMaxAmount decimal.NullDecimal `json:"match_max_amount"`
+	MatchAccountID *int64              `json:"match_account_id" binding:"omitempty,min=1"`
+	MatchDay       *int32              `json:"match_day" binding:"omitempty,min=1,max=31"`
+	SetCategoryID  *int64              `json:"set_category_id" binding:"omitempty,min=1"`
+	SetPayee       *string             `json:"set_payee"`
+	SetTags        []string            `json:"set_tags"`
+	SetChecked     *bool               `json:"set_checked"`
+	SetDescription *string             `json:"set_description"`
+}
+
+// rule returns the stored form of the request, an empty title pattern or tag list is no condition or action
+func (req ruleRequest) rule(owner string) db.Rule {
+	rule := db.Rule{
+		Owner:          owner,
+		Title:          req.Title,
+		Priority:       req.Priority,
+		MatchTitle:     req.MatchTitle,
+		MatchMinAmount: req.MatchMinAmount,
+		MatchMaxAmount: req.MatchMaxAmount,
+		MatchAccountID: req.MatchAccountID,
+		MatchDay:       req.MatchDay,
+		SetCategoryID:  req.SetCategoryID,
+		SetPayee:       req.SetPayee,
+		SetTags:        req.SetTags,
+		SetChecked:     req.SetChecked,
+		SetDescription: req.SetDescription,
+	}
+	if rule.MatchTitle != nil && *rule.MatchTitle == "" {
+		rule.MatchTitle = nil
+	}
+	if len(rule.SetTags) == 0 {
+		rule.SetTags = nil
+	}
+
+	return rule
+}
+
+// validRule checks a rule and that the account and category it references belong to its owner
+func (server *Server) validRule(ctx *gin.Context, rule db.Rule) bool {
+	if err := rules.Validate(db.EngineRule(rule)); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return false
+	}
+
+	if rule.MatchAccountID != nil {
+		account, err := server.store.GetAccount(ctx, *rule.MatchAccountID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		if account.Owner != rule.Owner {
+			err := errors.New("account doesn't belong to the authenticated user")
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return false
+		}
+	}
+
+	if rule.SetCategoryID != nil {
+		category, err := server.store.GetCategory(ctx, *rule.SetCategoryID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		if category.Owner != rule.Owner {
+			err := errors.New("category doesn't belong to the authenticated user")
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return false
+		}
+	}
+
+	return true
+}
+
+func (server *Server) createRule(ctx *gin.Context) {
+	var req ruleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	rule := req.rule(authPayload.Username)
+	if !server.validRule(ctx, rule) {
+		return
+	}
+
+	arg := db.CreateRuleParams{
+		Owner:          rule.Owner,
+		Title:          rule.Title,
+		Priority:       rule.Priority,
+		MatchTitle:     rule.MatchTitle,
+		MatchMinAmount: rule.MatchMinAmount,
+		MatchMaxAmount: rule.MatchMaxAmount,
+		MatchAccountID: rule.MatchAccountID,
+		MatchDay:       rule.MatchDay,
+		SetCategoryID:  rule.SetCategoryID,
+		SetPayee:       rule.SetPayee,
+		SetTags:        rule.SetTags,
+		SetChecked:     rule.SetChecked,
+		SetDescription: rule.SetDescription,
+	}
+
+	rule, err := server.store.CreateRule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+type ruleIDRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getOwnedRule reads a rule, answering the request itself when the rule can't be used
+func (server *Server) getOwnedRule(ctx *gin.Context, id int64) (db.Rule, bool) {
+	rule, err := server.store.GetRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return rule, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return rule, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if rule.Owner != authPayload.Username {
+		err := errors.New("rule doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return rule, false
+	}
+
+	return rule, true
+}
+
+func (server *Server) getRule(ctx *gin.Context) {
+	var req ruleIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rule, ok := server.getOwnedRule(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+type listRulesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listRules(ctx *gin.Context) {
+	var req listRulesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ListRulesParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	userRules, err := server.store.ListRules(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userRules)
+}
+
+// updateRule replaces a rule, conditions and actions left out of the request are removed
+func (server *Server) updateRule(ctx *gin.Context) {
+	var reqURI ruleIDRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req ruleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rule, ok := server.getOwnedRule(ctx, reqURI.ID)
+	if !ok {
+		return
+	}
+
+	updated := req.rule(rule.Owner)
+	if !server.validRule(ctx, updated) {
+		return
+	}
+
+	arg := db.UpdateRuleParams{
+		ID:             rule.ID,
+		Title:          updated.Title,
+		Priority:       updated.Priority,
+		MatchTitle:     updated.MatchTitle,
+		MatchMinAmount: updated.MatchMinAmount,
+		MatchMaxAmount: updated.MatchMaxAmount,
+		MatchAccountID: updated.MatchAccountID,
+		MatchDay:       updated.MatchDay,
+		SetCategoryID:  updated.SetCategoryID,
+		SetPayee:       updated.SetPayee,
+		SetTags:        updated.SetTags,
+		SetChecked:     updated.SetChecked,
+		SetDescription: updated.SetDescription,
+	}
+
+	rule, err := server.store.UpdateRule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rule)
+}
+
+func (server *Server) deleteRule(ctx *gin.Context) {
+	var req ruleIDRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, ok := server.getOwnedRule(ctx, req.ID); !ok {
+		return
+	}
+
+	if err := server.store.DeleteRule(ctx, req.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Rule %d has been deleted", req.ID)})
+}
+
+type runRulesRequest struct {
+	DryRun bool `form:"dry_run"`
+	lineFilters
+}
+
+// runRules applies the rules to existing lines, a dry run only reports the changes
+func (server *Server) runRules(ctx *gin.Context) {
+	var req runRulesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.RunRulesTxParams{
+		Owner:      authPayload.Username,
+		DryRun:     req.DryRun,
+		AccountID:  req.AccountID,
+		MonthID:    req.MonthID,
+		YearID:     req.YearID,
+		CategoryID: req.CategoryID,
+	}
+
+	result, err := server.store.RunRulesTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !result.DryRun {
+		server.suggester.recategorize(arg.Owner, result.Changes)
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
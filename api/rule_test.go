@@ -0,0 +1,464 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomRule(owner string, category db.Category) db.Rule {
+	pattern := "(?i)" + util.RandomString(6)
+
+	return db.Rule{
+		ID:            util.RandomInt(1, 1000),
+		Owner:         owner,
+		Title:         util.RandomTitle(),
+		Priority:      int32(util.RandomInt(0, 10)),
+		MatchTitle:    &pattern,
+		SetCategoryID: &category.ID,
+		SetTags:       []string{util.RandomString(4)},
+	}
+}
+
+func TestCreateRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	category := randomCategory(user.Username)
+	otherCategory := randomCategory(otherUser.Username)
+	rule := randomRule(user.Username, category)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"title":           rule.Title,
+				"priority":        rule.Priority,
+				"match_title":     *rule.MatchTitle,
+				"set_category_id": category.ID,
+				"set_tags":        rule.SetTags,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				arg := db.CreateRuleParams{
+					Owner:         user.Username,
+					Title:         rule.Title,
+					Priority:      rule.Priority,
+					MatchTitle:    rule.MatchTitle,
+					SetCategoryID: rule.SetCategoryID,
+					SetTags:       rule.SetTags,
+				}
+				store.EXPECT().
+					CreateRule(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rule, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Rule
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, rule.ID, got.ID)
+				require.Equal(t, *rule.MatchTitle, *got.MatchTitle)
+			},
+		},
+		{
+			name: "InvalidPattern",
+			body: gin.H{
+				"title":           rule.Title,
+				"match_title":     "(",
+				"set_category_id": category.ID,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoCondition",
+			body: gin.H{
+				"title":       rule.Title,
+				"set_checked": true,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidDay",
+			body: gin.H{
+				"title":       rule.Title,
+				"match_day":   32,
+				"set_checked": true,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedCategory",
+			body: gin.H{
+				"title":           rule.Title,
+				"match_title":     *rule.MatchTitle,
+				"set_category_id": otherCategory.ID,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(otherCategory.ID)).
+					Times(1).
+					Return(otherCategory, nil)
+				store.EXPECT().
+					CreateRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			body: gin.H{
+				"title":       rule.Title,
+				"match_title": *rule.MatchTitle,
+				"set_checked": true,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/rules", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	rule := randomRule(user.Username, randomCategory(user.Username))
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(rule, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(rule, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(db.Rule{}, sql.ErrNoRows)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/rules/%d", rule.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	rule := randomRule(user.Username, randomCategory(user.Username))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetRule(gomock.Any(), gomock.Eq(rule.ID)).
+		Times(1).
+		Return(rule, nil)
+	store.EXPECT().
+		UpdateRule(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg db.UpdateRuleParams) (db.Rule, error) {
+			// Conditions and actions left out of the request are removed
+			require.Equal(t, rule.ID, arg.ID)
+			require.Nil(t, arg.MatchTitle)
+			require.Nil(t, arg.SetCategoryID)
+			require.Nil(t, arg.SetTags)
+			require.Equal(t, int32(12), *arg.MatchDay)
+			require.True(t, *arg.SetChecked)
+			return rule, nil
+		})
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{"title": rule.Title, "match_day": 12, "set_checked": true})
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("/api/rules/%d", rule.ID)
+	request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestDeleteRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	rule := randomRule(user.Username, randomCategory(user.Username))
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(rule, nil)
+				store.EXPECT().
+					DeleteRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(rule, nil)
+				store.EXPECT().
+					DeleteRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, otherUser.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api/rules/%d", rule.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRunRulesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	result := db.RunRulesTxResult{
+		DryRun: true,
+		Lines:  2,
+		Changes: []db.RuleChange{{
+			LineID: util.RandomInt(1, 1000),
+			Rules:  []int64{1},
+			Before: db.RuleFields{CategoryID: 1, Tags: []string{}},
+			After:  db.RuleFields{CategoryID: 2, Tags: []string{"food"}},
+		}},
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		RunRulesTx(gomock.Any(), gomock.Eq(db.RunRulesTxParams{
+			Owner:     user.Username,
+			DryRun:    true,
+			AccountID: &account.ID,
+		})).
+		Times(1).
+		Return(result, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/api/rules/run?dry_run=true&account_id=%d", account.ID)
+	request, err := http.NewRequest(http.MethodPost, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got db.RunRulesTxResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.True(t, got.DryRun)
+	require.Len(t, got.Changes, 1)
+	require.Equal(t, []string{"food"}, got.Changes[0].After.Tags)
+}
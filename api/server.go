@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/notifier"
 	"github.com/moth13/finance_tracker/token"
 	"github.com/moth13/finance_tracker/util"
 )
@@ -20,6 +21,9 @@ type Server struct {
 	store      db.Store
 	tokenMaker token.Maker
 	router     *gin.Engine
+	suggester  *categorySuggester
+	// mailer sends the emails, nil when no SMTP server is configured
+	mailer notifier.Sender
 }
 
 var staticFiles embed.FS
@@ -34,6 +38,10 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		config:     config,
 		store:      store,
 		tokenMaker: tokenMaker,
+		suggester:  newCategorySuggester(),
+	}
+	if config.EmailSMTPAddress != "" {
+		server.mailer = notifier.NewSMTPSender(config.EmailSMTPAddress, config.EmailSenderAddress, config.EmailSMTPUsername, config.EmailSMTPPassword)
 	}
 
 	// if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
@@ -65,11 +73,22 @@ func (server *Server) setupViewRoutes(router *gin.Engine) {
 	views := router.Group("/views")
 
 	views.GET("/lines", server.getViewLinePage)
+	views.GET("/lines/table", server.getViewLineTable)
 	views.GET("/lines/:id", server.getViewLinePage)
 	views.POST("/lines", server.postViewLine)
 	views.DELETE("/lines/:id", server.deleteViewLine)
 	views.PUT("/lines/:id", server.updateViewLine)
+	views.GET("/suggestions", server.getViewSuggestions)
+
+	views.GET("/accounts/:id/statement.pdf", server.getViewAccountStatement)
 
+	views.GET("/forecast", server.forecastPage)
+	views.GET("/networth", server.netWorthPage)
+	views.GET("/compare", server.comparePage)
+	views.GET("/compare/months", server.getViewCompareMonths)
+	views.GET("/compare/years", server.getViewCompareYears)
+	views.GET("/dashboard", server.dashboardPage)
+	views.GET("/dashboard/charts", server.getViewDashboardCharts)
 	views.GET("/about", server.aboutPageHandler)
 }
 
@@ -89,11 +108,13 @@ func (server *Server) setupApiRoutes(router *gin.Engine) {
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
 	router.POST("/tokens/renew_access", server.renewAccessToken)
+	router.GET("/calendar/:file", server.getCalendarFeed)
 
 	authRoutes := router.Group("/api").Use(authMiddleware(server.tokenMaker))
 
 	authRoutes.POST("/users", server.createUser)
 	authRoutes.DELETE("/users/:id", server.deleteUser)
+	authRoutes.PATCH("/users/preferences", server.updateUserPreferences)
 	// authRoutes.GET("/users/:id", server.getUser)
 	// authRoutes.GET("/users", server.listUsers)
 	// authRoutes.POST("/users/login", server.loginUser)
@@ -101,6 +122,9 @@ func (server *Server) setupApiRoutes(router *gin.Engine) {
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
 	authRoutes.GET("/accounts", server.listAccounts)
+	authRoutes.GET("/accounts/:id/statement.pdf", server.getAccountStatement)
+	authRoutes.GET("/accounts/:id/balances", server.getAccountBalances)
+	authRoutes.GET("/accounts/:id/history", server.getAccountHistory)
 	authRoutes.PATCH("/accounts/:id", server.updateAccount)
 	authRoutes.DELETE("/accounts/:id", server.deleteAccount)
 
@@ -109,6 +133,9 @@ func (server *Server) setupApiRoutes(router *gin.Engine) {
 	authRoutes.GET("/months", server.listMonths)
 	authRoutes.PATCH("/months/:id", server.updateMonth)
 	authRoutes.DELETE("/months/:id", server.deleteMonth)
+	authRoutes.GET("/months/:id/budget", server.getMonthBudget)
+	authRoutes.GET("/months/:id/envelopes", server.getMonthEnvelopes)
+	authRoutes.PUT("/months/:id/envelopes/:category_id", server.assignEnvelope)
 
 	authRoutes.POST("/years", server.createYear)
 	authRoutes.GET("/years/:id", server.getYear)
@@ -117,24 +144,69 @@ func (server *Server) setupApiRoutes(router *gin.Engine) {
 	authRoutes.DELETE("/years/:id", server.deleteYear)
 
 	authRoutes.POST("/categories", server.createCategory)
+	authRoutes.GET("/categories/tree", server.getCategoryTree)
+	authRoutes.POST("/categories/defaults", server.seedCategories)
 	authRoutes.GET("/categories/:id", server.getCategory)
 	authRoutes.GET("/categories", server.listCategories)
-	// authRoutes.UPDATE("/categories/:id", server.updateCategory)
+	authRoutes.POST("/categories/:id/move", server.moveCategory)
+	authRoutes.PATCH("/categories/:id", server.updateCategory)
+	authRoutes.POST("/categories/:id/merge", server.mergeCategory)
 	authRoutes.DELETE("/categories/:id", server.deleteCategory)
 
 	authRoutes.POST("/lines", server.createLine)
+	authRoutes.GET("/lines/suggestions", server.suggestLineCategories)
 	authRoutes.GET("/lines/:id", server.getLine)
 	authRoutes.GET("/lines", server.listLines)
 	authRoutes.PATCH("/lines/:id", server.updateLine)
 	authRoutes.DELETE("/lines/:id", server.deleteLine)
 
+	authRoutes.POST("/budgets", server.createBudget)
+	authRoutes.GET("/budgets/:id", server.getBudget)
+	authRoutes.GET("/budgets", server.listBudgets)
+	authRoutes.PATCH("/budgets/:id", server.updateBudget)
+	authRoutes.DELETE("/budgets/:id", server.deleteBudget)
+
+	authRoutes.POST("/goals", server.createGoal)
+	authRoutes.GET("/goals/:id", server.getGoal)
+	authRoutes.GET("/goals", server.listGoals)
+	authRoutes.PATCH("/goals/:id", server.updateGoal)
+	authRoutes.DELETE("/goals/:id", server.deleteGoal)
+
+	authRoutes.GET("/notifications", server.listNotifications)
+	authRoutes.POST("/notifications/:id/read", server.readNotification)
+
+	authRoutes.GET("/insights/anomalies", server.listAnomalies)
+	authRoutes.POST("/insights/anomalies/:id/dismiss", server.dismissAnomaly)
+
+	authRoutes.POST("/rules", server.createRule)
+	authRoutes.GET("/rules/:id", server.getRule)
+	authRoutes.GET("/rules", server.listRules)
+	authRoutes.PUT("/rules/:id", server.updateRule)
+	authRoutes.DELETE("/rules/:id", server.deleteRule)
+	authRoutes.POST("/rules/run", server.runRules)
+
+	authRoutes.POST("/imports/lines", server.importLines)
+	authRoutes.POST("/imports/apps", server.importBook)
+	authRoutes.GET("/exports/lines", server.exportLines)
+	authRoutes.GET("/exports/journal", server.exportJournal)
+
+	authRoutes.GET("/backup", server.getBackup)
+	authRoutes.POST("/backup", server.restoreBackup)
+
+	authRoutes.POST("/calendar/token", server.rotateCalendarToken)
+	authRoutes.DELETE("/calendar/token", server.revokeCalendarToken)
+
 	authRoutes.POST("/reclines", server.createRecLine)
 	authRoutes.GET("/reclines/:id", server.getRecLine)
 	authRoutes.GET("/reclines", server.listRecLines)
 	// authRoutes.UPDATE("/reclines/:id", server.UpdateRecLine)
 	authRoutes.DELETE("/reclines/:id", server.deleteRecLine)
 
-	// api.GET("/stats/", server.getStats)
+	authRoutes.GET("/reports/categories", server.getCategoryReport)
+	authRoutes.GET("/reports/networth", server.getNetWorth)
+	authRoutes.GET("/reports/months/compare", server.compareMonths)
+	authRoutes.GET("/reports/years/compare", server.compareYears)
+	authRoutes.GET("/forecast", server.getForecast)
 
 	// authRoutes.POST("/accounts", server.createAccount)
 	// authRoutes.GET("/accounts/:id", server.getAccount)
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/exporter"
+	"github.com/moth13/finance_tracker/token"
+)
+
+type statementUriRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type statementRequest struct {
+	MonthID *int64 `form:"month_id" binding:"omitempty,min=1"`
+	YearID  *int64 `form:"year_id" binding:"omitempty,min=1"`
+}
+
+// statementPeriod is the month or year a statement covers
+type statementPeriod struct {
+	owner string
+	title string
+	from  time.Time
+	to    time.Time
+}
+
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+	var uriReq statementUriRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req statementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uriReq.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	server.writeAccountStatement(ctx, account, req)
+}
+
+// writeAccountStatement renders the statement of an account as a PDF download
+func (server *Server) writeAccountStatement(ctx *gin.Context, account db.Account, req statementRequest) {
+	if (req.MonthID == nil) == (req.YearID == nil) {
+		err := errors.New("exactly one of month_id and year_id is required")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	period, err := server.getStatementPeriod(ctx, req)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if period.owner != account.Owner {
+		err := errors.New("period doesn't belong to the account owner")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, account.Owner)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	before, err := server.store.SumAccountLinesBefore(ctx, db.SumAccountLinesBeforeParams{
+		AccountID: account.ID,
+		Before:    period.from,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	statement := exporter.Statement{
+		Owner:               user.FullName,
+		Currency:            user.Currency,
+		Account:             account.Title,
+		Period:              period.title,
+		From:                period.from,
+		To:                  period.to,
+		OpeningBalance:      account.InitBalance.Add(before.Balance),
+		OpeningFinalBalance: account.InitBalance.Add(before.FinalBalance),
+		GeneratedAt:         time.Now(),
+	}
+
+	// Lines are picked by date like the opening balance, so none is counted twice or left out
+	arg := db.ListAccountLinesBetweenParams{
+		AccountID: account.ID,
+		FromDate:  period.from,
+		ToDate:    period.to,
+		Limit:     exportPageSize,
+	}
+	for {
+		lines, err := server.store.ListAccountLinesBetween(ctx, arg)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, line := range lines {
+			statement.Lines = append(statement.Lines, exportLine(db.ListExplicitLinesRow(line)))
+		}
+		if len(lines) < exportPageSize {
+			break
+		}
+		arg.Offset += exportPageSize
+	}
+
+	filename := fmt.Sprintf("statement-%s-%s.pdf", filenamePart(account.Title), filenamePart(period.title))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Header("Content-Type", "application/pdf")
+	ctx.Status(http.StatusOK)
+
+	if err := exporter.WriteStatement(ctx.Writer, statement); err != nil {
+		ctx.Error(err)
+	}
+}
+
+func (server *Server) getStatementPeriod(ctx *gin.Context, req statementRequest) (statementPeriod, error) {
+	if req.MonthID != nil {
+		month, err := server.store.GetMonth(ctx, *req.MonthID)
+		if err != nil {
+			return statementPeriod{}, err
+		}
+		return statementPeriod{owner: month.Owner, title: month.Title, from: month.StartDate, to: month.EndDate}, nil
+	}
+
+	year, err := server.store.GetYear(ctx, *req.YearID)
+	if err != nil {
+		return statementPeriod{}, err
+	}
+	return statementPeriod{owner: year.Owner, title: year.Title, from: year.StartDate, to: year.EndDate}, nil
+}
+
+// filenamePart keeps a title safe to use in a download file name
+func filenamePart(title string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, title)
+}
+
+// getViewAccountStatement serves the statement download button of the views
+func (server *Server) getViewAccountStatement(ctx *gin.Context) {
+	var uriReq statementUriRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req statementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uriReq.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if account.Owner != viewOwner {
+		err := errors.New("account doesn't belong to the user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	server.writeAccountStatement(ctx, account, req)
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moth13/finance_tracker/classifier"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	"github.com/moth13/finance_tracker/views"
+	decimal "github.com/shopspring/decimal"
+)
+
+// suggestionCount is the number of categories suggested for a line
+const suggestionCount = 3
+
+// categorySuggester keeps a category classifier per user.
+// A model is trained from all the lines of its user on first use, then follows every line change.
+type categorySuggester struct {
+	mu     sync.Mutex
+	models map[string]*classifier.Model
+}
+
+func newCategorySuggester() *categorySuggester {
+	return &categorySuggester{models: map[string]*classifier.Model{}}
+}
+
+func lineSample(title string, amount decimal.Decimal, accountID int64) classifier.Sample {
+	return classifier.Sample{Title: title, Amount: amount, AccountID: accountID}
+}
+
+// suggest returns the most likely categories of a line for a user
+func (suggester *categorySuggester) suggest(ctx context.Context, store db.Store, owner string, sample classifier.Sample) ([]classifier.Suggestion, error) {
+	suggester.mu.Lock()
+	model, ok := suggester.models[owner]
+	suggester.mu.Unlock()
+
+	var trained *classifier.Model
+	if !ok {
+		// Train outside of the lock, reading the history of a user must not block the others
+		var err error
+		trained, err = trainModel(ctx, store, owner)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	suggester.mu.Lock()
+	defer suggester.mu.Unlock()
+	if !ok {
+		if model, ok = suggester.models[owner]; !ok {
+			model = trained
+			suggester.models[owner] = model
+		}
+	}
+
+	return model.Suggest(sample, suggestionCount), nil
+}
+
+// trainModel learns the category of every line of a user
+func trainModel(ctx context.Context, store db.Store, owner string) (*classifier.Model, error) {
+	model := classifier.New()
+
+	arg := db.ListLinesParams{
+		Owner: owner,
+		Limit: exportPageSize,
+	}
+	for {
+		lines, err := store.ListLines(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range lines {
+			model.Train(line.CategoryID, lineSample(line.Title, line.Amount, line.AccountID))
+		}
+		if len(lines) < exportPageSize {
+			break
+		}
+		arg.Offset += exportPageSize
+	}
+
+	return model, nil
+}
+
+// learn adds a line to the model of its owner, models not trained yet will read it from the database
+func (suggester *categorySuggester) learn(line db.Line) {
+	suggester.mu.Lock()
+	defer suggester.mu.Unlock()
+
+	if model, ok := suggester.models[line.Owner]; ok {
+		model.Train(line.CategoryID, lineSample(line.Title, line.Amount, line.AccountID))
+	}
+}
+
+// forget removes a line from the model of its owner
+func (suggester *categorySuggester) forget(line db.Line) {
+	suggester.mu.Lock()
+	defer suggester.mu.Unlock()
+
+	if model, ok := suggester.models[line.Owner]; ok {
+		model.Forget(line.CategoryID, lineSample(line.Title, line.Amount, line.AccountID))
+	}
+}
+
+// recategorize moves lines changed by the rules to their new category
+func (suggester *categorySuggester) recategorize(owner string, changes []db.RuleChange) {
+	suggester.mu.Lock()
+	defer suggester.mu.Unlock()
+
+	model, ok := suggester.models[owner]
+	if !ok {
+		return
+	}
+
+	for _, change := range changes {
+		if change.Before.CategoryID == change.After.CategoryID {
+			continue
+		}
+		sample := lineSample(change.Title, change.Amount, change.AccountID)
+		model.Forget(change.Before.CategoryID, sample)
+		model.Train(change.After.CategoryID, sample)
+	}
+}
+
+// invalidate drops the model of a user, it is trained again on next use
+func (suggester *categorySuggester) invalidate(owner string) {
+	suggester.mu.Lock()
+	defer suggester.mu.Unlock()
+
+	delete(suggester.models, owner)
+}
+
+// categorySuggestion is a suggested category with the confidence of the classifier
+type categorySuggestion struct {
+	CategoryID int64   `json:"category_id"`
+	Category   string  `json:"category"`
+	Confidence float64 `json:"confidence"`
+}
+
+// suggestCategories returns the suggestions for a line with their category titles.
+// titles caches the titles already read, categories deleted since they were learned are left out.
+func (server *Server) suggestCategories(ctx *gin.Context, owner string, sample classifier.Sample, titles map[int64]string) ([]categorySuggestion, error) {
+	suggestions, err := server.suggester.suggest(ctx, server.store, owner, sample)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]categorySuggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		title, ok := titles[suggestion.CategoryID]
+		if !ok {
+			category, err := server.store.GetCategory(ctx, suggestion.CategoryID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				return nil, err
+			}
+			title = category.Title
+			titles[suggestion.CategoryID] = title
+		}
+
+		result = append(result, categorySuggestion{
+			CategoryID: suggestion.CategoryID,
+			Category:   title,
+			Confidence: suggestion.Confidence,
+		})
+	}
+
+	return result, nil
+}
+
+type suggestCategoriesRequest struct {
+	Title     string          `form:"title" binding:"required"`
+	Amount    decimal.Decimal `form:"amount"`
+	AccountID int64           `form:"account_id" binding:"omitempty,min=1"`
+}
+
+// suggestLineCategories suggests categories for a line about to be created
+func (server *Server) suggestLineCategories(ctx *gin.Context) {
+	var req suggestCategoriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	sample := lineSample(req.Title, req.Amount, req.AccountID)
+
+	suggestions, err := server.suggestCategories(ctx, authPayload.Username, sample, map[int64]string{})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestions)
+}
+
+type suggestViewCategoriesRequest struct {
+	Title  string `form:"title"`
+	Amount string `form:"amount"`
+}
+
+// getViewSuggestions fills the category choices of the line form as its title is typed
+func (server *Server) getViewSuggestions(ctx *gin.Context) {
+	var req suggestViewCategoriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	options := []views.CategoryOption{}
+	if req.Title != "" {
+		// The form is typed by hand, an amount not parsed yet only gives less hints
+		amount, _ := decimal.NewFromString(req.Amount)
+		suggestions, err := server.suggestCategories(ctx, viewOwner, lineSample(req.Title, amount, 0), map[int64]string{})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		for _, suggestion := range suggestions {
+			options = append(options, views.CategoryOption{
+				Value: suggestion.Category,
+				Label: fmt.Sprintf("%s (%.0f%%)", suggestion.Category, suggestion.Confidence*100),
+			})
+		}
+	}
+
+	if err := server.render(ctx, http.StatusOK, views.CategorySuggestions(options)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+}
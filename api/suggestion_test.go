@@ -0,0 +1,258 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// randomHistory returns lines of a user in two categories with distinct titles
+func randomHistory(user db.User, groceries db.Category, salary db.Category) []db.Line {
+	year := randomYear(user.Username)
+	month := randomMonth(user.Username, year)
+	account := randomAccount(user.Username)
+
+	var lines []db.Line
+	for i, title := range []string{"CB CARREFOUR 12/03", "CB Carrefour Market", "CB LIDL 0042"} {
+		line := randomLine(user, month, year, account, groceries)
+		line.ID = int64(i + 1)
+		line.Title = title
+		line.Amount = decimal.RequireFromString("-42.50")
+		lines = append(lines, line)
+	}
+	for i, title := range []string{"VIR ACME SALAIRE MARS", "VIR ACME SALAIRE AVRIL"} {
+		line := randomLine(user, month, year, account, salary)
+		line.ID = int64(i + 10)
+		line.Title = title
+		line.Amount = decimal.RequireFromString("2500")
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestSuggestLineCategoriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	groceries := randomCategory(user.Username)
+	salary := randomCategory(user.Username)
+	salary.ID = groceries.ID + 1
+	lines := randomHistory(user, groceries, salary)
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubds   func(store *mockdb.MockStore)
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"title": {"CB CARREFOUR CITY"}, "amount": {"-12.90"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLines(gomock.Any(), gomock.Eq(db.ListLinesParams{Owner: user.Username, Limit: exportPageSize})).
+					Times(1).
+					Return(lines, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(groceries.ID)).
+					Times(1).
+					Return(groceries, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(salary.ID)).
+					Times(1).
+					Return(salary, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				suggestions := requireBodyMatchSuggestions(t, recorder.Body)
+				require.Len(t, suggestions, 2)
+				require.Equal(t, groceries.ID, suggestions[0].CategoryID)
+				require.Equal(t, groceries.Title, suggestions[0].Category)
+				require.Greater(t, suggestions[0].Confidence, suggestions[1].Confidence)
+			},
+		},
+		{
+			name:  "NoHistory",
+			query: url.Values{"title": {"CB CARREFOUR CITY"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLines(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Line{}, nil)
+				store.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Empty(t, requireBodyMatchSuggestions(t, recorder.Body))
+			},
+		},
+		{
+			name:  "MissingTitle",
+			query: url.Values{"amount": {"-12.90"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: url.Values{"title": {"CB CARREFOUR CITY"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{"title": {"CB CARREFOUR CITY"}},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListLines(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Line{}, sql.ErrConnDone)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/api/lines/suggestions?" + tc.query.Encode()
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSuggestionsFollowRecategorization(t *testing.T) {
+	user, _ := randomUser(t)
+	groceries := randomCategory(user.Username)
+	salary := randomCategory(user.Username)
+	salary.ID = groceries.ID + 1
+	lines := randomHistory(user, groceries, salary)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	// The history is read once, later changes are learned incrementally
+	store.EXPECT().
+		ListLines(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(lines, nil)
+	store.EXPECT().
+		GetCategory(gomock.Any(), gomock.Eq(groceries.ID)).
+		AnyTimes().
+		Return(groceries, nil)
+	store.EXPECT().
+		GetCategory(gomock.Any(), gomock.Eq(salary.ID)).
+		AnyTimes().
+		Return(salary, nil)
+
+	suggest := func() []categorySuggestion {
+		recorder := httptest.NewRecorder()
+		query := url.Values{"title": {"CB LIDL"}, "amount": {"-42.50"}}
+		request, err := http.NewRequest(http.MethodGet, "/api/lines/suggestions?"+query.Encode(), nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		return requireBodyMatchSuggestions(t, recorder.Body)
+	}
+	require.Equal(t, groceries.ID, suggest()[0].CategoryID)
+
+	// Move every grocery line to the salary category
+	for _, line := range lines[:3] {
+		updated := line
+		updated.CategoryID = salary.ID
+		store.EXPECT().
+			UpdateLineTx(gomock.Any(), gomock.Eq(db.UpdateLineTxParams{ID: line.ID, CategoryID: &salary.ID})).
+			Times(1).
+			Return(db.UpdateLineTxResult{Line: updated, Previous: line}, nil)
+
+		recorder := httptest.NewRecorder()
+		data, err := json.Marshal(gin.H{"category_id": salary.ID})
+		require.NoError(t, err)
+		request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/api/lines/%d", line.ID), bytes.NewReader(data))
+		require.NoError(t, err)
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	suggestions := suggest()
+	require.Len(t, suggestions, 1)
+	require.Equal(t, salary.ID, suggestions[0].CategoryID)
+}
+
+func requireBodyMatchSuggestions(t *testing.T, body *bytes.Buffer) []categorySuggestion {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var suggestions []categorySuggestion
+	err = json.Unmarshal(data, &suggestions)
+	require.NoError(t, err)
+
+	return suggestions
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/token"
 	"github.com/moth13/finance_tracker/util"
 )
 
@@ -19,6 +20,10 @@ type createUserRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 	FullName string `json:"fullname" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
+	Currency string `json:"currency"`
+	// Locale picks the language of the default categories, it follows the currency when left out
+	Locale                string `json:"locale"`
+	SkipDefaultCategories bool   `json:"skip_default_categories"`
 }
 
 type userResponse struct {
@@ -27,6 +32,9 @@ type userResponse struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreateAt          time.Time `json:"create_at"`
+	CategoryKindCheck string    `json:"category_kind_check"`
+	Locale            string    `json:"locale"`
+	BudgetAlertEmail  bool      `json:"budget_alert_email"`
 }
 
 func newUserResponse(user db.User) userResponse {
@@ -36,6 +44,9 @@ func newUserResponse(user db.User) userResponse {
 		Email:             user.Email,
 		PasswordChangedAt: user.PasswordChangedAt,
 		CreateAt:          user.CreateAt,
+		CategoryKindCheck: user.CategoryKindCheck,
+		Locale:            user.Locale,
+		BudgetAlertEmail:  user.BudgetAlertEmail,
 	}
 }
 
@@ -46,19 +57,38 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
+	if req.Currency != "" && !util.IsSupportedCurrency(req.Currency) {
+		err := fmt.Errorf("unsupported currency %q", req.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Locale == "" {
+		req.Locale = util.LocaleForCurrency(req.Currency)
+	}
+	if !util.IsSupportedLocale(req.Locale) {
+		err := fmt.Errorf("unsupported locale %q", req.Locale)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	hashedPassword, err := util.HashedPassword(req.Password)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 	}
 
-	arg := db.CreateUserParams{
-		Username:       req.Username,
-		HashedPassword: hashedPassword,
-		FullName:       req.FullName,
-		Email:          req.Email,
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       req.Username,
+			HashedPassword: hashedPassword,
+			FullName:       req.FullName,
+			Email:          req.Email,
+			Currency:       req.Currency,
+			Locale:         req.Locale,
+		},
+		SkipDefaultCategories: req.SkipDefaultCategories,
 	}
 
-	user, err := server.store.CreateUser(ctx, arg)
+	result, err := server.store.CreateUserTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -71,7 +101,7 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
-	rsp := newUserResponse(user)
+	rsp := newUserResponse(result.User)
 	ctx.JSON(http.StatusOK, rsp)
 }
 
@@ -102,6 +132,52 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("User %s has been deleted", req.Username)})
 }
 
+type updateUserPreferencesRequest struct {
+	CategoryKindCheck *string `json:"category_kind_check"`
+	BudgetAlertEmail  *bool   `json:"budget_alert_email"`
+}
+
+// updateUserPreferences changes how the authenticated user wants lines checked and budget alerts delivered,
+// preferences left out are kept
+func (server *Server) updateUserPreferences(ctx *gin.Context) {
+	var req updateUserPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.CategoryKindCheck == nil && req.BudgetAlertEmail == nil {
+		err := errors.New("no preference to update")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.CategoryKindCheck != nil && !util.IsSupportedKindCheck(*req.CategoryKindCheck) {
+		err := fmt.Errorf("unsupported category kind check %q", *req.CategoryKindCheck)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateUserPreferencesParams{
+		Username:          authPayload.Username,
+		CategoryKindCheck: req.CategoryKindCheck,
+		BudgetAlertEmail:  req.BudgetAlertEmail,
+	}
+
+	user, err := server.store.UpdateUserPreferences(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newUserResponse(user))
+}
+
 type loginUserRequest struct {
 	Username string `json:"username" binding:"required,alphanum"`
 	Password string `json:"password" binding:"required,min=6"`
@@ -1,8 +1,16 @@
 package api
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
 	db "github.com/moth13/finance_tracker/db/sqlc"
 	"github.com/moth13/finance_tracker/util"
 	"github.com/stretchr/testify/require"
@@ -19,6 +27,156 @@ func randomUser(t *testing.T) (user db.User, password string) {
 		FullName:       util.RandomFullName(),
 		Email:          util.RandomEmail(),
 		Currency:       util.RandomCurrency(),
+		Locale:         util.EN,
 	}
 	return
 }
+
+func TestCreateUserAPI(t *testing.T) {
+	user, password := randomUser(t)
+	user.Currency = util.EUR
+	user.Locale = util.FR
+
+	// Test cases definition
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubds   func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+				"fullname": user.FullName,
+				"email":    user.Email,
+				"currency": user.Currency,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+						// The locale follows the currency and the defaults are seeded
+						require.Equal(t, util.FR, arg.Locale)
+						require.False(t, arg.SkipDefaultCategories)
+						require.NoError(t, util.CheckPassword(password, arg.HashedPassword))
+						return db.CreateUserTxResult{User: user}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp userResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, user.Username, rsp.Username)
+				require.Equal(t, user.Locale, rsp.Locale)
+			},
+		},
+		{
+			name: "SkipDefaultCategories",
+			body: gin.H{
+				"username":                user.Username,
+				"password":                password,
+				"fullname":                user.FullName,
+				"email":                   user.Email,
+				"locale":                  util.EN,
+				"skip_default_categories": true,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+						require.Equal(t, util.EN, arg.Locale)
+						require.True(t, arg.SkipDefaultCategories)
+						return db.CreateUserTxResult{User: user}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnsupportedLocale",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+				"fullname": user.FullName,
+				"email":    user.Email,
+				"locale":   "de",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnsupportedCurrency",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+				"fullname": user.FullName,
+				"email":    user.Email,
+				"currency": "XYZ",
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+				"fullname": user.FullName,
+				"email":    user.Email,
+			},
+			buildStubds: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateUserTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	// Checking cases
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubds(store)
+
+			// start test server and send request
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -35,16 +35,19 @@ func (server *Server) getViewLinePage(ctx *gin.Context) {
 		}
 
 		vLine = components.Line{
-			Id:          line.Title,
-			DbID:        line.ID,
-			Description: line.Description,
-			Title:       line.Title,
-			Amount:      line.Amount,
-			DueDate:     line.DueDate,
-			Checked:     line.Checked,
-			Account:     line.Account,
-			Month:       line.Month,
-			Category:    line.Category,
+			Id:            line.Title,
+			DbID:          line.ID,
+			Description:   line.Description,
+			Title:         line.Title,
+			Amount:        line.Amount,
+			DueDate:       line.DueDate,
+			Checked:       line.Checked,
+			Account:       line.Account,
+			Month:         line.Month,
+			Category:      line.Category,
+			CategoryKind:  line.CategoryKind,
+			CategoryColor: line.CategoryColor,
+			CategoryIcon:  line.CategoryIcon,
 		}
 	} else if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -94,11 +97,17 @@ func (server *Server) postViewLine(ctx *gin.Context) {
 		DueDate:     due_date,
 	}
 
-	_, err = server.store.AddLineTx(ctx, arg)
+	result, err := server.store.AddLineTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, db.ErrCategoryKindMismatch) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	server.suggester.learn(result.Line)
+	server.deliverBudgetAlerts(ctx, result.Line.Owner, result.Alerts)
 
 	server.homePage(ctx)
 }
@@ -118,7 +127,7 @@ func (server *Server) deleteViewLine(ctx *gin.Context) {
 		ID: req.ID,
 	}
 
-	_, err := server.store.DeleteLineTx(ctx, arg)
+	result, err := server.store.DeleteLineTx(ctx, arg)
 	fmt.Println(err)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -128,6 +137,7 @@ func (server *Server) deleteViewLine(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	server.suggester.forget(result.Line)
 
 	server.homePage(ctx)
 }
@@ -177,15 +187,22 @@ func (server *Server) updateViewLine(ctx *gin.Context) {
 		arg.DueDate = &due_date
 	}
 
-	_, err := server.store.UpdateLineTx(ctx, arg)
+	result, err := server.store.UpdateLineTx(ctx, arg)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrCategoryKindMismatch) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	server.suggester.forget(result.Previous)
+	server.suggester.learn(result.Line)
+	server.deliverBudgetAlerts(ctx, result.Line.Owner, result.Alerts)
 
 	server.homePage(ctx)
 }
@@ -196,6 +196,28 @@ func (server *Server) updateYear(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, year)
 }
 
+// getOwnedYear reads a year, answering the request itself when the year can't be used
+func (server *Server) getOwnedYear(ctx *gin.Context, id int64) (db.Year, bool) {
+	year, err := server.store.GetYear(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return year, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return year, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if year.Owner != authPayload.Username {
+		err := errors.New("year doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return year, false
+	}
+
+	return year, true
+}
+
 func (server *Server) validYear(ctx *gin.Context, yearID int64) (db.Year, bool) {
 	year, err := server.store.GetYear(ctx, yearID)
 	if err != nil {
@@ -0,0 +1,200 @@
+package classifier
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Sample is what the classifier knows about a line
+type Sample struct {
+	Title     string
+	Amount    decimal.Decimal
+	AccountID int64
+}
+
+// Suggestion is a category with the probability the classifier gives it
+type Suggestion struct {
+	CategoryID int64   `json:"category_id"`
+	Confidence float64 `json:"confidence"`
+}
+
+type categoryStats struct {
+	samples  int
+	total    int
+	features map[string]int
+}
+
+// Model is a multinomial naive Bayes classifier over title tokens, amount bucket and account.
+// It learns one sample at a time so it can follow recategorizations without a full retraining.
+// A Model is not safe for concurrent use.
+type Model struct {
+	samples    int
+	categories map[int64]*categoryStats
+	// vocabulary counts every occurrence of a feature, whatever its category
+	vocabulary map[string]int
+}
+
+// New creates an empty model
+func New() *Model {
+	return &Model{
+		categories: map[int64]*categoryStats{},
+		vocabulary: map[string]int{},
+	}
+}
+
+// Samples returns the number of samples the model learned
+func (model *Model) Samples() int {
+	return model.samples
+}
+
+// Train learns that a sample belongs to a category
+func (model *Model) Train(categoryID int64, sample Sample) {
+	stats, ok := model.categories[categoryID]
+	if !ok {
+		stats = &categoryStats{features: map[string]int{}}
+		model.categories[categoryID] = stats
+	}
+
+	model.samples++
+	stats.samples++
+	for _, feature := range features(sample) {
+		stats.features[feature]++
+		stats.total++
+		model.vocabulary[feature]++
+	}
+}
+
+// Forget removes a sample previously learned for a category, as when its line is recategorized or deleted
+func (model *Model) Forget(categoryID int64, sample Sample) {
+	stats, ok := model.categories[categoryID]
+	if !ok || stats.samples == 0 {
+		return
+	}
+
+	model.samples--
+	stats.samples--
+	for _, feature := range features(sample) {
+		if stats.features[feature] == 0 {
+			continue
+		}
+		stats.features[feature]--
+		stats.total--
+		if stats.features[feature] == 0 {
+			delete(stats.features, feature)
+		}
+
+		model.vocabulary[feature]--
+		if model.vocabulary[feature] <= 0 {
+			delete(model.vocabulary, feature)
+		}
+	}
+
+	if stats.samples == 0 {
+		delete(model.categories, categoryID)
+	}
+}
+
+// Suggest returns at most n categories for a sample, the most likely first
+func (model *Model) Suggest(sample Sample, n int) []Suggestion {
+	suggestions := []Suggestion{}
+	if model.samples == 0 || n <= 0 {
+		return suggestions
+	}
+
+	// Features never seen carry no information, leave them out rather than penalize every category
+	var known []string
+	for _, feature := range features(sample) {
+		if model.vocabulary[feature] > 0 {
+			known = append(known, feature)
+		}
+	}
+
+	vocabulary := float64(len(model.vocabulary))
+	scores := make(map[int64]float64, len(model.categories))
+	best := math.Inf(-1)
+	for categoryID, stats := range model.categories {
+		// Laplace smoothing keeps unseen feature and category pairs possible
+		score := math.Log(float64(stats.samples) / float64(model.samples))
+		for _, feature := range known {
+			score += math.Log(float64(stats.features[feature]+1) / (float64(stats.total) + vocabulary))
+		}
+		scores[categoryID] = score
+		best = math.Max(best, score)
+	}
+
+	// Normalize the log scores into probabilities
+	var sum float64
+	for categoryID, score := range scores {
+		scores[categoryID] = math.Exp(score - best)
+		sum += scores[categoryID]
+	}
+	for categoryID, score := range scores {
+		suggestions = append(suggestions, Suggestion{
+			CategoryID: categoryID,
+			Confidence: score / sum,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].CategoryID < suggestions[j].CategoryID
+	})
+	if len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+
+	return suggestions
+}
+
+// features turns a sample into the words of its title, the magnitude of its amount and its account
+func features(sample Sample) []string {
+	var result []string
+	for _, token := range tokens(sample.Title) {
+		result = append(result, "title:"+token)
+	}
+	result = append(result, "amount:"+amountBucket(sample.Amount))
+	if sample.AccountID != 0 {
+		result = append(result, fmt.Sprintf("account:%d", sample.AccountID))
+	}
+
+	return result
+}
+
+// tokens splits a title into lowercase words, dropping numbers which are mostly dates and references
+func tokens(title string) []string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) < 2 || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+		result = append(result, word)
+	}
+
+	return result
+}
+
+// amountBucket groups amounts by sign and order of magnitude
+func amountBucket(amount decimal.Decimal) string {
+	sign := "+"
+	if amount.IsNegative() {
+		sign = "-"
+	}
+
+	magnitude := 0
+	for abs := amount.Abs(); abs.GreaterThanOrEqual(decimal.NewFromInt(10)); abs = abs.Shift(-1) {
+		magnitude++
+	}
+
+	return fmt.Sprintf("%s%d", sign, magnitude)
+}
@@ -0,0 +1,90 @@
+package classifier
+
+import (
+	"testing"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	groceries = int64(1)
+	salary    = int64(2)
+	transport = int64(3)
+)
+
+func sample(title, amount string) Sample {
+	return Sample{Title: title, Amount: decimal.RequireFromString(amount), AccountID: 1}
+}
+
+func trainedModel() *Model {
+	model := New()
+	model.Train(groceries, sample("CB CARREFOUR 12/03", "-57.30"))
+	model.Train(groceries, sample("CB Carrefour Market", "-23.10"))
+	model.Train(groceries, sample("CB LIDL 0042", "-41.00"))
+	model.Train(salary, sample("VIR ACME SALAIRE MARS", "2500"))
+	model.Train(salary, sample("VIR ACME SALAIRE AVRIL", "2500"))
+	model.Train(transport, sample("SNCF BILLET", "-89.00"))
+	model.Train(transport, sample("TOTAL ESSENCE", "-62.45"))
+	return model
+}
+
+func TestSuggest(t *testing.T) {
+	model := trainedModel()
+	require.Equal(t, 7, model.Samples())
+
+	suggestions := model.Suggest(sample("CB CARREFOUR CITY", "-12.90"), 3)
+	require.Len(t, suggestions, 3)
+	require.Equal(t, groceries, suggestions[0].CategoryID)
+	require.Greater(t, suggestions[0].Confidence, 0.5)
+
+	var sum float64
+	for i, suggestion := range suggestions {
+		sum += suggestion.Confidence
+		if i > 0 {
+			require.GreaterOrEqual(t, suggestions[i-1].Confidence, suggestion.Confidence)
+		}
+	}
+	require.InDelta(t, 1, sum, 1e-9)
+
+	suggestions = model.Suggest(sample("VIR ACME SALAIRE MAI", "2500"), 1)
+	require.Len(t, suggestions, 1)
+	require.Equal(t, salary, suggestions[0].CategoryID)
+}
+
+func TestForget(t *testing.T) {
+	model := trainedModel()
+
+	// Recategorize the SNCF ticket as groceries, then back
+	ticket := sample("SNCF BILLET", "-89.00")
+	model.Forget(transport, ticket)
+	model.Train(groceries, ticket)
+	require.Equal(t, groceries, model.Suggest(sample("SNCF BILLET", "-45.00"), 1)[0].CategoryID)
+
+	model.Forget(groceries, ticket)
+	model.Train(transport, ticket)
+	require.Equal(t, transport, model.Suggest(sample("SNCF BILLET", "-45.00"), 1)[0].CategoryID)
+	require.Equal(t, 7, model.Samples())
+
+	// Forgetting the last sample of a category removes it
+	model.Forget(transport, ticket)
+	model.Forget(transport, sample("TOTAL ESSENCE", "-62.45"))
+	for _, suggestion := range model.Suggest(ticket, 5) {
+		require.NotEqual(t, transport, suggestion.CategoryID)
+	}
+
+	// Forgetting what was never learned does nothing
+	model.Forget(transport, ticket)
+	require.Equal(t, 5, model.Samples())
+}
+
+func TestSuggestEmptyModel(t *testing.T) {
+	require.Empty(t, New().Suggest(sample("CB CARREFOUR", "-10"), 3))
+}
+
+func TestFeatures(t *testing.T) {
+	require.Equal(t, []string{"cb", "carrefour"}, tokens("CB CARREFOUR 12/03 x"))
+	require.Equal(t, "-1", amountBucket(decimal.RequireFromString("-57.30")))
+	require.Equal(t, "+3", amountBucket(decimal.RequireFromString("2500")))
+	require.Equal(t, "+0", amountBucket(decimal.Zero))
+}
@@ -35,6 +35,7 @@ func main() {
 		FullName:       "Jose Marcel",
 		Email:          "jose.marcel@gmail.com",
 		Currency:       util.EUR,
+		Locale:         util.FR,
 	}
 
 	user, err := store.CreateUser(context.Background(), argUser)
@@ -88,6 +89,7 @@ func main() {
 	argCategoryCourse := db.CreateCategoryParams{
 		Title: "Course",
 		Owner: user.Username,
+		Kind:  util.EXPENSE,
 	}
 
 	catCourse, err := store.CreateCategory(context.Background(), argCategoryCourse)
@@ -98,6 +100,7 @@ func main() {
 	argCategoryFun := db.CreateCategoryParams{
 		Title: "Fun",
 		Owner: user.Username,
+		Kind:  util.EXPENSE,
 	}
 
 	catFun, err := store.CreateCategory(context.Background(), argCategoryFun)
@@ -108,6 +111,7 @@ func main() {
 	argCategoryAbo := db.CreateCategoryParams{
 		Title: "Abonnement",
 		Owner: user.Username,
+		Kind:  util.EXPENSE,
 	}
 
 	catAbo, err := store.CreateCategory(context.Background(), argCategoryAbo)
@@ -118,6 +122,7 @@ func main() {
 	argCategorySalaire := db.CreateCategoryParams{
 		Title: "Salaire",
 		Owner: user.Username,
+		Kind:  util.INCOME,
 	}
 
 	catSalaire, err := store.CreateCategory(context.Background(), argCategorySalaire)
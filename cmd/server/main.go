@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 
 	"github.com/moth13/finance_tracker/api"
 	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/moth13/finance_tracker/jobs"
 	"github.com/moth13/finance_tracker/util"
 )
 
@@ -26,6 +28,9 @@ func main() {
 	defer conn.Close()
 
 	store := db.NewStore(conn)
+	go jobs.NewBalanceSnapshots(store, config.BalanceSnapshotInterval).Run(context.Background())
+	go jobs.NewAnomalies(store, config.AnomalyInterval).Run(context.Background())
+
 	server, err := api.NewServer(config, store)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
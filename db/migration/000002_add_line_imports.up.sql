@@ -0,0 +1,22 @@
+CREATE TABLE "line_imports" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "line_id" bigint NOT NULL,
+  "due_date" date NOT NULL,
+  "amount" numeric(19,4) NOT NULL,
+  "reference" varchar NOT NULL,
+  "create_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "line_imports" ("owner");
+
+CREATE UNIQUE INDEX ON "line_imports" ("account_id", "due_date", "amount", "reference");
+
+COMMENT ON COLUMN "line_imports"."reference" IS 'bank reference, cheque number or payee used for dedupe';
+
+ALTER TABLE "line_imports" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "line_imports" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "line_imports" ADD FOREIGN KEY ("line_id") REFERENCES "lines" ("id") ON DELETE CASCADE;
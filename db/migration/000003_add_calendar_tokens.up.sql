@@ -0,0 +1,9 @@
+CREATE TABLE "calendar_tokens" (
+  "owner" varchar PRIMARY KEY,
+  "token_hash" varchar UNIQUE NOT NULL,
+  "create_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "calendar_tokens"."token_hash" IS 'sha256 of the secret token used in the feed url';
+
+ALTER TABLE "calendar_tokens" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
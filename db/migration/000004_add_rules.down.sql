@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS rules;
+
+ALTER TABLE "lines" DROP COLUMN IF EXISTS "tags";
+
+ALTER TABLE "lines" DROP COLUMN IF EXISTS "payee";
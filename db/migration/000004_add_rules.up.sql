@@ -0,0 +1,33 @@
+ALTER TABLE "lines" ADD COLUMN "payee" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "lines" ADD COLUMN "tags" varchar[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE "rules" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "title" varchar NOT NULL,
+  "priority" integer NOT NULL DEFAULT 0,
+  "match_title" varchar,
+  "match_min_amount" numeric(19,4),
+  "match_max_amount" numeric(19,4),
+  "match_account_id" bigint,
+  "match_day" integer,
+  "set_category_id" bigint,
+  "set_payee" varchar,
+  "set_tags" varchar[],
+  "set_checked" boolean,
+  "set_description" varchar,
+  "create_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "rules" ("owner", "priority");
+
+COMMENT ON COLUMN "rules"."priority" IS 'rules with a higher priority run first and win over the others';
+
+COMMENT ON COLUMN "rules"."match_title" IS 'regular expression matched against the line title';
+
+ALTER TABLE "rules" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "rules" ADD FOREIGN KEY ("match_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "rules" ADD FOREIGN KEY ("set_category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
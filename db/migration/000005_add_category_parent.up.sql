@@ -0,0 +1,7 @@
+ALTER TABLE "categories" ADD COLUMN "parent_id" bigint;
+
+CREATE INDEX ON "categories" ("parent_id");
+
+COMMENT ON COLUMN "categories"."parent_id" IS 'categories without parent are at the top of the tree';
+
+ALTER TABLE "categories" ADD FOREIGN KEY ("parent_id") REFERENCES "categories" ("id");
@@ -0,0 +1,7 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "category_kind_check";
+
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "icon";
+
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "color";
+
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "kind";
@@ -0,0 +1,13 @@
+ALTER TABLE "categories" ADD COLUMN "kind" varchar NOT NULL DEFAULT 'EXPENSE';
+
+ALTER TABLE "categories" ADD COLUMN "color" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "categories" ADD COLUMN "icon" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "users" ADD COLUMN "category_kind_check" varchar NOT NULL DEFAULT 'WARN';
+
+COMMENT ON COLUMN "categories"."kind" IS 'INCOME, EXPENSE, TRANSFER or SAVINGS';
+
+COMMENT ON COLUMN "categories"."color" IS 'hexadecimal color of the category badge, empty for the default one';
+
+COMMENT ON COLUMN "users"."category_kind_check" IS 'WARN or ENFORCE when a line amount does not match the kind of its category';
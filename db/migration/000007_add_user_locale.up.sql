@@ -0,0 +1,3 @@
+ALTER TABLE "users" ADD COLUMN "locale" varchar NOT NULL DEFAULT 'en';
+
+COMMENT ON COLUMN "users"."locale" IS 'language of the default categories, en or fr';
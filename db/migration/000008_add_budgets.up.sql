@@ -0,0 +1,23 @@
+CREATE TABLE "budgets" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "category_id" bigint NOT NULL,
+  "month_id" bigint NOT NULL,
+  "recurring" bool NOT NULL DEFAULT (false),
+  "amount" numeric(19,4) NOT NULL,
+  "create_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "budgets" ("owner");
+
+CREATE UNIQUE INDEX ON "budgets" ("category_id", "month_id", "recurring");
+
+COMMENT ON COLUMN "budgets"."month_id" IS 'first month of a recurring budget, or its only month';
+
+COMMENT ON COLUMN "budgets"."recurring" IS 'recurring budgets apply to every later month until another budget of the category replaces them';
+
+ALTER TABLE "budgets" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "budgets" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "budgets" ADD FOREIGN KEY ("month_id") REFERENCES "months" ("id") ON DELETE CASCADE;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS envelope_assignments;
@@ -0,0 +1,20 @@
+CREATE TABLE "envelope_assignments" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "category_id" bigint NOT NULL,
+  "month_id" bigint NOT NULL,
+  "amount" numeric(19,4) NOT NULL,
+  "create_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "envelope_assignments" ("owner");
+
+CREATE UNIQUE INDEX ON "envelope_assignments" ("category_id", "month_id");
+
+COMMENT ON COLUMN "envelope_assignments"."amount" IS 'income put in the envelope of the category for the month, negative to take it back';
+
+ALTER TABLE "envelope_assignments" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "envelope_assignments" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "envelope_assignments" ADD FOREIGN KEY ("month_id") REFERENCES "months" ("id") ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "budget_alert_email";
+
+DROP TABLE IF EXISTS budget_alerts;
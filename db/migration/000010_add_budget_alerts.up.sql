@@ -0,0 +1,32 @@
+CREATE TABLE "budget_alerts" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "budget_id" bigint NOT NULL,
+  "category_id" bigint NOT NULL,
+  "month_id" bigint NOT NULL,
+  "threshold" int NOT NULL,
+  "amount" numeric(19,4) NOT NULL,
+  "spent" numeric(19,4) NOT NULL,
+  "read" bool NOT NULL DEFAULT (false),
+  "create_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "budget_alerts" ("owner", "read");
+
+CREATE UNIQUE INDEX ON "budget_alerts" ("category_id", "month_id", "threshold");
+
+COMMENT ON COLUMN "budget_alerts"."threshold" IS 'percentage of the budget reached, 80 or 100';
+
+COMMENT ON COLUMN "budget_alerts"."spent" IS 'what the month spent in the category when the threshold was reached';
+
+ALTER TABLE "budget_alerts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "budget_alerts" ADD FOREIGN KEY ("budget_id") REFERENCES "budgets" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "budget_alerts" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "budget_alerts" ADD FOREIGN KEY ("month_id") REFERENCES "months" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "users" ADD COLUMN "budget_alert_email" bool NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN "users"."budget_alert_email" IS 'budget alerts are also sent by email';
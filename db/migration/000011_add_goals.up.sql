@@ -0,0 +1,27 @@
+CREATE TABLE "goals" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "title" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "category_id" bigint,
+  "target_amount" numeric(19,4) NOT NULL,
+  "target_date" date NOT NULL,
+  "recline_id" bigint,
+  "create_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "goals" ("owner");
+
+CREATE INDEX ON "goals" ("account_id");
+
+COMMENT ON COLUMN "goals"."category_id" IS 'category of the contributions, without it the whole account balance counts';
+
+COMMENT ON COLUMN "goals"."recline_id" IS 'recline planning the monthly contribution';
+
+ALTER TABLE "goals" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "goals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "goals" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "goals" ADD FOREIGN KEY ("recline_id") REFERENCES "reclines" ("id") ON DELETE SET NULL;
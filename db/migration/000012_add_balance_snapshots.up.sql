@@ -0,0 +1,15 @@
+CREATE TABLE "balance_snapshots" (
+  "account_id" bigint NOT NULL,
+  "date" date NOT NULL,
+  "balance" numeric(19,4) NOT NULL DEFAULT 0,
+  "final_balance" numeric(19,4) NOT NULL DEFAULT 0,
+  PRIMARY KEY ("account_id", "date")
+);
+
+COMMENT ON TABLE "balance_snapshots" IS 'end of day balances of the lines of an account, without its initial balance';
+
+COMMENT ON COLUMN "balance_snapshots"."balance" IS 'sum of the checked lines up to the date';
+
+COMMENT ON COLUMN "balance_snapshots"."final_balance" IS 'sum of all lines up to the date';
+
+ALTER TABLE "balance_snapshots" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
@@ -0,0 +1,21 @@
+CREATE TABLE "line_anomalies" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "line_id" bigint NOT NULL,
+  "kind" varchar NOT NULL,
+  "reason" varchar NOT NULL,
+  "dismissed" bool NOT NULL DEFAULT (false),
+  "create_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "line_anomalies" ("owner", "dismissed");
+
+CREATE UNIQUE INDEX ON "line_anomalies" ("line_id", "kind");
+
+COMMENT ON COLUMN "line_anomalies"."kind" IS 'UNUSUAL_AMOUNT, NEW_PAYEE or DUPLICATE';
+
+COMMENT ON COLUMN "line_anomalies"."dismissed" IS 'the user looked at the line, detecting it again keeps it dismissed';
+
+ALTER TABLE "line_anomalies" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "line_anomalies" ADD FOREIGN KEY ("line_id") REFERENCES "lines" ("id") ON DELETE CASCADE;
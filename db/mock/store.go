@@ -7,10 +7,12 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 	db "github.com/moth13/finance_tracker/db/sqlc"
+	forecast "github.com/moth13/finance_tracker/forecast"
 )
 
 // MockStore is a mock of Store interface.
@@ -96,6 +98,96 @@ func (mr *MockStoreMockRecorder) AddYearBalance(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddYearBalance", reflect.TypeOf((*MockStore)(nil).AddYearBalance), arg0, arg1)
 }
 
+// BackupTx mocks base method.
+func (m *MockStore) BackupTx(arg0 context.Context, arg1 string) (db.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackupTx indicates an expected call of BackupTx.
+func (mr *MockStoreMockRecorder) BackupTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupTx", reflect.TypeOf((*MockStore)(nil).BackupTx), arg0, arg1)
+}
+
+// BalanceHistoryTx mocks base method.
+func (m *MockStore) BalanceHistoryTx(arg0 context.Context, arg1 db.BalanceHistoryTxParams) ([]db.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceHistoryTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceHistoryTx indicates an expected call of BalanceHistoryTx.
+func (mr *MockStoreMockRecorder) BalanceHistoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceHistoryTx", reflect.TypeOf((*MockStore)(nil).BalanceHistoryTx), arg0, arg1)
+}
+
+// CategoryReportTx mocks base method.
+func (m *MockStore) CategoryReportTx(arg0 context.Context, arg1 db.CategoryReportTxParams) (db.CategoryReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategoryReportTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CategoryReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategoryReportTx indicates an expected call of CategoryReportTx.
+func (mr *MockStoreMockRecorder) CategoryReportTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryReportTx", reflect.TypeOf((*MockStore)(nil).CategoryReportTx), arg0, arg1)
+}
+
+// CategoryTreeTx mocks base method.
+func (m *MockStore) CategoryTreeTx(arg0 context.Context, arg1 db.CategoryTreeTxParams) ([]*db.CategoryNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategoryTreeTx", arg0, arg1)
+	ret0, _ := ret[0].([]*db.CategoryNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategoryTreeTx indicates an expected call of CategoryTreeTx.
+func (mr *MockStoreMockRecorder) CategoryTreeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryTreeTx", reflect.TypeOf((*MockStore)(nil).CategoryTreeTx), arg0, arg1)
+}
+
+// CompareMonthsTx mocks base method.
+func (m *MockStore) CompareMonthsTx(arg0 context.Context, arg1 db.CompareMonthsTxParams) (db.PeriodComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareMonthsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PeriodComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareMonthsTx indicates an expected call of CompareMonthsTx.
+func (mr *MockStoreMockRecorder) CompareMonthsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareMonthsTx", reflect.TypeOf((*MockStore)(nil).CompareMonthsTx), arg0, arg1)
+}
+
+// CompareYearsTx mocks base method.
+func (m *MockStore) CompareYearsTx(arg0 context.Context, arg1 db.CompareYearsTxParams) (db.PeriodComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareYearsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PeriodComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareYearsTx indicates an expected call of CompareYearsTx.
+func (mr *MockStoreMockRecorder) CompareYearsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareYearsTx", reflect.TypeOf((*MockStore)(nil).CompareYearsTx), arg0, arg1)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +203,51 @@ func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
 }
 
+// CreateBalanceSnapshots mocks base method.
+func (m *MockStore) CreateBalanceSnapshots(arg0 context.Context, arg1 db.CreateBalanceSnapshotsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBalanceSnapshots", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBalanceSnapshots indicates an expected call of CreateBalanceSnapshots.
+func (mr *MockStoreMockRecorder) CreateBalanceSnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBalanceSnapshots", reflect.TypeOf((*MockStore)(nil).CreateBalanceSnapshots), arg0, arg1)
+}
+
+// CreateBudget mocks base method.
+func (m *MockStore) CreateBudget(arg0 context.Context, arg1 db.CreateBudgetParams) (db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudget indicates an expected call of CreateBudget.
+func (mr *MockStoreMockRecorder) CreateBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudget", reflect.TypeOf((*MockStore)(nil).CreateBudget), arg0, arg1)
+}
+
+// CreateBudgetAlert mocks base method.
+func (m *MockStore) CreateBudgetAlert(arg0 context.Context, arg1 db.CreateBudgetAlertParams) (db.BudgetAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudgetAlert", arg0, arg1)
+	ret0, _ := ret[0].(db.BudgetAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudgetAlert indicates an expected call of CreateBudgetAlert.
+func (mr *MockStoreMockRecorder) CreateBudgetAlert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudgetAlert", reflect.TypeOf((*MockStore)(nil).CreateBudgetAlert), arg0, arg1)
+}
+
 // CreateCategory mocks base method.
 func (m *MockStore) CreateCategory(arg0 context.Context, arg1 db.CreateCategoryParams) (db.Category, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +263,36 @@ func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
 }
 
+// CreateGoal mocks base method.
+func (m *MockStore) CreateGoal(arg0 context.Context, arg1 db.CreateGoalParams) (db.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoal", arg0, arg1)
+	ret0, _ := ret[0].(db.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *MockStoreMockRecorder) CreateGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockStore)(nil).CreateGoal), arg0, arg1)
+}
+
+// CreateGoalTx mocks base method.
+func (m *MockStore) CreateGoalTx(arg0 context.Context, arg1 db.CreateGoalTxParams) (db.CreateGoalTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoalTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateGoalTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoalTx indicates an expected call of CreateGoalTx.
+func (mr *MockStoreMockRecorder) CreateGoalTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoalTx", reflect.TypeOf((*MockStore)(nil).CreateGoalTx), arg0, arg1)
+}
+
 // CreateLine mocks base method.
 func (m *MockStore) CreateLine(arg0 context.Context, arg1 db.CreateLineParams) (db.Line, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +308,36 @@ func (mr *MockStoreMockRecorder) CreateLine(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLine", reflect.TypeOf((*MockStore)(nil).CreateLine), arg0, arg1)
 }
 
+// CreateLineAnomaly mocks base method.
+func (m *MockStore) CreateLineAnomaly(arg0 context.Context, arg1 db.CreateLineAnomalyParams) (db.LineAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLineAnomaly", arg0, arg1)
+	ret0, _ := ret[0].(db.LineAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLineAnomaly indicates an expected call of CreateLineAnomaly.
+func (mr *MockStoreMockRecorder) CreateLineAnomaly(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLineAnomaly", reflect.TypeOf((*MockStore)(nil).CreateLineAnomaly), arg0, arg1)
+}
+
+// CreateLineImport mocks base method.
+func (m *MockStore) CreateLineImport(arg0 context.Context, arg1 db.CreateLineImportParams) (db.LineImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLineImport", arg0, arg1)
+	ret0, _ := ret[0].(db.LineImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLineImport indicates an expected call of CreateLineImport.
+func (mr *MockStoreMockRecorder) CreateLineImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLineImport", reflect.TypeOf((*MockStore)(nil).CreateLineImport), arg0, arg1)
+}
+
 // CreateMonth mocks base method.
 func (m *MockStore) CreateMonth(arg0 context.Context, arg1 db.CreateMonthParams) (db.Month, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +368,21 @@ func (mr *MockStoreMockRecorder) CreateRecLine(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecLine", reflect.TypeOf((*MockStore)(nil).CreateRecLine), arg0, arg1)
 }
 
+// CreateRule mocks base method.
+func (m *MockStore) CreateRule(arg0 context.Context, arg1 db.CreateRuleParams) (db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", arg0, arg1)
+	ret0, _ := ret[0].(db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockStoreMockRecorder) CreateRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockStore)(nil).CreateRule), arg0, arg1)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
 	m.ctrl.T.Helper()
@@ -201,6 +413,21 @@ func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
 }
 
+// CreateUserTx mocks base method.
+func (m *MockStore) CreateUserTx(arg0 context.Context, arg1 db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserTx indicates an expected call of CreateUserTx.
+func (mr *MockStoreMockRecorder) CreateUserTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), arg0, arg1)
+}
+
 // CreateYear mocks base method.
 func (m *MockStore) CreateYear(arg0 context.Context, arg1 db.CreateYearParams) (db.Year, error) {
 	m.ctrl.T.Helper()
@@ -230,6 +457,49 @@ func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
 }
 
+// DeleteBalanceSnapshotsFrom mocks base method.
+func (m *MockStore) DeleteBalanceSnapshotsFrom(arg0 context.Context, arg1 db.DeleteBalanceSnapshotsFromParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBalanceSnapshotsFrom", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBalanceSnapshotsFrom indicates an expected call of DeleteBalanceSnapshotsFrom.
+func (mr *MockStoreMockRecorder) DeleteBalanceSnapshotsFrom(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBalanceSnapshotsFrom", reflect.TypeOf((*MockStore)(nil).DeleteBalanceSnapshotsFrom), arg0, arg1)
+}
+
+// DeleteBudget mocks base method.
+func (m *MockStore) DeleteBudget(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudget", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBudget indicates an expected call of DeleteBudget.
+func (mr *MockStoreMockRecorder) DeleteBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockStore)(nil).DeleteBudget), arg0, arg1)
+}
+
+// DeleteCalendarToken mocks base method.
+func (m *MockStore) DeleteCalendarToken(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCalendarToken indicates an expected call of DeleteCalendarToken.
+func (mr *MockStoreMockRecorder) DeleteCalendarToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarToken", reflect.TypeOf((*MockStore)(nil).DeleteCalendarToken), arg0, arg1)
+}
+
 // DeleteCategory mocks base method.
 func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -244,6 +514,35 @@ func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
 }
 
+// DeleteCategoryTx mocks base method.
+func (m *MockStore) DeleteCategoryTx(arg0 context.Context, arg1 db.DeleteCategoryTxParams) (db.MergeCategoryTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MergeCategoryTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategoryTx indicates an expected call of DeleteCategoryTx.
+func (mr *MockStoreMockRecorder) DeleteCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryTx", reflect.TypeOf((*MockStore)(nil).DeleteCategoryTx), arg0, arg1)
+}
+
+// DeleteGoal mocks base method.
+func (m *MockStore) DeleteGoal(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoal", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoal indicates an expected call of DeleteGoal.
+func (mr *MockStoreMockRecorder) DeleteGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoal", reflect.TypeOf((*MockStore)(nil).DeleteGoal), arg0, arg1)
+}
+
 // DeleteLine mocks base method.
 func (m *MockStore) DeleteLine(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -301,6 +600,35 @@ func (mr *MockStoreMockRecorder) DeleteRecLine(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecLine", reflect.TypeOf((*MockStore)(nil).DeleteRecLine), arg0, arg1)
 }
 
+// DeleteRule mocks base method.
+func (m *MockStore) DeleteRule(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockStoreMockRecorder) DeleteRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockStore)(nil).DeleteRule), arg0, arg1)
+}
+
+// DeleteStaleLineAnomalies mocks base method.
+func (m *MockStore) DeleteStaleLineAnomalies(arg0 context.Context, arg1 db.DeleteStaleLineAnomaliesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleLineAnomalies", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleLineAnomalies indicates an expected call of DeleteStaleLineAnomalies.
+func (mr *MockStoreMockRecorder) DeleteStaleLineAnomalies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleLineAnomalies", reflect.TypeOf((*MockStore)(nil).DeleteStaleLineAnomalies), arg0, arg1)
+}
+
 // DeleteUser mocks base method.
 func (m *MockStore) DeleteUser(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -329,143 +657,383 @@ func (mr *MockStoreMockRecorder) DeleteYear(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteYear", reflect.TypeOf((*MockStore)(nil).DeleteYear), arg0, arg1)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
+// DetectAnomaliesTx mocks base method.
+func (m *MockStore) DetectAnomaliesTx(arg0 context.Context, arg1 db.DetectAnomaliesTxParams) (db.DetectAnomaliesTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "DetectAnomaliesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DetectAnomaliesTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+// DetectAnomaliesTx indicates an expected call of DetectAnomaliesTx.
+func (mr *MockStoreMockRecorder) DetectAnomaliesTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectAnomaliesTx", reflect.TypeOf((*MockStore)(nil).DetectAnomaliesTx), arg0, arg1)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
+// DismissLineAnomaly mocks base method.
+func (m *MockStore) DismissLineAnomaly(arg0 context.Context, arg1 int64) (db.LineAnomaly, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "DismissLineAnomaly", arg0, arg1)
+	ret0, _ := ret[0].(db.LineAnomaly)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// DismissLineAnomaly indicates an expected call of DismissLineAnomaly.
+func (mr *MockStoreMockRecorder) DismissLineAnomaly(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissLineAnomaly", reflect.TypeOf((*MockStore)(nil).DismissLineAnomaly), arg0, arg1)
 }
 
-// GetCategory mocks base method.
-func (m *MockStore) GetCategory(arg0 context.Context, arg1 int64) (db.Category, error) {
+// ForecastTx mocks base method.
+func (m *MockStore) ForecastTx(arg0 context.Context, arg1 db.ForecastTxParams) (forecast.Forecast, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "ForecastTx", arg0, arg1)
+	ret0, _ := ret[0].(forecast.Forecast)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+// ForecastTx indicates an expected call of ForecastTx.
+func (mr *MockStoreMockRecorder) ForecastTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForecastTx", reflect.TypeOf((*MockStore)(nil).ForecastTx), arg0, arg1)
 }
 
-// GetCategoryForUpdate mocks base method.
-func (m *MockStore) GetCategoryForUpdate(arg0 context.Context, arg1 int64) (db.Category, error) {
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategoryForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategoryForUpdate indicates an expected call of GetCategoryForUpdate.
-func (mr *MockStoreMockRecorder) GetCategoryForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryForUpdate", reflect.TypeOf((*MockStore)(nil).GetCategoryForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
 }
 
-// GetExpliciteLine mocks base method.
-func (m *MockStore) GetExpliciteLine(arg0 context.Context, arg1 int64) (db.GetExpliciteLineRow, error) {
+// GetAccountByTitle mocks base method.
+func (m *MockStore) GetAccountByTitle(arg0 context.Context, arg1 db.GetAccountByTitleParams) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExpliciteLine", arg0, arg1)
-	ret0, _ := ret[0].(db.GetExpliciteLineRow)
+	ret := m.ctrl.Call(m, "GetAccountByTitle", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetExpliciteLine indicates an expected call of GetExpliciteLine.
-func (mr *MockStoreMockRecorder) GetExpliciteLine(arg0, arg1 interface{}) *gomock.Call {
+// GetAccountByTitle indicates an expected call of GetAccountByTitle.
+func (mr *MockStoreMockRecorder) GetAccountByTitle(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpliciteLine", reflect.TypeOf((*MockStore)(nil).GetExpliciteLine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByTitle", reflect.TypeOf((*MockStore)(nil).GetAccountByTitle), arg0, arg1)
 }
 
-// GetLine mocks base method.
-func (m *MockStore) GetLine(arg0 context.Context, arg1 int64) (db.Line, error) {
+// GetAccountFirstLineDate mocks base method.
+func (m *MockStore) GetAccountFirstLineDate(arg0 context.Context, arg1 int64) (time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLine", arg0, arg1)
-	ret0, _ := ret[0].(db.Line)
+	ret := m.ctrl.Call(m, "GetAccountFirstLineDate", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLine indicates an expected call of GetLine.
-func (mr *MockStoreMockRecorder) GetLine(arg0, arg1 interface{}) *gomock.Call {
+// GetAccountFirstLineDate indicates an expected call of GetAccountFirstLineDate.
+func (mr *MockStoreMockRecorder) GetAccountFirstLineDate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLine", reflect.TypeOf((*MockStore)(nil).GetLine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountFirstLineDate", reflect.TypeOf((*MockStore)(nil).GetAccountFirstLineDate), arg0, arg1)
 }
 
-// GetLineForUpdate mocks base method.
-func (m *MockStore) GetLineForUpdate(arg0 context.Context, arg1 int64) (db.Line, error) {
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLineForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Line)
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLineForUpdate indicates an expected call of GetLineForUpdate.
-func (mr *MockStoreMockRecorder) GetLineForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLineForUpdate", reflect.TypeOf((*MockStore)(nil).GetLineForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
 }
 
-// GetMonth mocks base method.
-func (m *MockStore) GetMonth(arg0 context.Context, arg1 int64) (db.Month, error) {
+// GetBudget mocks base method.
+func (m *MockStore) GetBudget(arg0 context.Context, arg1 int64) (db.Budget, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMonth", arg0, arg1)
-	ret0, _ := ret[0].(db.Month)
+	ret := m.ctrl.Call(m, "GetBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMonth indicates an expected call of GetMonth.
-func (mr *MockStoreMockRecorder) GetMonth(arg0, arg1 interface{}) *gomock.Call {
+// GetBudget indicates an expected call of GetBudget.
+func (mr *MockStoreMockRecorder) GetBudget(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonth", reflect.TypeOf((*MockStore)(nil).GetMonth), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudget", reflect.TypeOf((*MockStore)(nil).GetBudget), arg0, arg1)
 }
 
-// GetMonthForUpdate mocks base method.
-func (m *MockStore) GetMonthForUpdate(arg0 context.Context, arg1 int64) (db.Month, error) {
+// GetBudgetAlert mocks base method.
+func (m *MockStore) GetBudgetAlert(arg0 context.Context, arg1 int64) (db.BudgetAlert, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMonthForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Month)
+	ret := m.ctrl.Call(m, "GetBudgetAlert", arg0, arg1)
+	ret0, _ := ret[0].(db.BudgetAlert)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMonthForUpdate indicates an expected call of GetMonthForUpdate.
-func (mr *MockStoreMockRecorder) GetMonthForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// GetBudgetAlert indicates an expected call of GetBudgetAlert.
+func (mr *MockStoreMockRecorder) GetBudgetAlert(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthForUpdate", reflect.TypeOf((*MockStore)(nil).GetMonthForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetAlert", reflect.TypeOf((*MockStore)(nil).GetBudgetAlert), arg0, arg1)
 }
 
-// GetRecLine mocks base method.
-func (m *MockStore) GetRecLine(arg0 context.Context, arg1 int64) (db.Recline, error) {
+// GetCalendarTokenByHash mocks base method.
+func (m *MockStore) GetCalendarTokenByHash(arg0 context.Context, arg1 string) (db.CalendarToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarTokenByHash", arg0, arg1)
+	ret0, _ := ret[0].(db.CalendarToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarTokenByHash indicates an expected call of GetCalendarTokenByHash.
+func (mr *MockStoreMockRecorder) GetCalendarTokenByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarTokenByHash", reflect.TypeOf((*MockStore)(nil).GetCalendarTokenByHash), arg0, arg1)
+}
+
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 context.Context, arg1 int64) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+}
+
+// GetCategoryByTitle mocks base method.
+func (m *MockStore) GetCategoryByTitle(arg0 context.Context, arg1 db.GetCategoryByTitleParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByTitle", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByTitle indicates an expected call of GetCategoryByTitle.
+func (mr *MockStoreMockRecorder) GetCategoryByTitle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByTitle", reflect.TypeOf((*MockStore)(nil).GetCategoryByTitle), arg0, arg1)
+}
+
+// GetCategoryForUpdate mocks base method.
+func (m *MockStore) GetCategoryForUpdate(arg0 context.Context, arg1 int64) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryForUpdate indicates an expected call of GetCategoryForUpdate.
+func (mr *MockStoreMockRecorder) GetCategoryForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryForUpdate", reflect.TypeOf((*MockStore)(nil).GetCategoryForUpdate), arg0, arg1)
+}
+
+// GetCategoryUsage mocks base method.
+func (m *MockStore) GetCategoryUsage(arg0 context.Context, arg1 int64) (db.GetCategoryUsageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryUsage", arg0, arg1)
+	ret0, _ := ret[0].(db.GetCategoryUsageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryUsage indicates an expected call of GetCategoryUsage.
+func (mr *MockStoreMockRecorder) GetCategoryUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryUsage", reflect.TypeOf((*MockStore)(nil).GetCategoryUsage), arg0, arg1)
+}
+
+// GetExpliciteLine mocks base method.
+func (m *MockStore) GetExpliciteLine(arg0 context.Context, arg1 int64) (db.GetExpliciteLineRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpliciteLine", arg0, arg1)
+	ret0, _ := ret[0].(db.GetExpliciteLineRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpliciteLine indicates an expected call of GetExpliciteLine.
+func (mr *MockStoreMockRecorder) GetExpliciteLine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpliciteLine", reflect.TypeOf((*MockStore)(nil).GetExpliciteLine), arg0, arg1)
+}
+
+// GetFirstBalanceSnapshot mocks base method.
+func (m *MockStore) GetFirstBalanceSnapshot(arg0 context.Context, arg1 int64) (db.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirstBalanceSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(db.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirstBalanceSnapshot indicates an expected call of GetFirstBalanceSnapshot.
+func (mr *MockStoreMockRecorder) GetFirstBalanceSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstBalanceSnapshot", reflect.TypeOf((*MockStore)(nil).GetFirstBalanceSnapshot), arg0, arg1)
+}
+
+// GetGoal mocks base method.
+func (m *MockStore) GetGoal(arg0 context.Context, arg1 int64) (db.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoal", arg0, arg1)
+	ret0, _ := ret[0].(db.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoal indicates an expected call of GetGoal.
+func (mr *MockStoreMockRecorder) GetGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoal", reflect.TypeOf((*MockStore)(nil).GetGoal), arg0, arg1)
+}
+
+// GetLastBalanceSnapshot mocks base method.
+func (m *MockStore) GetLastBalanceSnapshot(arg0 context.Context, arg1 int64) (db.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastBalanceSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(db.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastBalanceSnapshot indicates an expected call of GetLastBalanceSnapshot.
+func (mr *MockStoreMockRecorder) GetLastBalanceSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastBalanceSnapshot", reflect.TypeOf((*MockStore)(nil).GetLastBalanceSnapshot), arg0, arg1)
+}
+
+// GetLine mocks base method.
+func (m *MockStore) GetLine(arg0 context.Context, arg1 int64) (db.Line, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLine", arg0, arg1)
+	ret0, _ := ret[0].(db.Line)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLine indicates an expected call of GetLine.
+func (mr *MockStoreMockRecorder) GetLine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLine", reflect.TypeOf((*MockStore)(nil).GetLine), arg0, arg1)
+}
+
+// GetLineAnomaly mocks base method.
+func (m *MockStore) GetLineAnomaly(arg0 context.Context, arg1 int64) (db.LineAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLineAnomaly", arg0, arg1)
+	ret0, _ := ret[0].(db.LineAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLineAnomaly indicates an expected call of GetLineAnomaly.
+func (mr *MockStoreMockRecorder) GetLineAnomaly(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLineAnomaly", reflect.TypeOf((*MockStore)(nil).GetLineAnomaly), arg0, arg1)
+}
+
+// GetLineForUpdate mocks base method.
+func (m *MockStore) GetLineForUpdate(arg0 context.Context, arg1 int64) (db.Line, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLineForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Line)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLineForUpdate indicates an expected call of GetLineForUpdate.
+func (mr *MockStoreMockRecorder) GetLineForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLineForUpdate", reflect.TypeOf((*MockStore)(nil).GetLineForUpdate), arg0, arg1)
+}
+
+// GetLineImport mocks base method.
+func (m *MockStore) GetLineImport(arg0 context.Context, arg1 db.GetLineImportParams) (db.LineImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLineImport", arg0, arg1)
+	ret0, _ := ret[0].(db.LineImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLineImport indicates an expected call of GetLineImport.
+func (mr *MockStoreMockRecorder) GetLineImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLineImport", reflect.TypeOf((*MockStore)(nil).GetLineImport), arg0, arg1)
+}
+
+// GetMonth mocks base method.
+func (m *MockStore) GetMonth(arg0 context.Context, arg1 int64) (db.Month, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonth", arg0, arg1)
+	ret0, _ := ret[0].(db.Month)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonth indicates an expected call of GetMonth.
+func (mr *MockStoreMockRecorder) GetMonth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonth", reflect.TypeOf((*MockStore)(nil).GetMonth), arg0, arg1)
+}
+
+// GetMonthByDate mocks base method.
+func (m *MockStore) GetMonthByDate(arg0 context.Context, arg1 db.GetMonthByDateParams) (db.Month, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthByDate", arg0, arg1)
+	ret0, _ := ret[0].(db.Month)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthByDate indicates an expected call of GetMonthByDate.
+func (mr *MockStoreMockRecorder) GetMonthByDate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthByDate", reflect.TypeOf((*MockStore)(nil).GetMonthByDate), arg0, arg1)
+}
+
+// GetMonthForUpdate mocks base method.
+func (m *MockStore) GetMonthForUpdate(arg0 context.Context, arg1 int64) (db.Month, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Month)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthForUpdate indicates an expected call of GetMonthForUpdate.
+func (mr *MockStoreMockRecorder) GetMonthForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthForUpdate", reflect.TypeOf((*MockStore)(nil).GetMonthForUpdate), arg0, arg1)
+}
+
+// GetRecLine mocks base method.
+func (m *MockStore) GetRecLine(arg0 context.Context, arg1 int64) (db.Recline, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRecLine", arg0, arg1)
 	ret0, _ := ret[0].(db.Recline)
@@ -473,190 +1041,999 @@ func (m *MockStore) GetRecLine(arg0 context.Context, arg1 int64) (db.Recline, er
 	return ret0, ret1
 }
 
-// GetRecLine indicates an expected call of GetRecLine.
-func (mr *MockStoreMockRecorder) GetRecLine(arg0, arg1 interface{}) *gomock.Call {
+// GetRecLine indicates an expected call of GetRecLine.
+func (mr *MockStoreMockRecorder) GetRecLine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecLine", reflect.TypeOf((*MockStore)(nil).GetRecLine), arg0, arg1)
+}
+
+// GetRecLineByTitle mocks base method.
+func (m *MockStore) GetRecLineByTitle(arg0 context.Context, arg1 db.GetRecLineByTitleParams) (db.Recline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecLineByTitle", arg0, arg1)
+	ret0, _ := ret[0].(db.Recline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecLineByTitle indicates an expected call of GetRecLineByTitle.
+func (mr *MockStoreMockRecorder) GetRecLineByTitle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecLineByTitle", reflect.TypeOf((*MockStore)(nil).GetRecLineByTitle), arg0, arg1)
+}
+
+// GetRecLineForUpdate mocks base method.
+func (m *MockStore) GetRecLineForUpdate(arg0 context.Context, arg1 int64) (db.Recline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecLineForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Recline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecLineForUpdate indicates an expected call of GetRecLineForUpdate.
+func (mr *MockStoreMockRecorder) GetRecLineForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecLineForUpdate", reflect.TypeOf((*MockStore)(nil).GetRecLineForUpdate), arg0, arg1)
+}
+
+// GetRule mocks base method.
+func (m *MockStore) GetRule(arg0 context.Context, arg1 int64) (db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRule", arg0, arg1)
+	ret0, _ := ret[0].(db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRule indicates an expected call of GetRule.
+func (mr *MockStoreMockRecorder) GetRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRule", reflect.TypeOf((*MockStore)(nil).GetRule), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetYear mocks base method.
+func (m *MockStore) GetYear(arg0 context.Context, arg1 int64) (db.Year, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYear", arg0, arg1)
+	ret0, _ := ret[0].(db.Year)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYear indicates an expected call of GetYear.
+func (mr *MockStoreMockRecorder) GetYear(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYear", reflect.TypeOf((*MockStore)(nil).GetYear), arg0, arg1)
+}
+
+// GetYearByDate mocks base method.
+func (m *MockStore) GetYearByDate(arg0 context.Context, arg1 db.GetYearByDateParams) (db.Year, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYearByDate", arg0, arg1)
+	ret0, _ := ret[0].(db.Year)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYearByDate indicates an expected call of GetYearByDate.
+func (mr *MockStoreMockRecorder) GetYearByDate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYearByDate", reflect.TypeOf((*MockStore)(nil).GetYearByDate), arg0, arg1)
+}
+
+// GetYearForUpdate mocks base method.
+func (m *MockStore) GetYearForUpdate(arg0 context.Context, arg1 int64) (db.Year, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYearForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Year)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYearForUpdate indicates an expected call of GetYearForUpdate.
+func (mr *MockStoreMockRecorder) GetYearForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYearForUpdate", reflect.TypeOf((*MockStore)(nil).GetYearForUpdate), arg0, arg1)
+}
+
+// GoalProgressTx mocks base method.
+func (m *MockStore) GoalProgressTx(arg0 context.Context, arg1 db.GoalProgressTxParams) (db.GoalProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GoalProgressTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GoalProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GoalProgressTx indicates an expected call of GoalProgressTx.
+func (mr *MockStoreMockRecorder) GoalProgressTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoalProgressTx", reflect.TypeOf((*MockStore)(nil).GoalProgressTx), arg0, arg1)
+}
+
+// ImportBookTx mocks base method.
+func (m *MockStore) ImportBookTx(arg0 context.Context, arg1 db.ImportBookTxParams) (db.ImportBookTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBookTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportBookTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBookTx indicates an expected call of ImportBookTx.
+func (mr *MockStoreMockRecorder) ImportBookTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBookTx", reflect.TypeOf((*MockStore)(nil).ImportBookTx), arg0, arg1)
+}
+
+// ImportLinesTx mocks base method.
+func (m *MockStore) ImportLinesTx(arg0 context.Context, arg1 db.ImportLinesTxParams) (db.ImportLinesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLinesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportLinesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportLinesTx indicates an expected call of ImportLinesTx.
+func (mr *MockStoreMockRecorder) ImportLinesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLinesTx", reflect.TypeOf((*MockStore)(nil).ImportLinesTx), arg0, arg1)
+}
+
+// ListAccountHistory mocks base method.
+func (m *MockStore) ListAccountHistory(arg0 context.Context, arg1 db.ListAccountHistoryParams) ([]db.ListAccountHistoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountHistory", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListAccountHistoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountHistory indicates an expected call of ListAccountHistory.
+func (mr *MockStoreMockRecorder) ListAccountHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountHistory", reflect.TypeOf((*MockStore)(nil).ListAccountHistory), arg0, arg1)
+}
+
+// ListAccountIDs mocks base method.
+func (m *MockStore) ListAccountIDs(arg0 context.Context, arg1 db.ListAccountIDsParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountIDs indicates an expected call of ListAccountIDs.
+func (mr *MockStoreMockRecorder) ListAccountIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountIDs", reflect.TypeOf((*MockStore)(nil).ListAccountIDs), arg0, arg1)
+}
+
+// ListAccountLinesBetween mocks base method.
+func (m *MockStore) ListAccountLinesBetween(arg0 context.Context, arg1 db.ListAccountLinesBetweenParams) ([]db.ListAccountLinesBetweenRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountLinesBetween", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListAccountLinesBetweenRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountLinesBetween indicates an expected call of ListAccountLinesBetween.
+func (mr *MockStoreMockRecorder) ListAccountLinesBetween(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountLinesBetween", reflect.TypeOf((*MockStore)(nil).ListAccountLinesBetween), arg0, arg1)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+}
+
+// ListBalanceSnapshots mocks base method.
+func (m *MockStore) ListBalanceSnapshots(arg0 context.Context, arg1 db.ListBalanceSnapshotsParams) ([]db.BalanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBalanceSnapshots", arg0, arg1)
+	ret0, _ := ret[0].([]db.BalanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBalanceSnapshots indicates an expected call of ListBalanceSnapshots.
+func (mr *MockStoreMockRecorder) ListBalanceSnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBalanceSnapshots", reflect.TypeOf((*MockStore)(nil).ListBalanceSnapshots), arg0, arg1)
+}
+
+// ListBudgetAlerts mocks base method.
+func (m *MockStore) ListBudgetAlerts(arg0 context.Context, arg1 db.ListBudgetAlertsParams) ([]db.BudgetAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBudgetAlerts", arg0, arg1)
+	ret0, _ := ret[0].([]db.BudgetAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBudgetAlerts indicates an expected call of ListBudgetAlerts.
+func (mr *MockStoreMockRecorder) ListBudgetAlerts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBudgetAlerts", reflect.TypeOf((*MockStore)(nil).ListBudgetAlerts), arg0, arg1)
+}
+
+// ListBudgets mocks base method.
+func (m *MockStore) ListBudgets(arg0 context.Context, arg1 db.ListBudgetsParams) ([]db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBudgets", arg0, arg1)
+	ret0, _ := ret[0].([]db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBudgets indicates an expected call of ListBudgets.
+func (mr *MockStoreMockRecorder) ListBudgets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBudgets", reflect.TypeOf((*MockStore)(nil).ListBudgets), arg0, arg1)
+}
+
+// ListCategories mocks base method.
+func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
+}
+
+// ListCategoryAncestors mocks base method.
+func (m *MockStore) ListCategoryAncestors(arg0 context.Context, arg1 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryAncestors", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryAncestors indicates an expected call of ListCategoryAncestors.
+func (mr *MockStoreMockRecorder) ListCategoryAncestors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryAncestors", reflect.TypeOf((*MockStore)(nil).ListCategoryAncestors), arg0, arg1)
+}
+
+// ListChargeLines mocks base method.
+func (m *MockStore) ListChargeLines(arg0 context.Context, arg1 string) ([]db.ListChargeLinesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChargeLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListChargeLinesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChargeLines indicates an expected call of ListChargeLines.
+func (mr *MockStoreMockRecorder) ListChargeLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChargeLines", reflect.TypeOf((*MockStore)(nil).ListChargeLines), arg0, arg1)
+}
+
+// ListDismissedLineAnomalies mocks base method.
+func (m *MockStore) ListDismissedLineAnomalies(arg0 context.Context, arg1 db.ListDismissedLineAnomaliesParams) ([]db.LineAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDismissedLineAnomalies", arg0, arg1)
+	ret0, _ := ret[0].([]db.LineAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDismissedLineAnomalies indicates an expected call of ListDismissedLineAnomalies.
+func (mr *MockStoreMockRecorder) ListDismissedLineAnomalies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDismissedLineAnomalies", reflect.TypeOf((*MockStore)(nil).ListDismissedLineAnomalies), arg0, arg1)
+}
+
+// ListEnvelopeAssignments mocks base method.
+func (m *MockStore) ListEnvelopeAssignments(arg0 context.Context, arg1 db.ListEnvelopeAssignmentsParams) ([]db.EnvelopeAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnvelopeAssignments", arg0, arg1)
+	ret0, _ := ret[0].([]db.EnvelopeAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnvelopeAssignments indicates an expected call of ListEnvelopeAssignments.
+func (mr *MockStoreMockRecorder) ListEnvelopeAssignments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvelopeAssignments", reflect.TypeOf((*MockStore)(nil).ListEnvelopeAssignments), arg0, arg1)
+}
+
+// ListExplicitLines mocks base method.
+func (m *MockStore) ListExplicitLines(arg0 context.Context, arg1 db.ListExplicitLinesParams) ([]db.ListExplicitLinesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExplicitLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListExplicitLinesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExplicitLines indicates an expected call of ListExplicitLines.
+func (mr *MockStoreMockRecorder) ListExplicitLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExplicitLines", reflect.TypeOf((*MockStore)(nil).ListExplicitLines), arg0, arg1)
+}
+
+// ListExplicitRecLines mocks base method.
+func (m *MockStore) ListExplicitRecLines(arg0 context.Context, arg1 string) ([]db.ListExplicitRecLinesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExplicitRecLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListExplicitRecLinesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExplicitRecLines indicates an expected call of ListExplicitRecLines.
+func (mr *MockStoreMockRecorder) ListExplicitRecLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExplicitRecLines", reflect.TypeOf((*MockStore)(nil).ListExplicitRecLines), arg0, arg1)
+}
+
+// ListGoalProgressTx mocks base method.
+func (m *MockStore) ListGoalProgressTx(arg0 context.Context, arg1 db.ListGoalProgressTxParams) ([]db.GoalProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoalProgressTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.GoalProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoalProgressTx indicates an expected call of ListGoalProgressTx.
+func (mr *MockStoreMockRecorder) ListGoalProgressTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoalProgressTx", reflect.TypeOf((*MockStore)(nil).ListGoalProgressTx), arg0, arg1)
+}
+
+// ListGoals mocks base method.
+func (m *MockStore) ListGoals(arg0 context.Context, arg1 db.ListGoalsParams) ([]db.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoals", arg0, arg1)
+	ret0, _ := ret[0].([]db.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoals indicates an expected call of ListGoals.
+func (mr *MockStoreMockRecorder) ListGoals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoals", reflect.TypeOf((*MockStore)(nil).ListGoals), arg0, arg1)
+}
+
+// ListLineAnomalies mocks base method.
+func (m *MockStore) ListLineAnomalies(arg0 context.Context, arg1 db.ListLineAnomaliesParams) ([]db.ListLineAnomaliesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLineAnomalies", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLineAnomaliesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLineAnomalies indicates an expected call of ListLineAnomalies.
+func (mr *MockStoreMockRecorder) ListLineAnomalies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLineAnomalies", reflect.TypeOf((*MockStore)(nil).ListLineAnomalies), arg0, arg1)
+}
+
+// ListLines mocks base method.
+func (m *MockStore) ListLines(arg0 context.Context, arg1 db.ListLinesParams) ([]db.Line, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.Line)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLines indicates an expected call of ListLines.
+func (mr *MockStoreMockRecorder) ListLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLines", reflect.TypeOf((*MockStore)(nil).ListLines), arg0, arg1)
+}
+
+// ListLinesBetween mocks base method.
+func (m *MockStore) ListLinesBetween(arg0 context.Context, arg1 db.ListLinesBetweenParams) ([]db.Line, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinesBetween", arg0, arg1)
+	ret0, _ := ret[0].([]db.Line)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLinesBetween indicates an expected call of ListLinesBetween.
+func (mr *MockStoreMockRecorder) ListLinesBetween(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinesBetween", reflect.TypeOf((*MockStore)(nil).ListLinesBetween), arg0, arg1)
+}
+
+// ListMonthBudgets mocks base method.
+func (m *MockStore) ListMonthBudgets(arg0 context.Context, arg1 db.ListMonthBudgetsParams) ([]db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMonthBudgets", arg0, arg1)
+	ret0, _ := ret[0].([]db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMonthBudgets indicates an expected call of ListMonthBudgets.
+func (mr *MockStoreMockRecorder) ListMonthBudgets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMonthBudgets", reflect.TypeOf((*MockStore)(nil).ListMonthBudgets), arg0, arg1)
+}
+
+// ListMonths mocks base method.
+func (m *MockStore) ListMonths(arg0 context.Context, arg1 db.ListMonthsParams) ([]db.Month, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMonths", arg0, arg1)
+	ret0, _ := ret[0].([]db.Month)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMonths indicates an expected call of ListMonths.
+func (mr *MockStoreMockRecorder) ListMonths(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMonths", reflect.TypeOf((*MockStore)(nil).ListMonths), arg0, arg1)
+}
+
+// ListRecLines mocks base method.
+func (m *MockStore) ListRecLines(arg0 context.Context, arg1 db.ListRecLinesParams) ([]db.Recline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.Recline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecLines indicates an expected call of ListRecLines.
+func (mr *MockStoreMockRecorder) ListRecLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecLines", reflect.TypeOf((*MockStore)(nil).ListRecLines), arg0, arg1)
+}
+
+// ListRules mocks base method.
+func (m *MockStore) ListRules(arg0 context.Context, arg1 db.ListRulesParams) ([]db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", arg0, arg1)
+	ret0, _ := ret[0].([]db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockStoreMockRecorder) ListRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockStore)(nil).ListRules), arg0, arg1)
+}
+
+// ListUncheckedLinesBefore mocks base method.
+func (m *MockStore) ListUncheckedLinesBefore(arg0 context.Context, arg1 db.ListUncheckedLinesBeforeParams) ([]db.Line, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUncheckedLinesBefore", arg0, arg1)
+	ret0, _ := ret[0].([]db.Line)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUncheckedLinesBefore indicates an expected call of ListUncheckedLinesBefore.
+func (mr *MockStoreMockRecorder) ListUncheckedLinesBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUncheckedLinesBefore", reflect.TypeOf((*MockStore)(nil).ListUncheckedLinesBefore), arg0, arg1)
+}
+
+// ListUpcomingLines mocks base method.
+func (m *MockStore) ListUpcomingLines(arg0 context.Context, arg1 db.ListUpcomingLinesParams) ([]db.ListUpcomingLinesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUpcomingLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUpcomingLinesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUpcomingLines indicates an expected call of ListUpcomingLines.
+func (mr *MockStoreMockRecorder) ListUpcomingLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUpcomingLines", reflect.TypeOf((*MockStore)(nil).ListUpcomingLines), arg0, arg1)
+}
+
+// ListUsernames mocks base method.
+func (m *MockStore) ListUsernames(arg0 context.Context, arg1 db.ListUsernamesParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsernames", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsernames indicates an expected call of ListUsernames.
+func (mr *MockStoreMockRecorder) ListUsernames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsernames", reflect.TypeOf((*MockStore)(nil).ListUsernames), arg0, arg1)
+}
+
+// ListYears mocks base method.
+func (m *MockStore) ListYears(arg0 context.Context, arg1 db.ListYearsParams) ([]db.Year, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListYears", arg0, arg1)
+	ret0, _ := ret[0].([]db.Year)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListYears indicates an expected call of ListYears.
+func (mr *MockStoreMockRecorder) ListYears(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListYears", reflect.TypeOf((*MockStore)(nil).ListYears), arg0, arg1)
+}
+
+// LockCategoryTree mocks base method.
+func (m *MockStore) LockCategoryTree(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCategoryTree", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockCategoryTree indicates an expected call of LockCategoryTree.
+func (mr *MockStoreMockRecorder) LockCategoryTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCategoryTree", reflect.TypeOf((*MockStore)(nil).LockCategoryTree), arg0, arg1)
+}
+
+// MarkBudgetAlertRead mocks base method.
+func (m *MockStore) MarkBudgetAlertRead(arg0 context.Context, arg1 int64) (db.BudgetAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBudgetAlertRead", arg0, arg1)
+	ret0, _ := ret[0].(db.BudgetAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkBudgetAlertRead indicates an expected call of MarkBudgetAlertRead.
+func (mr *MockStoreMockRecorder) MarkBudgetAlertRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBudgetAlertRead", reflect.TypeOf((*MockStore)(nil).MarkBudgetAlertRead), arg0, arg1)
+}
+
+// MergeCategoryTx mocks base method.
+func (m *MockStore) MergeCategoryTx(arg0 context.Context, arg1 db.MergeCategoryTxParams) (db.MergeCategoryTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MergeCategoryTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeCategoryTx indicates an expected call of MergeCategoryTx.
+func (mr *MockStoreMockRecorder) MergeCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCategoryTx", reflect.TypeOf((*MockStore)(nil).MergeCategoryTx), arg0, arg1)
+}
+
+// MonthBudgetTx mocks base method.
+func (m *MockStore) MonthBudgetTx(arg0 context.Context, arg1 db.MonthBudgetTxParams) (db.MonthBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MonthBudgetTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MonthBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MonthBudgetTx indicates an expected call of MonthBudgetTx.
+func (mr *MockStoreMockRecorder) MonthBudgetTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecLine", reflect.TypeOf((*MockStore)(nil).GetRecLine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonthBudgetTx", reflect.TypeOf((*MockStore)(nil).MonthBudgetTx), arg0, arg1)
 }
 
-// GetRecLineForUpdate mocks base method.
-func (m *MockStore) GetRecLineForUpdate(arg0 context.Context, arg1 int64) (db.Recline, error) {
+// MonthEnvelopesTx mocks base method.
+func (m *MockStore) MonthEnvelopesTx(arg0 context.Context, arg1 db.MonthEnvelopesTxParams) (db.MonthEnvelopes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecLineForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Recline)
+	ret := m.ctrl.Call(m, "MonthEnvelopesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MonthEnvelopes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRecLineForUpdate indicates an expected call of GetRecLineForUpdate.
-func (mr *MockStoreMockRecorder) GetRecLineForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// MonthEnvelopesTx indicates an expected call of MonthEnvelopesTx.
+func (mr *MockStoreMockRecorder) MonthEnvelopesTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecLineForUpdate", reflect.TypeOf((*MockStore)(nil).GetRecLineForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonthEnvelopesTx", reflect.TypeOf((*MockStore)(nil).MonthEnvelopesTx), arg0, arg1)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+// MoveCategory mocks base method.
+func (m *MockStore) MoveCategory(arg0 context.Context, arg1 db.MoveCategoryParams) (db.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "MoveCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+// MoveCategory indicates an expected call of MoveCategory.
+func (mr *MockStoreMockRecorder) MoveCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCategory", reflect.TypeOf((*MockStore)(nil).MoveCategory), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+// MoveCategoryChildren mocks base method.
+func (m *MockStore) MoveCategoryChildren(arg0 context.Context, arg1 db.MoveCategoryChildrenParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "MoveCategoryChildren", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// MoveCategoryChildren indicates an expected call of MoveCategoryChildren.
+func (mr *MockStoreMockRecorder) MoveCategoryChildren(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCategoryChildren", reflect.TypeOf((*MockStore)(nil).MoveCategoryChildren), arg0, arg1)
 }
 
-// GetYear mocks base method.
-func (m *MockStore) GetYear(arg0 context.Context, arg1 int64) (db.Year, error) {
+// MoveCategoryTx mocks base method.
+func (m *MockStore) MoveCategoryTx(arg0 context.Context, arg1 db.MoveCategoryTxParams) (db.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetYear", arg0, arg1)
-	ret0, _ := ret[0].(db.Year)
+	ret := m.ctrl.Call(m, "MoveCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetYear indicates an expected call of GetYear.
-func (mr *MockStoreMockRecorder) GetYear(arg0, arg1 interface{}) *gomock.Call {
+// MoveCategoryTx indicates an expected call of MoveCategoryTx.
+func (mr *MockStoreMockRecorder) MoveCategoryTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYear", reflect.TypeOf((*MockStore)(nil).GetYear), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCategoryTx", reflect.TypeOf((*MockStore)(nil).MoveCategoryTx), arg0, arg1)
 }
 
-// GetYearForUpdate mocks base method.
-func (m *MockStore) GetYearForUpdate(arg0 context.Context, arg1 int64) (db.Year, error) {
+// NetWorthTx mocks base method.
+func (m *MockStore) NetWorthTx(arg0 context.Context, arg1 db.NetWorthTxParams) (db.NetWorth, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetYearForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Year)
+	ret := m.ctrl.Call(m, "NetWorthTx", arg0, arg1)
+	ret0, _ := ret[0].(db.NetWorth)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetYearForUpdate indicates an expected call of GetYearForUpdate.
-func (mr *MockStoreMockRecorder) GetYearForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// NetWorthTx indicates an expected call of NetWorthTx.
+func (mr *MockStoreMockRecorder) NetWorthTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYearForUpdate", reflect.TypeOf((*MockStore)(nil).GetYearForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetWorthTx", reflect.TypeOf((*MockStore)(nil).NetWorthTx), arg0, arg1)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+// ReassignBudgetsCategory mocks base method.
+func (m *MockStore) ReassignBudgetsCategory(arg0 context.Context, arg1 db.ReassignBudgetsCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "ReassignBudgetsCategory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+// ReassignBudgetsCategory indicates an expected call of ReassignBudgetsCategory.
+func (mr *MockStoreMockRecorder) ReassignBudgetsCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignBudgetsCategory", reflect.TypeOf((*MockStore)(nil).ReassignBudgetsCategory), arg0, arg1)
 }
 
-// ListCategories mocks base method.
-func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
+// ReassignEnvelopeAssignmentsCategory mocks base method.
+func (m *MockStore) ReassignEnvelopeAssignmentsCategory(arg0 context.Context, arg1 db.ReassignEnvelopeAssignmentsCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
-	ret0, _ := ret[0].([]db.Category)
+	ret := m.ctrl.Call(m, "ReassignEnvelopeAssignmentsCategory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCategories indicates an expected call of ListCategories.
-func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 interface{}) *gomock.Call {
+// ReassignEnvelopeAssignmentsCategory indicates an expected call of ReassignEnvelopeAssignmentsCategory.
+func (mr *MockStoreMockRecorder) ReassignEnvelopeAssignmentsCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignEnvelopeAssignmentsCategory", reflect.TypeOf((*MockStore)(nil).ReassignEnvelopeAssignmentsCategory), arg0, arg1)
 }
 
-// ListExplicitLines mocks base method.
-func (m *MockStore) ListExplicitLines(arg0 context.Context, arg1 db.ListExplicitLinesParams) ([]db.ListExplicitLinesRow, error) {
+// ReassignGoalsCategory mocks base method.
+func (m *MockStore) ReassignGoalsCategory(arg0 context.Context, arg1 db.ReassignGoalsCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExplicitLines", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListExplicitLinesRow)
+	ret := m.ctrl.Call(m, "ReassignGoalsCategory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExplicitLines indicates an expected call of ListExplicitLines.
-func (mr *MockStoreMockRecorder) ListExplicitLines(arg0, arg1 interface{}) *gomock.Call {
+// ReassignGoalsCategory indicates an expected call of ReassignGoalsCategory.
+func (mr *MockStoreMockRecorder) ReassignGoalsCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExplicitLines", reflect.TypeOf((*MockStore)(nil).ListExplicitLines), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignGoalsCategory", reflect.TypeOf((*MockStore)(nil).ReassignGoalsCategory), arg0, arg1)
 }
 
-// ListLines mocks base method.
-func (m *MockStore) ListLines(arg0 context.Context, arg1 db.ListLinesParams) ([]db.Line, error) {
+// ReassignLinesCategory mocks base method.
+func (m *MockStore) ReassignLinesCategory(arg0 context.Context, arg1 db.ReassignLinesCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLines", arg0, arg1)
-	ret0, _ := ret[0].([]db.Line)
+	ret := m.ctrl.Call(m, "ReassignLinesCategory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLines indicates an expected call of ListLines.
-func (mr *MockStoreMockRecorder) ListLines(arg0, arg1 interface{}) *gomock.Call {
+// ReassignLinesCategory indicates an expected call of ReassignLinesCategory.
+func (mr *MockStoreMockRecorder) ReassignLinesCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLines", reflect.TypeOf((*MockStore)(nil).ListLines), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignLinesCategory", reflect.TypeOf((*MockStore)(nil).ReassignLinesCategory), arg0, arg1)
 }
 
-// ListMonths mocks base method.
-func (m *MockStore) ListMonths(arg0 context.Context, arg1 db.ListMonthsParams) ([]db.Month, error) {
+// ReassignRecLinesCategory mocks base method.
+func (m *MockStore) ReassignRecLinesCategory(arg0 context.Context, arg1 db.ReassignRecLinesCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMonths", arg0, arg1)
-	ret0, _ := ret[0].([]db.Month)
+	ret := m.ctrl.Call(m, "ReassignRecLinesCategory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListMonths indicates an expected call of ListMonths.
-func (mr *MockStoreMockRecorder) ListMonths(arg0, arg1 interface{}) *gomock.Call {
+// ReassignRecLinesCategory indicates an expected call of ReassignRecLinesCategory.
+func (mr *MockStoreMockRecorder) ReassignRecLinesCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMonths", reflect.TypeOf((*MockStore)(nil).ListMonths), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignRecLinesCategory", reflect.TypeOf((*MockStore)(nil).ReassignRecLinesCategory), arg0, arg1)
 }
 
-// ListRecLines mocks base method.
-func (m *MockStore) ListRecLines(arg0 context.Context, arg1 db.ListRecLinesParams) ([]db.Recline, error) {
+// ReassignRulesCategory mocks base method.
+func (m *MockStore) ReassignRulesCategory(arg0 context.Context, arg1 db.ReassignRulesCategoryParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRecLines", arg0, arg1)
-	ret0, _ := ret[0].([]db.Recline)
+	ret := m.ctrl.Call(m, "ReassignRulesCategory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListRecLines indicates an expected call of ListRecLines.
-func (mr *MockStoreMockRecorder) ListRecLines(arg0, arg1 interface{}) *gomock.Call {
+// ReassignRulesCategory indicates an expected call of ReassignRulesCategory.
+func (mr *MockStoreMockRecorder) ReassignRulesCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecLines", reflect.TypeOf((*MockStore)(nil).ListRecLines), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignRulesCategory", reflect.TypeOf((*MockStore)(nil).ReassignRulesCategory), arg0, arg1)
 }
 
-// ListYears mocks base method.
-func (m *MockStore) ListYears(arg0 context.Context, arg1 db.ListYearsParams) ([]db.Year, error) {
+// RefreshBalanceSnapshotsTx mocks base method.
+func (m *MockStore) RefreshBalanceSnapshotsTx(arg0 context.Context, arg1 db.RefreshBalanceSnapshotsTxParams) (db.RefreshBalanceSnapshotsTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListYears", arg0, arg1)
-	ret0, _ := ret[0].([]db.Year)
+	ret := m.ctrl.Call(m, "RefreshBalanceSnapshotsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RefreshBalanceSnapshotsTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListYears indicates an expected call of ListYears.
-func (mr *MockStoreMockRecorder) ListYears(arg0, arg1 interface{}) *gomock.Call {
+// RefreshBalanceSnapshotsTx indicates an expected call of RefreshBalanceSnapshotsTx.
+func (mr *MockStoreMockRecorder) RefreshBalanceSnapshotsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListYears", reflect.TypeOf((*MockStore)(nil).ListYears), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshBalanceSnapshotsTx", reflect.TypeOf((*MockStore)(nil).RefreshBalanceSnapshotsTx), arg0, arg1)
+}
+
+// ReportLinesByCategory mocks base method.
+func (m *MockStore) ReportLinesByCategory(arg0 context.Context, arg1 db.ReportLinesByCategoryParams) ([]db.ReportLinesByCategoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportLinesByCategory", arg0, arg1)
+	ret0, _ := ret[0].([]db.ReportLinesByCategoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportLinesByCategory indicates an expected call of ReportLinesByCategory.
+func (mr *MockStoreMockRecorder) ReportLinesByCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportLinesByCategory", reflect.TypeOf((*MockStore)(nil).ReportLinesByCategory), arg0, arg1)
+}
+
+// RestoreBackupTx mocks base method.
+func (m *MockStore) RestoreBackupTx(arg0 context.Context, arg1 db.RestoreBackupTxParams) (db.RestoreBackupTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBackupTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RestoreBackupTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBackupTx indicates an expected call of RestoreBackupTx.
+func (mr *MockStoreMockRecorder) RestoreBackupTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBackupTx", reflect.TypeOf((*MockStore)(nil).RestoreBackupTx), arg0, arg1)
+}
+
+// RunRulesTx mocks base method.
+func (m *MockStore) RunRulesTx(arg0 context.Context, arg1 db.RunRulesTxParams) (db.RunRulesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRulesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RunRulesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunRulesTx indicates an expected call of RunRulesTx.
+func (mr *MockStoreMockRecorder) RunRulesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRulesTx", reflect.TypeOf((*MockStore)(nil).RunRulesTx), arg0, arg1)
+}
+
+// SeedCategoriesTx mocks base method.
+func (m *MockStore) SeedCategoriesTx(arg0 context.Context, arg1 db.SeedCategoriesTxParams) (db.SeedCategoriesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedCategoriesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SeedCategoriesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeedCategoriesTx indicates an expected call of SeedCategoriesTx.
+func (mr *MockStoreMockRecorder) SeedCategoriesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedCategoriesTx", reflect.TypeOf((*MockStore)(nil).SeedCategoriesTx), arg0, arg1)
+}
+
+// SetEnvelopeAssignment mocks base method.
+func (m *MockStore) SetEnvelopeAssignment(arg0 context.Context, arg1 db.SetEnvelopeAssignmentParams) (db.EnvelopeAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnvelopeAssignment", arg0, arg1)
+	ret0, _ := ret[0].(db.EnvelopeAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEnvelopeAssignment indicates an expected call of SetEnvelopeAssignment.
+func (mr *MockStoreMockRecorder) SetEnvelopeAssignment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnvelopeAssignment", reflect.TypeOf((*MockStore)(nil).SetEnvelopeAssignment), arg0, arg1)
+}
+
+// SumAccountLinesBefore mocks base method.
+func (m *MockStore) SumAccountLinesBefore(arg0 context.Context, arg1 db.SumAccountLinesBeforeParams) (db.SumAccountLinesBeforeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumAccountLinesBefore", arg0, arg1)
+	ret0, _ := ret[0].(db.SumAccountLinesBeforeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumAccountLinesBefore indicates an expected call of SumAccountLinesBefore.
+func (mr *MockStoreMockRecorder) SumAccountLinesBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumAccountLinesBefore", reflect.TypeOf((*MockStore)(nil).SumAccountLinesBefore), arg0, arg1)
+}
+
+// SumEnvelopeActivity mocks base method.
+func (m *MockStore) SumEnvelopeActivity(arg0 context.Context, arg1 db.SumEnvelopeActivityParams) ([]db.SumEnvelopeActivityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumEnvelopeActivity", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumEnvelopeActivityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumEnvelopeActivity indicates an expected call of SumEnvelopeActivity.
+func (mr *MockStoreMockRecorder) SumEnvelopeActivity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumEnvelopeActivity", reflect.TypeOf((*MockStore)(nil).SumEnvelopeActivity), arg0, arg1)
+}
+
+// SumEnvelopeAssignments mocks base method.
+func (m *MockStore) SumEnvelopeAssignments(arg0 context.Context, arg1 db.SumEnvelopeAssignmentsParams) ([]db.SumEnvelopeAssignmentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumEnvelopeAssignments", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumEnvelopeAssignmentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumEnvelopeAssignments indicates an expected call of SumEnvelopeAssignments.
+func (mr *MockStoreMockRecorder) SumEnvelopeAssignments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumEnvelopeAssignments", reflect.TypeOf((*MockStore)(nil).SumEnvelopeAssignments), arg0, arg1)
+}
+
+// SumGoalLines mocks base method.
+func (m *MockStore) SumGoalLines(arg0 context.Context, arg1 db.SumGoalLinesParams) (db.SumGoalLinesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumGoalLines", arg0, arg1)
+	ret0, _ := ret[0].(db.SumGoalLinesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumGoalLines indicates an expected call of SumGoalLines.
+func (mr *MockStoreMockRecorder) SumGoalLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumGoalLines", reflect.TypeOf((*MockStore)(nil).SumGoalLines), arg0, arg1)
+}
+
+// SumLinesByAccountAndPeriod mocks base method.
+func (m *MockStore) SumLinesByAccountAndPeriod(arg0 context.Context, arg1 db.SumLinesByAccountAndPeriodParams) ([]db.SumLinesByAccountAndPeriodRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumLinesByAccountAndPeriod", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumLinesByAccountAndPeriodRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumLinesByAccountAndPeriod indicates an expected call of SumLinesByAccountAndPeriod.
+func (mr *MockStoreMockRecorder) SumLinesByAccountAndPeriod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLinesByAccountAndPeriod", reflect.TypeOf((*MockStore)(nil).SumLinesByAccountAndPeriod), arg0, arg1)
+}
+
+// SumLinesByCategory mocks base method.
+func (m *MockStore) SumLinesByCategory(arg0 context.Context, arg1 db.SumLinesByCategoryParams) ([]db.SumLinesByCategoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumLinesByCategory", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumLinesByCategoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumLinesByCategory indicates an expected call of SumLinesByCategory.
+func (mr *MockStoreMockRecorder) SumLinesByCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLinesByCategory", reflect.TypeOf((*MockStore)(nil).SumLinesByCategory), arg0, arg1)
+}
+
+// SumLinesByTitle mocks base method.
+func (m *MockStore) SumLinesByTitle(arg0 context.Context, arg1 db.SumLinesByTitleParams) ([]db.SumLinesByTitleRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumLinesByTitle", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumLinesByTitleRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumLinesByTitle indicates an expected call of SumLinesByTitle.
+func (mr *MockStoreMockRecorder) SumLinesByTitle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLinesByTitle", reflect.TypeOf((*MockStore)(nil).SumLinesByTitle), arg0, arg1)
 }
 
 // UpdateAccount mocks base method.
@@ -674,6 +2051,51 @@ func (mr *MockStoreMockRecorder) UpdateAccount(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), arg0, arg1)
 }
 
+// UpdateBudget mocks base method.
+func (m *MockStore) UpdateBudget(arg0 context.Context, arg1 db.UpdateBudgetParams) (db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBudget indicates an expected call of UpdateBudget.
+func (mr *MockStoreMockRecorder) UpdateBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBudget", reflect.TypeOf((*MockStore)(nil).UpdateBudget), arg0, arg1)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockStore) UpdateCategory(arg0 context.Context, arg1 db.UpdateCategoryParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockStoreMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0, arg1)
+}
+
+// UpdateGoal mocks base method.
+func (m *MockStore) UpdateGoal(arg0 context.Context, arg1 db.UpdateGoalParams) (db.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoal", arg0, arg1)
+	ret0, _ := ret[0].(db.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoal indicates an expected call of UpdateGoal.
+func (mr *MockStoreMockRecorder) UpdateGoal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoal", reflect.TypeOf((*MockStore)(nil).UpdateGoal), arg0, arg1)
+}
+
 // UpdateLine mocks base method.
 func (m *MockStore) UpdateLine(arg0 context.Context, arg1 db.UpdateLineParams) (db.Line, error) {
 	m.ctrl.T.Helper()
@@ -689,6 +2111,21 @@ func (mr *MockStoreMockRecorder) UpdateLine(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLine", reflect.TypeOf((*MockStore)(nil).UpdateLine), arg0, arg1)
 }
 
+// UpdateLineRuleFields mocks base method.
+func (m *MockStore) UpdateLineRuleFields(arg0 context.Context, arg1 db.UpdateLineRuleFieldsParams) (db.Line, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLineRuleFields", arg0, arg1)
+	ret0, _ := ret[0].(db.Line)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLineRuleFields indicates an expected call of UpdateLineRuleFields.
+func (mr *MockStoreMockRecorder) UpdateLineRuleFields(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLineRuleFields", reflect.TypeOf((*MockStore)(nil).UpdateLineRuleFields), arg0, arg1)
+}
+
 // UpdateLineTx mocks base method.
 func (m *MockStore) UpdateLineTx(arg0 context.Context, arg1 db.UpdateLineTxParams) (db.UpdateLineTxResult, error) {
 	m.ctrl.T.Helper()
@@ -734,6 +2171,51 @@ func (mr *MockStoreMockRecorder) UpdateRecLine(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecLine", reflect.TypeOf((*MockStore)(nil).UpdateRecLine), arg0, arg1)
 }
 
+// UpdateRule mocks base method.
+func (m *MockStore) UpdateRule(arg0 context.Context, arg1 db.UpdateRuleParams) (db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", arg0, arg1)
+	ret0, _ := ret[0].(db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockStoreMockRecorder) UpdateRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockStore)(nil).UpdateRule), arg0, arg1)
+}
+
+// UpdateUserPreferences mocks base method.
+func (m *MockStore) UpdateUserPreferences(arg0 context.Context, arg1 db.UpdateUserPreferencesParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPreferences", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPreferences indicates an expected call of UpdateUserPreferences.
+func (mr *MockStoreMockRecorder) UpdateUserPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPreferences", reflect.TypeOf((*MockStore)(nil).UpdateUserPreferences), arg0, arg1)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockStore) UpdateUserProfile(arg0 context.Context, arg1 db.UpdateUserProfileParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockStoreMockRecorder) UpdateUserProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockStore)(nil).UpdateUserProfile), arg0, arg1)
+}
+
 // UpdateYear mocks base method.
 func (m *MockStore) UpdateYear(arg0 context.Context, arg1 db.UpdateYearParams) (db.Year, error) {
 	m.ctrl.T.Helper()
@@ -748,3 +2230,18 @@ func (mr *MockStoreMockRecorder) UpdateYear(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateYear", reflect.TypeOf((*MockStore)(nil).UpdateYear), arg0, arg1)
 }
+
+// UpsertCalendarToken mocks base method.
+func (m *MockStore) UpsertCalendarToken(arg0 context.Context, arg1 db.UpsertCalendarTokenParams) (db.CalendarToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCalendarToken", arg0, arg1)
+	ret0, _ := ret[0].(db.CalendarToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCalendarToken indicates an expected call of UpsertCalendarToken.
+func (mr *MockStoreMockRecorder) UpsertCalendarToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCalendarToken", reflect.TypeOf((*MockStore)(nil).UpsertCalendarToken), arg0, arg1)
+}
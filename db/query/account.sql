@@ -12,10 +12,21 @@ INSERT INTO accounts (
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountByTitle :one
+SELECT * FROM accounts
+WHERE owner = $1 AND title = $2
+LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
 
+-- name: ListAccountIDs :many
+SELECT id FROM accounts
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
 -- name: ListAccounts :many
 SELECT * FROM accounts
 WHERE owner = $1
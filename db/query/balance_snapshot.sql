@@ -0,0 +1,49 @@
+-- name: CreateBalanceSnapshots :execrows
+INSERT INTO balance_snapshots (
+  account_id,
+  date,
+  balance,
+  final_balance
+)
+SELECT
+  sqlc.arg(account_id)::bigint,
+  days.date::date,
+  sqlc.arg(balance)::numeric + SUM(COALESCE(daily.balance, 0)) OVER (ORDER BY days.date),
+  sqlc.arg(final_balance)::numeric + SUM(COALESCE(daily.final_balance, 0)) OVER (ORDER BY days.date)
+FROM generate_series(sqlc.arg(from_date)::date, sqlc.arg(to_date)::date, '1 day') AS days(date)
+LEFT JOIN (
+  SELECT
+    due_date,
+    SUM(amount) FILTER (WHERE checked) AS balance,
+    SUM(amount) AS final_balance
+  FROM lines
+  WHERE account_id = sqlc.arg(account_id)
+    AND due_date >= sqlc.arg(from_date)
+    AND due_date <= sqlc.arg(to_date)
+  GROUP BY due_date
+) AS daily ON daily.due_date = days.date::date
+ON CONFLICT (account_id, date) DO UPDATE
+SET balance = EXCLUDED.balance, final_balance = EXCLUDED.final_balance;
+
+-- name: GetFirstBalanceSnapshot :one
+SELECT * FROM balance_snapshots
+WHERE account_id = $1
+ORDER BY date
+LIMIT 1;
+
+-- name: GetLastBalanceSnapshot :one
+SELECT * FROM balance_snapshots
+WHERE account_id = $1
+ORDER BY date DESC
+LIMIT 1;
+
+-- name: ListBalanceSnapshots :many
+SELECT * FROM balance_snapshots
+WHERE account_id = sqlc.arg(account_id)
+  AND date >= sqlc.arg(from_date)
+  AND date <= sqlc.arg(to_date)
+ORDER BY date;
+
+-- name: DeleteBalanceSnapshotsFrom :execrows
+DELETE FROM balance_snapshots
+WHERE account_id = sqlc.arg(account_id) AND date >= sqlc.arg(from_date);
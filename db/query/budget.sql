@@ -0,0 +1,44 @@
+-- name: CreateBudget :one
+INSERT INTO budgets (
+  owner,
+  category_id,
+  month_id,
+  recurring,
+  amount
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetBudget :one
+SELECT * FROM budgets
+WHERE id = $1 LIMIT 1;
+
+-- name: ListBudgets :many
+SELECT * FROM budgets
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateBudget :one
+UPDATE budgets
+SET month_id = $2, recurring = $3, amount = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBudget :exec
+DELETE FROM budgets WHERE id = $1;
+
+-- name: ReassignBudgetsCategory :execrows
+INSERT INTO budgets (owner, category_id, month_id, recurring, amount)
+SELECT budgets.owner, sqlc.arg(target_id)::bigint, budgets.month_id, budgets.recurring, budgets.amount FROM budgets
+WHERE budgets.category_id = sqlc.arg(source_id)
+ON CONFLICT (category_id, month_id, recurring) DO UPDATE SET amount = budgets.amount + EXCLUDED.amount;
+
+-- name: ListMonthBudgets :many
+SELECT DISTINCT ON (budgets.category_id) budgets.id, budgets.owner, budgets.category_id, budgets.month_id, budgets.recurring, budgets.amount, budgets.create_at FROM budgets
+JOIN months ON months.id = budgets.month_id
+WHERE budgets.owner = sqlc.arg(owner)
+  AND (budgets.month_id = sqlc.arg(month_id)
+    OR (budgets.recurring AND months.start_date <= (SELECT start_date FROM months WHERE months.id = sqlc.arg(month_id))))
+ORDER BY budgets.category_id, (budgets.month_id = sqlc.arg(month_id) AND NOT budgets.recurring) DESC, months.start_date DESC, budgets.id DESC;
@@ -0,0 +1,32 @@
+-- name: CreateBudgetAlert :one
+INSERT INTO budget_alerts (
+  owner,
+  budget_id,
+  category_id,
+  month_id,
+  threshold,
+  amount,
+  spent
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (category_id, month_id, threshold) DO NOTHING
+RETURNING *;
+
+-- name: GetBudgetAlert :one
+SELECT * FROM budget_alerts
+WHERE id = $1 LIMIT 1;
+
+-- name: ListBudgetAlerts :many
+SELECT * FROM budget_alerts
+WHERE owner = sqlc.arg(owner)
+  AND (NOT sqlc.arg(unread_only)::bool OR NOT read)
+ORDER BY create_at DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: MarkBudgetAlertRead :one
+UPDATE budget_alerts
+SET read = true
+WHERE id = $1
+RETURNING *;
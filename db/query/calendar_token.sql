@@ -0,0 +1,17 @@
+-- name: UpsertCalendarToken :one
+INSERT INTO calendar_tokens (
+  owner,
+  token_hash
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (owner) DO UPDATE
+SET token_hash = EXCLUDED.token_hash, create_at = now()
+RETURNING *;
+
+-- name: GetCalendarTokenByHash :one
+SELECT * FROM calendar_tokens
+WHERE token_hash = $1 LIMIT 1;
+
+-- name: DeleteCalendarToken :exec
+DELETE FROM calendar_tokens WHERE owner = $1;
@@ -1,9 +1,13 @@
 -- name: CreateCategory :one
 INSERT INTO categories (
   title,
-  owner
+  owner,
+  parent_id,
+  kind,
+  color,
+  icon
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetCategory :one
@@ -22,4 +26,63 @@ LIMIT $2
 OFFSET $3;
 
 -- name: DeleteCategory :exec
-DELETE FROM categories WHERE id = $1;
\ No newline at end of file
+DELETE FROM categories WHERE id = $1;
+
+-- name: GetCategoryByTitle :one
+SELECT * FROM categories
+WHERE owner = sqlc.arg(owner) AND title = sqlc.arg(title)
+  AND parent_id IS NOT DISTINCT FROM sqlc.narg(parent_id)
+LIMIT 1;
+
+-- name: MoveCategory :one
+UPDATE categories
+SET parent_id = sqlc.narg(parent_id)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+  SELECT categories.id, categories.parent_id, 0 AS depth, ARRAY[categories.id] AS path FROM categories
+  WHERE categories.id = $1
+  UNION ALL
+  SELECT categories.id, categories.parent_id, ancestors.depth + 1, ancestors.path || categories.id FROM categories
+  JOIN ancestors ON categories.id = ancestors.parent_id
+  WHERE NOT categories.id = ANY(ancestors.path)
+)
+SELECT id FROM ancestors
+ORDER BY depth;
+
+-- name: LockCategoryTree :exec
+SELECT pg_advisory_xact_lock(hashtext(sqlc.arg(owner)::text));
+
+-- name: SumLinesByCategory :many
+SELECT category_id, COALESCE(SUM(amount),0)::numeric AS amount, COALESCE(SUM(amount) FILTER (WHERE checked),0)::numeric AS checked_amount FROM lines
+WHERE owner = sqlc.arg(owner)
+  AND (sqlc.narg(account_id)::bigint IS NULL OR account_id = sqlc.narg(account_id))
+  AND (sqlc.narg(month_id)::bigint IS NULL OR month_id = sqlc.narg(month_id))
+  AND (sqlc.narg(year_id)::bigint IS NULL OR year_id = sqlc.narg(year_id))
+GROUP BY category_id
+ORDER BY category_id;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET title = $2,
+    kind = $3,
+    color = $4,
+    icon = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: GetCategoryUsage :one
+SELECT
+  (SELECT COUNT(*) FROM lines WHERE lines.category_id = sqlc.arg(id)) AS lines,
+  (SELECT COUNT(*) FROM reclines WHERE reclines.category_id = sqlc.arg(id)) AS reclines,
+  (SELECT COUNT(*) FROM rules WHERE rules.set_category_id = sqlc.arg(id)) AS rules,
+  (SELECT COUNT(*) FROM budgets WHERE budgets.category_id = sqlc.arg(id)) AS budgets,
+  (SELECT COUNT(*) FROM envelope_assignments WHERE envelope_assignments.category_id = sqlc.arg(id)) AS envelopes,
+  (SELECT COUNT(*) FROM goals WHERE goals.category_id = sqlc.arg(id)) AS goals;
+
+-- name: MoveCategoryChildren :execrows
+UPDATE categories
+SET parent_id = sqlc.narg(parent_id)
+WHERE parent_id = sqlc.arg(old_parent_id);
@@ -0,0 +1,48 @@
+-- name: SetEnvelopeAssignment :one
+INSERT INTO envelope_assignments (
+  owner,
+  category_id,
+  month_id,
+  amount
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (category_id, month_id) DO UPDATE SET amount = EXCLUDED.amount
+RETURNING *;
+
+-- name: ListEnvelopeAssignments :many
+SELECT * FROM envelope_assignments
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ReassignEnvelopeAssignmentsCategory :execrows
+INSERT INTO envelope_assignments (owner, category_id, month_id, amount)
+SELECT envelope_assignments.owner, sqlc.arg(target_id)::bigint, envelope_assignments.month_id, envelope_assignments.amount FROM envelope_assignments
+WHERE envelope_assignments.category_id = sqlc.arg(source_id)
+ON CONFLICT (category_id, month_id) DO UPDATE SET amount = envelope_assignments.amount + EXCLUDED.amount;
+
+-- name: SumEnvelopeAssignments :many
+SELECT envelope_assignments.category_id,
+  COALESCE(SUM(envelope_assignments.amount) FILTER (WHERE envelope_assignments.month_id = sqlc.arg(month_id)),0)::numeric AS amount,
+  COALESCE(SUM(envelope_assignments.amount) FILTER (WHERE envelope_assignments.month_id <> sqlc.arg(month_id)),0)::numeric AS previous_amount
+FROM envelope_assignments
+JOIN months ON months.id = envelope_assignments.month_id
+WHERE envelope_assignments.owner = sqlc.arg(owner)
+  AND (envelope_assignments.month_id = sqlc.arg(month_id)
+    OR months.start_date < (SELECT start_date FROM months WHERE months.id = sqlc.arg(month_id)))
+GROUP BY envelope_assignments.category_id
+ORDER BY envelope_assignments.category_id;
+
+-- name: SumEnvelopeActivity :many
+SELECT lines.category_id,
+  COALESCE(SUM(lines.amount) FILTER (WHERE lines.month_id = sqlc.arg(month_id)),0)::numeric AS amount,
+  COALESCE(SUM(lines.amount) FILTER (WHERE lines.month_id <> sqlc.arg(month_id)),0)::numeric AS previous_amount
+FROM lines
+JOIN months ON months.id = lines.month_id
+WHERE lines.owner = sqlc.arg(owner)
+  AND (lines.month_id = sqlc.arg(month_id)
+    OR months.start_date < (SELECT start_date FROM months WHERE months.id = sqlc.arg(month_id)))
+GROUP BY lines.category_id
+ORDER BY lines.category_id;
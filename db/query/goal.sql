@@ -0,0 +1,44 @@
+-- name: CreateGoal :one
+INSERT INTO goals (
+  owner,
+  title,
+  account_id,
+  category_id,
+  target_amount,
+  target_date,
+  recline_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetGoal :one
+SELECT * FROM goals
+WHERE id = $1 LIMIT 1;
+
+-- name: ListGoals :many
+SELECT * FROM goals
+WHERE owner = $1
+ORDER BY target_date, id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateGoal :one
+UPDATE goals
+SET title = $2, account_id = $3, category_id = $4, target_amount = $5, target_date = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteGoal :exec
+DELETE FROM goals WHERE id = $1;
+
+-- name: SumGoalLines :one
+SELECT
+  COALESCE(SUM(amount) FILTER (WHERE checked), 0)::numeric AS saved,
+  COALESCE(SUM(amount), 0)::numeric AS projected
+FROM lines
+WHERE account_id = sqlc.arg(account_id) AND category_id = sqlc.arg(category_id);
+
+-- name: ReassignGoalsCategory :execrows
+UPDATE goals
+SET category_id = sqlc.arg(target_id)
+WHERE category_id = sqlc.arg(source_id);
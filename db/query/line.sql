@@ -9,9 +9,11 @@ INSERT INTO lines (
   amount,
   checked,
   description,
-  due_date
+  due_date,
+  payee,
+  tags
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE(sqlc.narg(payee)::varchar, ''), COALESCE(sqlc.narg(tags)::varchar[], '{}')
 ) RETURNING *;
 
 -- name: GetLine :one
@@ -19,7 +21,7 @@ SELECT * FROM lines
 WHERE id = $1 LIMIT 1;
 
 -- name: GetExpliciteLine :one
-SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date FROM lines
+SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date, categories.kind as category_kind, categories.color as category_color, categories.icon as category_icon, lines.category_id FROM lines
 JOIN accounts ON accounts.id = lines.account_id
 JOIN months ON months.id = lines.month_id
 JOIN categories ON categories.id = lines.category_id
@@ -31,20 +33,101 @@ WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
 
 -- name: ListLines :many
 SELECT * FROM lines
-WHERE owner = $1
-ORDER BY due_date DESC
-LIMIT $2
-OFFSET $3;
+WHERE owner = sqlc.arg(owner)
+  AND (sqlc.narg(account_id)::bigint IS NULL OR account_id = sqlc.narg(account_id))
+  AND (sqlc.narg(month_id)::bigint IS NULL OR month_id = sqlc.narg(month_id))
+  AND (sqlc.narg(year_id)::bigint IS NULL OR year_id = sqlc.narg(year_id))
+  AND (sqlc.narg(category_id)::bigint IS NULL OR category_id = sqlc.narg(category_id))
+ORDER BY due_date DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListChargeLines :many
+SELECT lines.id, lines.title, lines.payee, lines.category_id, lines.amount, lines.due_date FROM lines
+JOIN categories ON categories.id = lines.category_id
+WHERE lines.owner = $1
+  AND lines.amount < 0
+  AND categories.kind <> 'TRANSFER'
+ORDER BY lines.due_date, lines.id;
 
 -- name: ListExplicitLines :many
-SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date FROM lines
+SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date, categories.kind as category_kind, categories.color as category_color, categories.icon as category_icon, lines.category_id FROM lines
 JOIN accounts ON accounts.id = lines.account_id
 JOIN months ON months.id = lines.month_id
 JOIN categories ON categories.id = lines.category_id
-WHERE lines.owner = $1
-ORDER BY lines.due_date DESC
-LIMIT $2
-OFFSET $3;
+WHERE lines.owner = sqlc.arg(owner)
+  AND (sqlc.narg(account_id)::bigint IS NULL OR lines.account_id = sqlc.narg(account_id))
+  AND (sqlc.narg(month_id)::bigint IS NULL OR lines.month_id = sqlc.narg(month_id))
+  AND (sqlc.narg(year_id)::bigint IS NULL OR lines.year_id = sqlc.narg(year_id))
+  AND (sqlc.narg(category_id)::bigint IS NULL OR lines.category_id = sqlc.narg(category_id))
+ORDER BY lines.due_date DESC, lines.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListAccountHistory :many
+SELECT history.id, history.title, history.account, history.month, history.category, history.amount, history.checked, history.description, history.due_date, history.category_kind, history.category_color, history.category_icon, history.category_id, history.cleared_balance, history.projected_balance FROM (
+  SELECT lines.id, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date, categories.kind as category_kind, categories.color as category_color, categories.icon as category_icon, lines.category_id,
+    (accounts.init_balance + COALESCE(SUM(lines.amount) FILTER (WHERE lines.checked) OVER running, 0))::numeric AS cleared_balance,
+    (accounts.init_balance + SUM(lines.amount) OVER running)::numeric AS projected_balance
+  FROM lines
+  JOIN accounts ON accounts.id = lines.account_id
+  JOIN months ON months.id = lines.month_id
+  JOIN categories ON categories.id = lines.category_id
+  WHERE lines.account_id = sqlc.arg(account_id)
+  WINDOW running AS (ORDER BY lines.due_date, lines.id)
+) AS history
+ORDER BY history.due_date DESC, history.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListAccountLinesBetween :many
+SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date, categories.kind as category_kind, categories.color as category_color, categories.icon as category_icon, lines.category_id FROM lines
+JOIN accounts ON accounts.id = lines.account_id
+JOIN months ON months.id = lines.month_id
+JOIN categories ON categories.id = lines.category_id
+WHERE lines.account_id = sqlc.arg(account_id)
+  AND lines.due_date >= sqlc.arg(from_date)
+  AND lines.due_date <= sqlc.arg(to_date)
+ORDER BY lines.due_date, lines.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListUpcomingLines :many
+SELECT lines.id, lines.title, accounts.title as account, categories.title as category, lines.amount, lines.description, lines.due_date FROM lines
+JOIN accounts ON accounts.id = lines.account_id
+JOIN categories ON categories.id = lines.category_id
+WHERE lines.owner = sqlc.arg(owner)
+  AND lines.checked = false
+  AND lines.due_date >= sqlc.arg(from_date)
+  AND lines.due_date < sqlc.arg(to_date)
+ORDER BY lines.due_date, lines.id;
+
+-- name: ListLinesBetween :many
+SELECT * FROM lines
+WHERE owner = sqlc.arg(owner)
+  AND due_date >= sqlc.arg(from_date)
+  AND due_date <= sqlc.arg(to_date)
+ORDER BY due_date, id;
+
+-- name: ListUncheckedLinesBefore :many
+SELECT * FROM lines
+WHERE owner = sqlc.arg(owner)
+  AND checked = false
+  AND due_date < sqlc.arg(to_date)
+ORDER BY due_date, id;
+
+-- name: GetAccountFirstLineDate :one
+SELECT due_date FROM lines
+WHERE account_id = $1
+ORDER BY due_date
+LIMIT 1;
+
+-- name: SumAccountLinesBefore :one
+SELECT
+  COALESCE(SUM(amount) FILTER (WHERE checked), 0)::numeric AS balance,
+  COALESCE(SUM(amount), 0)::numeric AS final_balance
+FROM lines
+WHERE account_id = sqlc.arg(account_id) AND due_date < sqlc.arg(before);
 
 -- name: DeleteLine :exec
 DELETE FROM lines WHERE id = $1;
@@ -54,3 +137,14 @@ UPDATE lines
 SET title = $2, account_id = $3, month_id = $4, category_id = $5, year_id = $6, amount = $7, checked = $8, description = $9, due_date = $10
 WHERE id = $1
 RETURNING *;
+
+-- name: UpdateLineRuleFields :one
+UPDATE lines
+SET category_id = $2, payee = $3, tags = $4, checked = $5, description = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: ReassignLinesCategory :execrows
+UPDATE lines
+SET category_id = sqlc.arg(target_id)
+WHERE category_id = sqlc.arg(source_id);
@@ -0,0 +1,44 @@
+-- name: CreateLineAnomaly :one
+INSERT INTO line_anomalies (
+  owner,
+  line_id,
+  kind,
+  reason
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (line_id, kind) DO UPDATE
+SET reason = EXCLUDED.reason
+RETURNING *;
+
+-- name: GetLineAnomaly :one
+SELECT * FROM line_anomalies
+WHERE id = $1 LIMIT 1;
+
+-- name: ListLineAnomalies :many
+SELECT line_anomalies.id, line_anomalies.line_id, line_anomalies.kind, line_anomalies.reason, line_anomalies.dismissed, line_anomalies.create_at, lines.title, lines.payee, lines.amount, lines.due_date, lines.account_id, lines.category_id FROM line_anomalies
+JOIN lines ON lines.id = line_anomalies.line_id
+WHERE line_anomalies.owner = sqlc.arg(owner)
+  AND (sqlc.arg(include_dismissed)::bool OR NOT line_anomalies.dismissed)
+ORDER BY lines.due_date DESC, line_anomalies.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListDismissedLineAnomalies :many
+SELECT * FROM line_anomalies
+WHERE owner = sqlc.arg(owner) AND dismissed
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: DismissLineAnomaly :one
+UPDATE line_anomalies
+SET dismissed = true
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteStaleLineAnomalies :execrows
+DELETE FROM line_anomalies
+WHERE owner = sqlc.arg(owner)
+  AND NOT dismissed
+  AND NOT (id = ANY(sqlc.arg(keep_ids)::bigint[]));
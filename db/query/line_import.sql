@@ -0,0 +1,16 @@
+-- name: CreateLineImport :one
+INSERT INTO line_imports (
+  owner,
+  account_id,
+  line_id,
+  due_date,
+  amount,
+  reference
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetLineImport :one
+SELECT * FROM line_imports
+WHERE account_id = $1 AND due_date = $2 AND amount = $3 AND reference = $4
+LIMIT 1;
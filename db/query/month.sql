@@ -39,3 +39,9 @@ UPDATE months
 SET title = $2, description = $3, year_id = $4, start_date = $5, end_date = $6
 WHERE id = $1
 RETURNING *;
+
+-- name: GetMonthByDate :one
+SELECT * FROM months
+WHERE owner = $1 AND start_date <= sqlc.arg(date) AND end_date >= sqlc.arg(date)
+ORDER BY start_date DESC
+LIMIT 1;
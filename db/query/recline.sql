@@ -16,6 +16,11 @@ INSERT INTO reclines (
 SELECT * FROM reclines
 WHERE id = $1 LIMIT 1;
 
+-- name: GetRecLineByTitle :one
+SELECT * FROM reclines
+WHERE owner = $1 AND account_id = $2 AND title = $3
+LIMIT 1;
+
 -- name: GetRecLineForUpdate :one
 SELECT * FROM reclines
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
@@ -27,6 +32,13 @@ ORDER BY id
 LIMIT $2
 OFFSET $3;
 
+-- name: ListExplicitRecLines :many
+SELECT reclines.id, reclines.title, accounts.title as account, categories.title as category, reclines.amount, reclines.description, reclines.recurrency, reclines.due_date FROM reclines
+JOIN accounts ON accounts.id = reclines.account_id
+JOIN categories ON categories.id = reclines.category_id
+WHERE reclines.owner = $1
+ORDER BY reclines.id;
+
 -- name: DeleteRecLine :exec
 DELETE FROM reclines WHERE id = $1;
 
@@ -35,3 +47,8 @@ UPDATE reclines
 SET title = $2, account_id = $3, category_id = $4, amount = $5, description = $6, recurrency = $7, due_date = $8
 WHERE id = $1
 RETURNING *;
+
+-- name: ReassignRecLinesCategory :execrows
+UPDATE reclines
+SET category_id = sqlc.arg(target_id)
+WHERE category_id = sqlc.arg(source_id);
@@ -0,0 +1,60 @@
+-- name: ReportLinesByCategory :many
+SELECT
+  report.period::date AS period,
+  report.category_id,
+  report.category,
+  report.category_kind,
+  report.count,
+  report.income::numeric AS income,
+  report.expense::numeric AS expense,
+  COALESCE(ROUND(100 * report.income / NULLIF(SUM(report.income) OVER (PARTITION BY report.period), 0), 2), 0)::numeric AS income_share,
+  COALESCE(ROUND(100 * report.expense / NULLIF(SUM(report.expense) OVER (PARTITION BY report.period), 0), 2), 0)::numeric AS expense_share
+FROM (
+  SELECT
+    CASE WHEN sqlc.arg(group_by)::text = '' THEN sqlc.arg(from_date)::date
+      ELSE date_trunc(sqlc.arg(group_by)::text, lines.due_date)::date END AS period,
+    lines.category_id,
+    categories.title AS category,
+    categories.kind AS category_kind,
+    COUNT(*) AS count,
+    COALESCE(SUM(lines.amount) FILTER (WHERE lines.amount > 0), 0) AS income,
+    COALESCE(-SUM(lines.amount) FILTER (WHERE lines.amount < 0), 0) AS expense
+  FROM lines
+  JOIN categories ON categories.id = lines.category_id
+  WHERE lines.owner = sqlc.arg(owner)
+    AND lines.due_date >= sqlc.arg(from_date)
+    AND lines.due_date <= sqlc.arg(to_date)
+    AND (sqlc.narg(account_id)::bigint IS NULL OR lines.account_id = sqlc.narg(account_id))
+    AND categories.kind <> 'TRANSFER'
+  GROUP BY 1, lines.category_id, categories.title, categories.kind
+) AS report
+ORDER BY report.period, report.expense DESC, report.income DESC, report.category_id;
+
+-- name: SumLinesByAccountAndPeriod :many
+SELECT
+  lines.account_id,
+  GREATEST(date_trunc(sqlc.arg(group_by)::text, lines.due_date), sqlc.arg(from_date)::date)::date AS period,
+  SUM(lines.amount)::numeric AS amount
+FROM lines
+WHERE lines.owner = sqlc.arg(owner)
+  AND lines.due_date <= sqlc.arg(to_date)
+GROUP BY 1, 2
+ORDER BY 2, 1;
+
+-- name: SumLinesByTitle :many
+SELECT
+  lines.category_id,
+  categories.title AS category,
+  categories.kind AS category_kind,
+  lines.title,
+  COUNT(*) AS count,
+  COALESCE(SUM(lines.amount) FILTER (WHERE lines.amount > 0), 0)::numeric AS income,
+  COALESCE(-SUM(lines.amount) FILTER (WHERE lines.amount < 0), 0)::numeric AS expense
+FROM lines
+JOIN categories ON categories.id = lines.category_id
+WHERE lines.owner = sqlc.arg(owner)
+  AND (sqlc.narg(month_id)::bigint IS NULL OR lines.month_id = sqlc.narg(month_id))
+  AND (sqlc.narg(year_id)::bigint IS NULL OR lines.year_id = sqlc.narg(year_id))
+  AND categories.kind <> 'TRANSFER'
+GROUP BY lines.category_id, categories.title, categories.kind, lines.title
+ORDER BY lines.category_id, lines.title;
@@ -0,0 +1,44 @@
+-- name: CreateRule :one
+INSERT INTO rules (
+  owner,
+  title,
+  priority,
+  match_title,
+  match_min_amount,
+  match_max_amount,
+  match_account_id,
+  match_day,
+  set_category_id,
+  set_payee,
+  set_tags,
+  set_checked,
+  set_description
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING *;
+
+-- name: GetRule :one
+SELECT * FROM rules
+WHERE id = $1 LIMIT 1;
+
+-- name: ListRules :many
+SELECT * FROM rules
+WHERE owner = $1
+ORDER BY priority DESC, id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateRule :one
+UPDATE rules
+SET title = $2, priority = $3, match_title = $4, match_min_amount = $5, match_max_amount = $6, match_account_id = $7, match_day = $8,
+  set_category_id = $9, set_payee = $10, set_tags = $11, set_checked = $12, set_description = $13
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRule :exec
+DELETE FROM rules WHERE id = $1;
+
+-- name: ReassignRulesCategory :execrows
+UPDATE rules
+SET set_category_id = sqlc.arg(target_id)
+WHERE set_category_id = sqlc.arg(source_id);
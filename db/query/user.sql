@@ -5,14 +5,36 @@ INSERT INTO users (
   full_name,
   email,
   currency,
-  password_changed_at
+  password_changed_at,
+  locale
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- name: GetUser :one
 SELECT * FROM users
 WHERE username = $1 LIMIT 1;
 
+-- name: ListUsernames :many
+SELECT username FROM users
+ORDER BY username
+LIMIT $1
+OFFSET $2;
+
 -- name: DeleteUser :exec
 DELETE FROM users WHERE username = $1;
+
+-- name: UpdateUserProfile :one
+UPDATE users
+SET full_name = sqlc.arg(full_name),
+    currency = sqlc.arg(currency),
+    locale = COALESCE(sqlc.narg(locale), locale)
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: UpdateUserPreferences :one
+UPDATE users
+SET category_kind_check = COALESCE(sqlc.narg(category_kind_check), category_kind_check),
+    budget_alert_email = COALESCE(sqlc.narg(budget_alert_email), budget_alert_email)
+WHERE username = sqlc.arg(username)
+RETURNING *;
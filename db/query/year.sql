@@ -13,6 +13,12 @@ INSERT INTO years (
 SELECT * FROM years
 WHERE id = $1 LIMIT 1;
 
+-- name: GetYearByDate :one
+SELECT * FROM years
+WHERE owner = $1 AND start_date <= sqlc.arg(date) AND end_date >= sqlc.arg(date)
+ORDER BY start_date DESC
+LIMIT 1;
+
 -- name: GetYearForUpdate :one
 SELECT * FROM years
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
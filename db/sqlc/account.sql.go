@@ -106,6 +106,32 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 	return i, err
 }
 
+const getAccountByTitle = `-- name: GetAccountByTitle :one
+SELECT id, owner, title, description, init_balance, balance, final_balance FROM accounts
+WHERE owner = $1 AND title = $2
+LIMIT 1
+`
+
+type GetAccountByTitleParams struct {
+	Owner string `json:"owner"`
+	Title string `json:"title"`
+}
+
+func (q *Queries) GetAccountByTitle(ctx context.Context, arg GetAccountByTitleParams) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByTitle, arg.Owner, arg.Title)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Title,
+		&i.Description,
+		&i.InitBalance,
+		&i.Balance,
+		&i.FinalBalance,
+	)
+	return i, err
+}
+
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
 SELECT id, owner, title, description, init_balance, balance, final_balance FROM accounts
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
@@ -126,6 +152,38 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 	return i, err
 }
 
+const listAccountIDs = `-- name: ListAccountIDs :many
+SELECT id FROM accounts
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListAccountIDsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListAccountIDs(ctx context.Context, arg ListAccountIDsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listAccountIDs, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAccounts = `-- name: ListAccounts :many
 SELECT id, owner, title, description, init_balance, balance, final_balance FROM accounts
 WHERE owner = $1
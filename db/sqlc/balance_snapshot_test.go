@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBalanceSnapshots(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	createReportLine(t, user, month, year, account, category, -40, from.AddDate(0, 0, 1))
+	createReportLine(t, user, month, year, account, category, 100, from.AddDate(0, 0, 3))
+
+	created, err := testStore.CreateBalanceSnapshots(context.Background(), CreateBalanceSnapshotsParams{
+		AccountID:    account.ID,
+		Balance:      decimal.Zero,
+		FinalBalance: decimal.NewFromInt(10),
+		FromDate:     from,
+		ToDate:       from.AddDate(0, 0, 4),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), created)
+
+	snapshots, err := testStore.ListBalanceSnapshots(context.Background(), ListBalanceSnapshotsParams{
+		AccountID: account.ID,
+		FromDate:  from,
+		ToDate:    from.AddDate(0, 0, 4),
+	})
+	require.NoError(t, err)
+	require.Len(t, snapshots, 5)
+
+	require.True(t, from.Equal(snapshots[0].Date))
+	require.True(t, decimal.NewFromInt(10).Equal(snapshots[0].FinalBalance))
+	require.True(t, decimal.NewFromInt(-30).Equal(snapshots[1].FinalBalance))
+	require.True(t, decimal.NewFromInt(-30).Equal(snapshots[2].FinalBalance))
+	require.True(t, decimal.NewFromInt(70).Equal(snapshots[4].FinalBalance))
+	// The lines aren't checked
+	require.True(t, snapshots[4].Balance.IsZero())
+
+	first, err := testStore.GetFirstBalanceSnapshot(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, snapshots[0], first)
+
+	last, err := testStore.GetLastBalanceSnapshot(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, snapshots[4], last)
+}
+
+func TestDeleteBalanceSnapshotsFrom(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := testStore.CreateBalanceSnapshots(context.Background(), CreateBalanceSnapshotsParams{
+		AccountID:    account.ID,
+		Balance:      decimal.Zero,
+		FinalBalance: decimal.Zero,
+		FromDate:     from,
+		ToDate:       from.AddDate(0, 0, 9),
+	})
+	require.NoError(t, err)
+
+	deleted, err := testStore.DeleteBalanceSnapshotsFrom(context.Background(), DeleteBalanceSnapshotsFromParams{
+		AccountID: account.ID,
+		FromDate:  from.AddDate(0, 0, 4),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), deleted)
+
+	last, err := testStore.GetLastBalanceSnapshot(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, from.AddDate(0, 0, 3).Equal(last.Date))
+}
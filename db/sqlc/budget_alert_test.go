@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomBudgetAlert(t *testing.T, user User, budget Budget, threshold int32) BudgetAlert {
+	arg := CreateBudgetAlertParams{
+		Owner:      user.Username,
+		BudgetID:   budget.ID,
+		CategoryID: budget.CategoryID,
+		MonthID:    budget.MonthID,
+		Threshold:  threshold,
+		Amount:     budget.Amount,
+		Spent:      budget.Amount,
+	}
+
+	alert, err := testStore.CreateBudgetAlert(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, alert.ID)
+	require.Equal(t, arg.Owner, alert.Owner)
+	require.Equal(t, arg.BudgetID, alert.BudgetID)
+	require.Equal(t, arg.CategoryID, alert.CategoryID)
+	require.Equal(t, arg.MonthID, alert.MonthID)
+	require.Equal(t, arg.Threshold, alert.Threshold)
+	require.True(t, arg.Spent.Equal(alert.Spent))
+	require.False(t, alert.Read)
+
+	return alert
+}
+
+func TestCreateBudgetAlert(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	budget := createRandomBudget(t, user, category, month, false)
+
+	alert := createRandomBudgetAlert(t, user, budget, 80)
+
+	// A threshold is only raised once a month
+	_, err := testStore.CreateBudgetAlert(context.Background(), CreateBudgetAlertParams{
+		Owner:      user.Username,
+		BudgetID:   budget.ID,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Threshold:  80,
+		Amount:     budget.Amount,
+		Spent:      budget.Amount,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	got, err := testStore.GetBudgetAlert(context.Background(), alert.ID)
+	require.NoError(t, err)
+	require.Equal(t, alert, got)
+}
+
+func TestListBudgetAlerts(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	budget := createRandomBudget(t, user, createRandomCategory(t, user), month, false)
+
+	warning := createRandomBudgetAlert(t, user, budget, 80)
+	over := createRandomBudgetAlert(t, user, budget, 100)
+
+	read, err := testStore.MarkBudgetAlertRead(context.Background(), warning.ID)
+	require.NoError(t, err)
+	require.True(t, read.Read)
+
+	alerts, err := testStore.ListBudgetAlerts(context.Background(), ListBudgetAlertsParams{
+		Owner: user.Username,
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	require.Equal(t, over.ID, alerts[0].ID)
+
+	alerts, err = testStore.ListBudgetAlerts(context.Background(), ListBudgetAlertsParams{
+		Owner:      user.Username,
+		UnreadOnly: true,
+		Limit:      5,
+	})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, over.ID, alerts[0].ID)
+}
+
+func TestReachedThresholds(t *testing.T) {
+	food := Category{ID: 1, Title: "Food", Kind: util.EXPENSE}
+	salary := Category{ID: 2, Title: "Salary", Kind: util.INCOME}
+	budget := Budget{ID: 3, Amount: decimal.NewFromInt(500)}
+
+	progress := func(category Category, projected int64) BudgetProgress {
+		return BudgetProgress{Budget: budget, Category: category, Projected: decimal.NewFromInt(projected)}
+	}
+
+	require.Empty(t, ReachedThresholds(progress(food, 399)))
+	require.Equal(t, []int32{80}, ReachedThresholds(progress(food, 400)))
+	require.Equal(t, []int32{80}, ReachedThresholds(progress(food, 499)))
+	require.Equal(t, []int32{80, 100}, ReachedThresholds(progress(food, 500)))
+	require.Empty(t, ReachedThresholds(progress(salary, 900)))
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// BudgetAlertThresholds are the percentages of a budget which raise an alert
+var BudgetAlertThresholds = []int32{80, 100}
+
+// ReachedThresholds returns the alert thresholds the projected spending of a budget reached.
+// Income budgets never alert, coming in above them is good news.
+func ReachedThresholds(progress BudgetProgress) []int32 {
+	reached := []int32{}
+	if progress.Category.Kind == util.INCOME || !progress.Budget.Amount.IsPositive() {
+		return reached
+	}
+
+	spent := progress.Projected.Mul(decimal.NewFromInt(100))
+	for _, threshold := range BudgetAlertThresholds {
+		if spent.GreaterThanOrEqual(progress.Budget.Amount.Mul(decimal.NewFromInt32(threshold))) {
+			reached = append(reached, threshold)
+		}
+	}
+
+	return reached
+}
+
+// evaluateBudgetAlerts records the thresholds reached by the budgets of a month and returns the new alerts.
+// An alert is raised once per category, month and threshold.
+func (store *SQLStore) evaluateBudgetAlerts(ctx context.Context, owner string, monthID int64) ([]BudgetAlert, error) {
+	report, err := store.MonthBudgetTx(ctx, MonthBudgetTxParams{
+		Owner:   owner,
+		MonthID: monthID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []BudgetAlert
+	for _, progress := range report.Budgets {
+		for _, threshold := range ReachedThresholds(progress) {
+			alert, err := store.CreateBudgetAlert(ctx, CreateBudgetAlertParams{
+				Owner:      owner,
+				BudgetID:   progress.Budget.ID,
+				CategoryID: progress.Category.ID,
+				MonthID:    monthID,
+				Threshold:  threshold,
+				Amount:     progress.Budget.Amount,
+				Spent:      progress.Projected,
+			})
+			if err != nil {
+				// Already raised this month
+				if errors.Is(err, pgx.ErrNoRows) {
+					continue
+				}
+				return alerts, err
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,220 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomBudget(t *testing.T, user User, category Category, month Month, recurring bool) Budget {
+	arg := CreateBudgetParams{
+		Owner:      user.Username,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Recurring:  recurring,
+		Amount:     decimal.NewFromInt(util.RandomInt(10, 1000)),
+	}
+
+	budget, err := testStore.CreateBudget(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, budget)
+
+	require.NotZero(t, budget.ID)
+	require.Equal(t, arg.Owner, budget.Owner)
+	require.Equal(t, arg.CategoryID, budget.CategoryID)
+	require.Equal(t, arg.MonthID, budget.MonthID)
+	require.Equal(t, arg.Recurring, budget.Recurring)
+	require.True(t, arg.Amount.Equal(budget.Amount))
+	require.NotZero(t, budget.CreateAt)
+
+	return budget
+}
+
+// createMonthStarting creates a month of a user starting on a given date
+func createMonthStarting(t *testing.T, user User, year Year, start time.Time) Month {
+	month, err := testStore.CreateMonth(context.Background(), CreateMonthParams{
+		Title:       util.RandomTitle(),
+		Owner:       user.Username,
+		Description: util.RandomString(14),
+		YearID:      year.ID,
+		StartDate:   start,
+		EndDate:     start.AddDate(0, 1, -1),
+	})
+	require.NoError(t, err)
+
+	return month
+}
+
+func TestCreateBudget(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	createRandomBudget(t, user, category, month, false)
+
+	// A category has a single budget per month and recurrence
+	_, err := testStore.CreateBudget(context.Background(), CreateBudgetParams{
+		Owner:      user.Username,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Amount:     decimal.NewFromInt(10),
+	})
+	require.Error(t, err)
+}
+
+func TestGetBudget(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	budget1 := createRandomBudget(t, user, category, month, true)
+
+	budget2, err := testStore.GetBudget(context.Background(), budget1.ID)
+	require.NoError(t, err)
+	require.Equal(t, budget1, budget2)
+}
+
+func TestUpdateBudget(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	budget := createRandomBudget(t, user, category, month, false)
+
+	arg := UpdateBudgetParams{
+		ID:        budget.ID,
+		MonthID:   month.ID,
+		Recurring: true,
+		Amount:    budget.Amount.Add(decimal.NewFromInt(5)),
+	}
+	updated, err := testStore.UpdateBudget(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, budget.ID, updated.ID)
+	require.Equal(t, budget.CategoryID, updated.CategoryID)
+	require.True(t, updated.Recurring)
+	require.True(t, arg.Amount.Equal(updated.Amount))
+}
+
+func TestDeleteBudget(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	budget := createRandomBudget(t, user, category, month, false)
+
+	err := testStore.DeleteBudget(context.Background(), budget.ID)
+	require.NoError(t, err)
+
+	_, err = testStore.GetBudget(context.Background(), budget.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestListBudgets(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+
+	for i := 0; i < 5; i++ {
+		createRandomBudget(t, user, createRandomCategory(t, user), month, false)
+	}
+
+	budgets, err := testStore.ListBudgets(context.Background(), ListBudgetsParams{
+		Owner:  user.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, budgets, 5)
+	for _, budget := range budgets {
+		require.Equal(t, user.Username, budget.Owner)
+	}
+}
+
+func TestListMonthBudgets(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	january := createMonthStarting(t, user, year, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	march := createMonthStarting(t, user, year, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+	april := createMonthStarting(t, user, year, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC))
+	food := createRandomCategory(t, user)
+	rent := createRandomCategory(t, user)
+	leisure := createRandomCategory(t, user)
+
+	createRandomBudget(t, user, food, january, true)
+	foodMarch := createRandomBudget(t, user, food, march, true)
+	rentJanuary := createRandomBudget(t, user, rent, january, true)
+	rentMarch := createRandomBudget(t, user, rent, march, false)
+	createRandomBudget(t, user, leisure, april, true)
+
+	// The latest recurring budget applies, a one-off budget of the month wins over it
+	budgets, err := testStore.ListMonthBudgets(context.Background(), ListMonthBudgetsParams{
+		Owner:   user.Username,
+		MonthID: march.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, budgets, 2)
+	require.Equal(t, foodMarch.ID, budgets[0].ID)
+	require.Equal(t, rentMarch.ID, budgets[1].ID)
+
+	// One-off budgets don't carry over, recurring budgets don't apply before their first month
+	budgets, err = testStore.ListMonthBudgets(context.Background(), ListMonthBudgetsParams{
+		Owner:   user.Username,
+		MonthID: april.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, budgets, 3)
+	require.Equal(t, foodMarch.ID, budgets[0].ID)
+	require.Equal(t, rentJanuary.ID, budgets[1].ID)
+	require.Equal(t, leisure.ID, budgets[2].CategoryID)
+}
+
+func TestBuildMonthBudget(t *testing.T) {
+	housing := Category{ID: 1, Title: "Housing", Kind: util.EXPENSE}
+	rent := Category{ID: 2, Title: "Rent", Kind: util.EXPENSE, ParentID: &housing.ID}
+	energy := Category{ID: 3, Title: "Energy", Kind: util.EXPENSE, ParentID: &housing.ID}
+	salary := Category{ID: 4, Title: "Salary", Kind: util.INCOME}
+	categories := []Category{housing, rent, energy, salary}
+
+	budgets := []Budget{
+		{ID: 10, CategoryID: housing.ID, Amount: decimal.NewFromInt(1000)},
+		{ID: 11, CategoryID: energy.ID, Amount: decimal.NewFromInt(100)},
+		{ID: 12, CategoryID: salary.ID, Amount: decimal.NewFromInt(2500)},
+	}
+	sums := []SumLinesByCategoryRow{
+		{CategoryID: rent.ID, Amount: decimal.NewFromInt(-800), CheckedAmount: decimal.NewFromInt(-800)},
+		{CategoryID: energy.ID, Amount: decimal.NewFromInt(-120), CheckedAmount: decimal.NewFromInt(-60)},
+		{CategoryID: salary.ID, Amount: decimal.NewFromInt(2500), CheckedAmount: decimal.Zero},
+	}
+
+	report := BuildMonthBudget(7, categories, budgets, sums)
+	require.Equal(t, int64(7), report.MonthID)
+	require.Len(t, report.Budgets, 3)
+
+	housingProgress := report.Budgets[0]
+	require.Equal(t, housing, housingProgress.Category)
+	require.ElementsMatch(t, []int64{housing.ID, rent.ID, energy.ID}, housingProgress.CategoryIDs)
+	require.True(t, decimal.NewFromInt(860).Equal(housingProgress.Spent))
+	require.True(t, decimal.NewFromInt(920).Equal(housingProgress.Projected))
+	require.True(t, decimal.NewFromInt(140).Equal(housingProgress.Remaining))
+	require.True(t, decimal.NewFromInt(80).Equal(housingProgress.ProjectedRemaining))
+
+	energyProgress := report.Budgets[1]
+	require.Equal(t, []int64{energy.ID}, energyProgress.CategoryIDs)
+	require.True(t, decimal.NewFromInt(-20).Equal(energyProgress.ProjectedRemaining))
+
+	// Income counts what came in
+	salaryProgress := report.Budgets[2]
+	require.True(t, salaryProgress.Spent.IsZero())
+	require.True(t, decimal.NewFromInt(2500).Equal(salaryProgress.Projected))
+
+	// Totals only count the top spending budgets
+	require.True(t, decimal.NewFromInt(1000).Equal(report.Budgeted))
+	require.True(t, decimal.NewFromInt(860).Equal(report.Spent))
+	require.True(t, decimal.NewFromInt(920).Equal(report.Projected))
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertCalendarToken(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := UpsertCalendarTokenParams{
+		Owner:     user.Username,
+		TokenHash: util.RandomString(64),
+	}
+	token1, err := testStore.UpsertCalendarToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Owner, token1.Owner)
+	require.Equal(t, arg.TokenHash, token1.TokenHash)
+	require.NotZero(t, token1.CreateAt)
+
+	// Rotating replaces the previous token
+	arg.TokenHash = util.RandomString(64)
+	token2, err := testStore.UpsertCalendarToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.TokenHash, token2.TokenHash)
+
+	_, err = testStore.GetCalendarTokenByHash(context.Background(), token1.TokenHash)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	token3, err := testStore.GetCalendarTokenByHash(context.Background(), token2.TokenHash)
+	require.NoError(t, err)
+	require.Equal(t, user.Username, token3.Owner)
+}
+
+func TestDeleteCalendarToken(t *testing.T) {
+	user := createRandomUser(t)
+
+	token, err := testStore.UpsertCalendarToken(context.Background(), UpsertCalendarTokenParams{
+		Owner:     user.Username,
+		TokenHash: util.RandomString(64),
+	})
+	require.NoError(t, err)
+
+	err = testStore.DeleteCalendarToken(context.Background(), user.Username)
+	require.NoError(t, err)
+
+	_, err = testStore.GetCalendarTokenByHash(context.Background(), token.TokenHash)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
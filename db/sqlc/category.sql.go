@@ -7,26 +7,51 @@ package db
 
 import (
 	"context"
+
+	decimal "github.com/shopspring/decimal"
 )
 
 const createCategory = `-- name: CreateCategory :one
 INSERT INTO categories (
   title,
-  owner
+  owner,
+  parent_id,
+  kind,
+  color,
+  icon
 ) VALUES (
-    $1, $2
-) RETURNING id, title, owner
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, title, owner, parent_id, kind, color, icon
 `
 
 type CreateCategoryParams struct {
-	Title string `json:"title"`
-	Owner string `json:"owner"`
+	Title    string `json:"title"`
+	Owner    string `json:"owner"`
+	ParentID *int64 `json:"parent_id"`
+	Kind     string `json:"kind"`
+	Color    string `json:"color"`
+	Icon     string `json:"icon"`
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
-	row := q.db.QueryRow(ctx, createCategory, arg.Title, arg.Owner)
+	row := q.db.QueryRow(ctx, createCategory,
+		arg.Title,
+		arg.Owner,
+		arg.ParentID,
+		arg.Kind,
+		arg.Color,
+		arg.Icon,
+	)
 	var i Category
-	err := row.Scan(&i.ID, &i.Title, &i.Owner)
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Owner,
+		&i.ParentID,
+		&i.Kind,
+		&i.Color,
+		&i.Icon,
+	)
 	return i, err
 }
 
@@ -40,31 +65,108 @@ func (q *Queries) DeleteCategory(ctx context.Context, id int64) error {
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, title, owner FROM categories
+SELECT id, title, owner, parent_id, kind, color, icon FROM categories
 WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetCategory(ctx context.Context, id int64) (Category, error) {
 	row := q.db.QueryRow(ctx, getCategory, id)
 	var i Category
-	err := row.Scan(&i.ID, &i.Title, &i.Owner)
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Owner,
+		&i.ParentID,
+		&i.Kind,
+		&i.Color,
+		&i.Icon,
+	)
+	return i, err
+}
+
+const getCategoryUsage = `-- name: GetCategoryUsage :one
+SELECT
+  (SELECT COUNT(*) FROM lines WHERE lines.category_id = $1) AS lines,
+  (SELECT COUNT(*) FROM reclines WHERE reclines.category_id = $1) AS reclines,
+  (SELECT COUNT(*) FROM rules WHERE rules.set_category_id = $1) AS rules,
+  (SELECT COUNT(*) FROM budgets WHERE budgets.category_id = $1) AS budgets,
+  (SELECT COUNT(*) FROM envelope_assignments WHERE envelope_assignments.category_id = $1) AS envelopes,
+  (SELECT COUNT(*) FROM goals WHERE goals.category_id = $1) AS goals
+`
+
+type GetCategoryUsageRow struct {
+	Lines     int64 `json:"lines"`
+	Reclines  int64 `json:"reclines"`
+	Rules     int64 `json:"rules"`
+	Budgets   int64 `json:"budgets"`
+	Envelopes int64 `json:"envelopes"`
+	Goals     int64 `json:"goals"`
+}
+
+func (q *Queries) GetCategoryUsage(ctx context.Context, id int64) (GetCategoryUsageRow, error) {
+	row := q.db.QueryRow(ctx, getCategoryUsage, id)
+	var i GetCategoryUsageRow
+	err := row.Scan(
+		&i.Lines,
+		&i.Reclines,
+		&i.Rules,
+		&i.Budgets,
+		&i.Envelopes,
+		&i.Goals,
+	)
+	return i, err
+}
+
+const getCategoryByTitle = `-- name: GetCategoryByTitle :one
+SELECT id, title, owner, parent_id, kind, color, icon FROM categories
+WHERE owner = $1 AND title = $2
+  AND parent_id IS NOT DISTINCT FROM $3
+LIMIT 1
+`
+
+type GetCategoryByTitleParams struct {
+	Owner    string `json:"owner"`
+	Title    string `json:"title"`
+	ParentID *int64 `json:"parent_id"`
+}
+
+func (q *Queries) GetCategoryByTitle(ctx context.Context, arg GetCategoryByTitleParams) (Category, error) {
+	row := q.db.QueryRow(ctx, getCategoryByTitle, arg.Owner, arg.Title, arg.ParentID)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Owner,
+		&i.ParentID,
+		&i.Kind,
+		&i.Color,
+		&i.Icon,
+	)
 	return i, err
 }
 
 const getCategoryForUpdate = `-- name: GetCategoryForUpdate :one
-SELECT id, title, owner FROM categories
+SELECT id, title, owner, parent_id, kind, color, icon FROM categories
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
 `
 
 func (q *Queries) GetCategoryForUpdate(ctx context.Context, id int64) (Category, error) {
 	row := q.db.QueryRow(ctx, getCategoryForUpdate, id)
 	var i Category
-	err := row.Scan(&i.ID, &i.Title, &i.Owner)
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Owner,
+		&i.ParentID,
+		&i.Kind,
+		&i.Color,
+		&i.Icon,
+	)
 	return i, err
 }
 
 const listCategories = `-- name: ListCategories :many
-SELECT id, title, owner FROM categories
+SELECT id, title, owner, parent_id, kind, color, icon FROM categories
 WHERE owner = $1
 ORDER BY id
 LIMIT $2
@@ -86,7 +188,151 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 	items := []Category{}
 	for rows.Next() {
 		var i Category
-		if err := rows.Scan(&i.ID, &i.Title, &i.Owner); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Owner,
+			&i.ParentID,
+			&i.Kind,
+			&i.Color,
+			&i.Icon,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryAncestors = `-- name: ListCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+  SELECT categories.id, categories.parent_id, 0 AS depth, ARRAY[categories.id] AS path FROM categories
+  WHERE categories.id = $1
+  UNION ALL
+  SELECT categories.id, categories.parent_id, ancestors.depth + 1, ancestors.path || categories.id FROM categories
+  JOIN ancestors ON categories.id = ancestors.parent_id
+  WHERE NOT categories.id = ANY(ancestors.path)
+)
+SELECT id FROM ancestors
+ORDER BY depth
+`
+
+func (q *Queries) ListCategoryAncestors(ctx context.Context, id int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listCategoryAncestors, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCategoryTree = `-- name: LockCategoryTree :exec
+SELECT pg_advisory_xact_lock(hashtext($1::text))
+`
+
+func (q *Queries) LockCategoryTree(ctx context.Context, owner string) error {
+	_, err := q.db.Exec(ctx, lockCategoryTree, owner)
+	return err
+}
+
+const moveCategory = `-- name: MoveCategory :one
+UPDATE categories
+SET parent_id = $1
+WHERE id = $2
+RETURNING id, title, owner, parent_id, kind, color, icon
+`
+
+type MoveCategoryParams struct {
+	ParentID *int64 `json:"parent_id"`
+	ID       int64  `json:"id"`
+}
+
+func (q *Queries) MoveCategory(ctx context.Context, arg MoveCategoryParams) (Category, error) {
+	row := q.db.QueryRow(ctx, moveCategory, arg.ParentID, arg.ID)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Owner,
+		&i.ParentID,
+		&i.Kind,
+		&i.Color,
+		&i.Icon,
+	)
+	return i, err
+}
+
+const moveCategoryChildren = `-- name: MoveCategoryChildren :execrows
+UPDATE categories
+SET parent_id = $1
+WHERE parent_id = $2
+`
+
+type MoveCategoryChildrenParams struct {
+	ParentID    *int64 `json:"parent_id"`
+	OldParentID *int64 `json:"old_parent_id"`
+}
+
+func (q *Queries) MoveCategoryChildren(ctx context.Context, arg MoveCategoryChildrenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveCategoryChildren, arg.ParentID, arg.OldParentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const sumLinesByCategory = `-- name: SumLinesByCategory :many
+SELECT category_id, COALESCE(SUM(amount),0)::numeric AS amount, COALESCE(SUM(amount) FILTER (WHERE checked),0)::numeric AS checked_amount FROM lines
+WHERE owner = $1
+  AND ($2::bigint IS NULL OR account_id = $2)
+  AND ($3::bigint IS NULL OR month_id = $3)
+  AND ($4::bigint IS NULL OR year_id = $4)
+GROUP BY category_id
+ORDER BY category_id
+`
+
+type SumLinesByCategoryParams struct {
+	Owner     string `json:"owner"`
+	AccountID *int64 `json:"account_id"`
+	MonthID   *int64 `json:"month_id"`
+	YearID    *int64 `json:"year_id"`
+}
+
+type SumLinesByCategoryRow struct {
+	CategoryID    int64           `json:"category_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	CheckedAmount decimal.Decimal `json:"checked_amount"`
+}
+
+func (q *Queries) SumLinesByCategory(ctx context.Context, arg SumLinesByCategoryParams) ([]SumLinesByCategoryRow, error) {
+	rows, err := q.db.Query(ctx, sumLinesByCategory,
+		arg.Owner,
+		arg.AccountID,
+		arg.MonthID,
+		arg.YearID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SumLinesByCategoryRow{}
+	for rows.Next() {
+		var i SumLinesByCategoryRow
+		if err := rows.Scan(&i.CategoryID, &i.Amount, &i.CheckedAmount); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -96,3 +342,42 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 	}
 	return items, nil
 }
+
+const updateCategory = `-- name: UpdateCategory :one
+UPDATE categories
+SET title = $2,
+    kind = $3,
+    color = $4,
+    icon = $5
+WHERE id = $1
+RETURNING id, title, owner, parent_id, kind, color, icon
+`
+
+type UpdateCategoryParams struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Kind  string `json:"kind"`
+	Color string `json:"color"`
+	Icon  string `json:"icon"`
+}
+
+func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
+	row := q.db.QueryRow(ctx, updateCategory,
+		arg.ID,
+		arg.Title,
+		arg.Kind,
+		arg.Color,
+		arg.Icon,
+	)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Owner,
+		&i.ParentID,
+		&i.Kind,
+		&i.Color,
+		&i.Icon,
+	)
+	return i, err
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// ErrCategoryKindMismatch is returned when a line amount doesn't match the kind of its category and its owner enforces kinds
+var ErrCategoryKindMismatch = errors.New("line amount doesn't match the kind of its category")
+
+// checkCategoryKind compares the sign of a line amount with the kind of its category.
+// A mismatch is a warning, or an error when the owner of the line enforces kinds.
+func checkCategoryKind(ctx context.Context, q *Queries, owner string, category Category, amount decimal.Decimal) ([]string, error) {
+	if util.AmountMatchesCategoryKind(category.Kind, amount) {
+		return nil, nil
+	}
+
+	user, err := q.GetUser(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.CategoryKindCheck == util.ENFORCE {
+		return nil, fmt.Errorf("%w: %s in %s category %q", ErrCategoryKindMismatch, amount, category.Kind, category.Title)
+	}
+
+	return []string{fmt.Sprintf("amount %s doesn't match the %s category %q", amount, category.Kind, category.Title)}, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,6 +14,7 @@ func createRandomCategory(t *testing.T, user User) Category {
 	arg := CreateCategoryParams{
 		Title: util.RandomTitle(),
 		Owner: user.Username,
+		Kind:  util.EXPENSE,
 	}
 
 	category, err := testStore.CreateCategory(context.Background(), arg)
@@ -22,6 +24,7 @@ func createRandomCategory(t *testing.T, user User) Category {
 	require.NotZero(t, category.ID)
 	require.Equal(t, category.Title, arg.Title)
 	require.Equal(t, category.Owner, arg.Owner)
+	require.Equal(t, category.Kind, arg.Kind)
 
 	return category
 }
@@ -82,3 +85,223 @@ func TestListCategories(t *testing.T) {
 		require.Equal(t, lastCategory.Owner, category.Owner)
 	}
 }
+
+func createRandomChildCategory(t *testing.T, user User, parent Category) Category {
+	arg := CreateCategoryParams{
+		Title:    util.RandomTitle(),
+		Owner:    user.Username,
+		ParentID: &parent.ID,
+		Kind:     parent.Kind,
+	}
+
+	category, err := testStore.CreateCategory(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotNil(t, category.ParentID)
+	require.Equal(t, parent.ID, *category.ParentID)
+
+	return category
+}
+
+func TestGetCategoryByTitle(t *testing.T) {
+	user := createRandomUser(t)
+	parent := createRandomCategory(t, user)
+	child := createRandomChildCategory(t, user, parent)
+
+	found, err := testStore.GetCategoryByTitle(context.Background(), GetCategoryByTitleParams{
+		Owner:    user.Username,
+		Title:    child.Title,
+		ParentID: &parent.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, child.ID, found.ID)
+
+	// The same title at the top of the tree is another category
+	_, err = testStore.GetCategoryByTitle(context.Background(), GetCategoryByTitleParams{
+		Owner: user.Username,
+		Title: child.Title,
+	})
+	require.EqualError(t, err, pgx.ErrNoRows.Error())
+}
+
+func TestMoveCategory(t *testing.T) {
+	user := createRandomUser(t)
+	parent := createRandomCategory(t, user)
+	category := createRandomCategory(t, user)
+	require.Nil(t, category.ParentID)
+
+	moved, err := testStore.MoveCategory(context.Background(), MoveCategoryParams{
+		ID:       category.ID,
+		ParentID: &parent.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, moved.ParentID)
+	require.Equal(t, parent.ID, *moved.ParentID)
+
+	moved, err = testStore.MoveCategory(context.Background(), MoveCategoryParams{ID: category.ID})
+	require.NoError(t, err)
+	require.Nil(t, moved.ParentID)
+}
+
+func TestUpdateCategory(t *testing.T) {
+	user := createRandomUser(t)
+	category1 := createRandomCategory(t, user)
+
+	arg := UpdateCategoryParams{
+		ID:    category1.ID,
+		Title: util.RandomTitle(),
+		Kind:  util.INCOME,
+		Color: "#2e7d32",
+		Icon:  "salary",
+	}
+
+	category2, err := testStore.UpdateCategory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, category1.ID, category2.ID)
+	require.Equal(t, arg.Title, category2.Title)
+	require.Equal(t, arg.Kind, category2.Kind)
+	require.Equal(t, arg.Color, category2.Color)
+	require.Equal(t, arg.Icon, category2.Icon)
+	require.Equal(t, category1.Owner, category2.Owner)
+}
+
+func TestGetCategoryUsage(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	usage, err := testStore.GetCategoryUsage(context.Background(), category.ID)
+	require.NoError(t, err)
+	require.Zero(t, usage.Lines+usage.Reclines+usage.Rules+usage.Budgets+usage.Envelopes+usage.Goals)
+
+	createRandomLine(t, user, month, year, account, category)
+	createRandomLine(t, user, month, year, account, category)
+	createRandomRecLine(t, user, account, category)
+	createRandomRule(t, user, category)
+	createRandomBudget(t, user, category, month, false)
+	assignRandomEnvelope(t, user, category, month)
+	createRandomGoal(t, user, account, &category.ID)
+
+	usage, err = testStore.GetCategoryUsage(context.Background(), category.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), usage.Lines)
+	require.Equal(t, int64(1), usage.Reclines)
+	require.Equal(t, int64(1), usage.Rules)
+	require.Equal(t, int64(1), usage.Budgets)
+	require.Equal(t, int64(1), usage.Envelopes)
+	require.Equal(t, int64(1), usage.Goals)
+}
+
+func TestDeleteCategoryUsedByGoal(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	category := createRandomCategory(t, user)
+	goal := createRandomGoal(t, user, account, &category.ID)
+
+	// Deleting the category would turn the goal into a whole account goal
+	_, err := testStore.DeleteCategoryTx(context.Background(), DeleteCategoryTxParams{ID: category.ID})
+	require.ErrorIs(t, err, ErrCategoryInUse)
+
+	goal, err = testStore.GetGoal(context.Background(), goal.ID)
+	require.NoError(t, err)
+	require.Equal(t, category.ID, *goal.CategoryID)
+}
+
+func TestListCategoryAncestors(t *testing.T) {
+	user := createRandomUser(t)
+	root := createRandomCategory(t, user)
+	child := createRandomChildCategory(t, user, root)
+	grandChild := createRandomChildCategory(t, user, child)
+
+	ancestors, err := testStore.ListCategoryAncestors(context.Background(), grandChild.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int64{grandChild.ID, child.ID, root.ID}, ancestors)
+
+	ancestors, err = testStore.ListCategoryAncestors(context.Background(), root.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int64{root.ID}, ancestors)
+}
+
+func TestListCategoryAncestorsCycle(t *testing.T) {
+	user := createRandomUser(t)
+	root := createRandomCategory(t, user)
+	child := createRandomChildCategory(t, user, root)
+
+	// A cycle stored in the table must not make the walk endless
+	_, err := testStore.MoveCategory(context.Background(), MoveCategoryParams{
+		ID:       root.ID,
+		ParentID: &child.ID,
+	})
+	require.NoError(t, err)
+
+	ancestors, err := testStore.ListCategoryAncestors(context.Background(), child.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int64{child.ID, root.ID}, ancestors)
+}
+
+func TestSumLinesByCategory(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category1 := createRandomCategory(t, user)
+	category2 := createRandomCategory(t, user)
+
+	total, checked := decimal.Zero, decimal.Zero
+	for i := 0; i < 3; i++ {
+		line := createRandomLine(t, user, month, year, account, category1)
+		total = total.Add(line.Amount)
+		if line.Checked {
+			checked = checked.Add(line.Amount)
+		}
+	}
+	line := createRandomLine(t, user, month, year, account, category2)
+
+	sums, err := testStore.SumLinesByCategory(context.Background(), SumLinesByCategoryParams{
+		Owner:   user.Username,
+		MonthID: &month.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, sums, 2)
+	require.Equal(t, category1.ID, sums[0].CategoryID)
+	require.True(t, total.Equal(sums[0].Amount))
+	require.True(t, checked.Equal(sums[0].CheckedAmount))
+	require.Equal(t, category2.ID, sums[1].CategoryID)
+	require.True(t, line.Amount.Equal(sums[1].Amount))
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	housing := Category{ID: 1, Title: "Housing"}
+	rent := Category{ID: 2, Title: "Rent", ParentID: &housing.ID}
+	energy := Category{ID: 3, Title: "Energy", ParentID: &housing.ID}
+	power := Category{ID: 4, Title: "Power", ParentID: &energy.ID}
+	salary := Category{ID: 5, Title: "Salary"}
+	orphanParent := int64(99)
+	orphan := Category{ID: 6, Title: "Orphan", ParentID: &orphanParent}
+
+	amounts := map[int64]decimal.Decimal{
+		housing.ID: decimal.NewFromInt(-5),
+		rent.ID:    decimal.NewFromInt(-800),
+		energy.ID:  decimal.NewFromInt(-20),
+		power.ID:   decimal.NewFromInt(-60),
+		salary.ID:  decimal.NewFromInt(2500),
+	}
+
+	tree := BuildCategoryTree([]Category{housing, rent, energy, power, salary, orphan}, amounts)
+	require.Len(t, tree, 3)
+	require.Equal(t, housing.ID, tree[0].ID)
+	require.Equal(t, salary.ID, tree[1].ID)
+	require.Equal(t, orphan.ID, tree[2].ID)
+
+	require.True(t, tree[0].Amount.Equal(decimal.NewFromInt(-5)))
+	require.True(t, tree[0].Total.Equal(decimal.NewFromInt(-885)))
+	require.Len(t, tree[0].Children, 2)
+	require.Equal(t, rent.ID, tree[0].Children[0].ID)
+	require.True(t, tree[0].Children[1].Total.Equal(decimal.NewFromInt(-80)))
+	require.Equal(t, power.ID, tree[0].Children[1].Children[0].ID)
+
+	require.True(t, tree[1].Total.Equal(decimal.NewFromInt(2500)))
+	require.True(t, tree[2].Total.IsZero())
+	require.Empty(t, tree[2].Children)
+}
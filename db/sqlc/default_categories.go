@@ -0,0 +1,82 @@
+package db
+
+import "github.com/moth13/finance_tracker/util"
+
+// DefaultCategory is a category given to new users, its children share its kind
+type DefaultCategory struct {
+	Title    string
+	Kind     string
+	Color    string
+	Icon     string
+	Children []DefaultCategory
+}
+
+var defaultCategories = map[string][]DefaultCategory{
+	util.EN: {
+		{Title: "Income", Kind: util.INCOME, Color: "#2e7d32", Icon: "salary", Children: []DefaultCategory{
+			{Title: "Salary", Icon: "salary"},
+			{Title: "Other income"},
+		}},
+		{Title: "Housing", Kind: util.EXPENSE, Color: "#6d4c41", Icon: "home", Children: []DefaultCategory{
+			{Title: "Rent"},
+			{Title: "Utilities"},
+			{Title: "Insurance"},
+		}},
+		{Title: "Food", Kind: util.EXPENSE, Color: "#ef6c00", Icon: "food", Children: []DefaultCategory{
+			{Title: "Groceries", Icon: "shopping"},
+			{Title: "Restaurants"},
+		}},
+		{Title: "Transport", Kind: util.EXPENSE, Color: "#1565c0", Icon: "transport", Children: []DefaultCategory{
+			{Title: "Fuel", Icon: "car"},
+			{Title: "Public transport"},
+		}},
+		{Title: "Health", Kind: util.EXPENSE, Color: "#c62828", Icon: "health"},
+		{Title: "Leisure", Kind: util.EXPENSE, Color: "#ad1457", Icon: "leisure", Children: []DefaultCategory{
+			{Title: "Subscriptions", Icon: "subscription"},
+			{Title: "Travel", Icon: "travel"},
+			{Title: "Gifts", Icon: "gift"},
+		}},
+		{Title: "Shopping", Kind: util.EXPENSE, Color: "#00838f", Icon: "shopping"},
+		{Title: "Bank fees", Kind: util.EXPENSE, Color: "#546e7a", Icon: "bank"},
+		{Title: "Savings", Kind: util.SAVINGS, Color: "#6a1b9a", Icon: "piggy-bank"},
+		{Title: "Transfers", Kind: util.TRANSFER, Color: "#455a64", Icon: "transfer"},
+	},
+	util.FR: {
+		{Title: "Revenus", Kind: util.INCOME, Color: "#2e7d32", Icon: "salary", Children: []DefaultCategory{
+			{Title: "Salaire", Icon: "salary"},
+			{Title: "Autres revenus"},
+		}},
+		{Title: "Logement", Kind: util.EXPENSE, Color: "#6d4c41", Icon: "home", Children: []DefaultCategory{
+			{Title: "Loyer"},
+			{Title: "Énergie"},
+			{Title: "Assurances"},
+		}},
+		{Title: "Alimentation", Kind: util.EXPENSE, Color: "#ef6c00", Icon: "food", Children: []DefaultCategory{
+			{Title: "Courses", Icon: "shopping"},
+			{Title: "Restaurants"},
+		}},
+		{Title: "Transport", Kind: util.EXPENSE, Color: "#1565c0", Icon: "transport", Children: []DefaultCategory{
+			{Title: "Carburant", Icon: "car"},
+			{Title: "Transports en commun"},
+		}},
+		{Title: "Santé", Kind: util.EXPENSE, Color: "#c62828", Icon: "health"},
+		{Title: "Loisirs", Kind: util.EXPENSE, Color: "#ad1457", Icon: "leisure", Children: []DefaultCategory{
+			{Title: "Abonnements", Icon: "subscription"},
+			{Title: "Voyages", Icon: "travel"},
+			{Title: "Cadeaux", Icon: "gift"},
+		}},
+		{Title: "Achats", Kind: util.EXPENSE, Color: "#00838f", Icon: "shopping"},
+		{Title: "Frais bancaires", Kind: util.EXPENSE, Color: "#546e7a", Icon: "bank"},
+		{Title: "Épargne", Kind: util.SAVINGS, Color: "#6a1b9a", Icon: "piggy-bank"},
+		{Title: "Virements", Kind: util.TRANSFER, Color: "#455a64", Icon: "transfer"},
+	},
+}
+
+// DefaultCategories returns the default category tree of a locale, english for unsupported ones
+func DefaultCategories(locale string) []DefaultCategory {
+	if categories, ok := defaultCategories[locale]; ok {
+		return categories
+	}
+
+	return defaultCategories[util.EN]
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func assignRandomEnvelope(t *testing.T, user User, category Category, month Month) EnvelopeAssignment {
+	arg := SetEnvelopeAssignmentParams{
+		Owner:      user.Username,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Amount:     decimal.NewFromInt(util.RandomInt(10, 1000)),
+	}
+
+	assignment, err := testStore.SetEnvelopeAssignment(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, assignment.ID)
+	require.Equal(t, arg.Owner, assignment.Owner)
+	require.Equal(t, arg.CategoryID, assignment.CategoryID)
+	require.Equal(t, arg.MonthID, assignment.MonthID)
+	require.True(t, arg.Amount.Equal(assignment.Amount))
+
+	return assignment
+}
+
+func TestSetEnvelopeAssignment(t *testing.T) {
+	user := createRandomUser(t)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	assignment := assignRandomEnvelope(t, user, category, month)
+
+	// Assigning again replaces the amount
+	amount := assignment.Amount.Add(decimal.NewFromInt(5))
+	updated, err := testStore.SetEnvelopeAssignment(context.Background(), SetEnvelopeAssignmentParams{
+		Owner:      user.Username,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Amount:     amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, assignment.ID, updated.ID)
+	require.True(t, amount.Equal(updated.Amount))
+}
+
+func TestSumEnvelopes(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	january := createMonthStarting(t, user, year, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	february := createMonthStarting(t, user, year, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC))
+	march := createMonthStarting(t, user, year, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+	category := createRandomCategory(t, user)
+
+	januaryAssignment := assignRandomEnvelope(t, user, category, january)
+	februaryAssignment := assignRandomEnvelope(t, user, category, february)
+	assignRandomEnvelope(t, user, category, march)
+	januaryLine := createRandomLine(t, user, january, year, account, category)
+	februaryLine := createRandomLine(t, user, february, year, account, category)
+	createRandomLine(t, user, march, year, account, category)
+
+	// Later months are left out
+	assignments, err := testStore.SumEnvelopeAssignments(context.Background(), SumEnvelopeAssignmentsParams{
+		MonthID: february.ID,
+		Owner:   user.Username,
+	})
+	require.NoError(t, err)
+	require.Len(t, assignments, 1)
+	require.Equal(t, category.ID, assignments[0].CategoryID)
+	require.True(t, februaryAssignment.Amount.Equal(assignments[0].Amount))
+	require.True(t, januaryAssignment.Amount.Equal(assignments[0].PreviousAmount))
+
+	activity, err := testStore.SumEnvelopeActivity(context.Background(), SumEnvelopeActivityParams{
+		MonthID: february.ID,
+		Owner:   user.Username,
+	})
+	require.NoError(t, err)
+	require.Len(t, activity, 1)
+	require.True(t, februaryLine.Amount.Equal(activity[0].Amount))
+	require.True(t, januaryLine.Amount.Equal(activity[0].PreviousAmount))
+}
+
+func TestBuildMonthEnvelopes(t *testing.T) {
+	salary := Category{ID: 1, Title: "Salary", Kind: util.INCOME}
+	food := Category{ID: 2, Title: "Food", Kind: util.EXPENSE}
+	leisure := Category{ID: 3, Title: "Leisure", Kind: util.EXPENSE}
+	holidays := Category{ID: 4, Title: "Holidays", Kind: util.SAVINGS}
+	unused := Category{ID: 5, Title: "Unused", Kind: util.EXPENSE}
+	categories := []Category{salary, food, leisure, holidays, unused}
+
+	assignments := []SumEnvelopeAssignmentsRow{
+		{CategoryID: food.ID, Amount: decimal.NewFromInt(400), PreviousAmount: decimal.NewFromInt(400)},
+		{CategoryID: leisure.ID, Amount: decimal.NewFromInt(100), PreviousAmount: decimal.NewFromInt(100)},
+		{CategoryID: holidays.ID, Amount: decimal.NewFromInt(200)},
+	}
+	activity := []SumEnvelopeActivityRow{
+		{CategoryID: salary.ID, Amount: decimal.NewFromInt(2500), PreviousAmount: decimal.NewFromInt(2500)},
+		{CategoryID: food.ID, Amount: decimal.NewFromInt(-350), PreviousAmount: decimal.NewFromInt(-380)},
+		{CategoryID: leisure.ID, Amount: decimal.NewFromInt(-20), PreviousAmount: decimal.NewFromInt(-150)},
+	}
+
+	envelopes := BuildMonthEnvelopes(7, categories, assignments, activity)
+	require.Equal(t, int64(7), envelopes.MonthID)
+	require.True(t, decimal.NewFromInt(2500).Equal(envelopes.Income))
+	require.True(t, decimal.NewFromInt(700).Equal(envelopes.Assigned))
+	require.True(t, decimal.NewFromInt(1800).Equal(envelopes.ToBeBudgeted))
+	require.Len(t, envelopes.Envelopes, 3)
+
+	// Unspent money rolls over
+	require.Equal(t, food, envelopes.Envelopes[0].Category)
+	require.True(t, decimal.NewFromInt(20).Equal(envelopes.Envelopes[0].Carried))
+	require.True(t, decimal.NewFromInt(70).Equal(envelopes.Envelopes[0].Available))
+
+	// Overspending is carried as negative
+	require.Equal(t, leisure, envelopes.Envelopes[1].Category)
+	require.True(t, decimal.NewFromInt(-50).Equal(envelopes.Envelopes[1].Carried))
+	require.True(t, decimal.NewFromInt(30).Equal(envelopes.Envelopes[1].Available))
+
+	require.Equal(t, holidays, envelopes.Envelopes[2].Category)
+	require.True(t, envelopes.Envelopes[2].Carried.IsZero())
+	require.True(t, decimal.NewFromInt(200).Equal(envelopes.Envelopes[2].Available))
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomGoal(t *testing.T, user User, account Account, categoryID *int64) Goal {
+	arg := CreateGoalParams{
+		Owner:        user.Username,
+		Title:        util.RandomTitle(),
+		AccountID:    account.ID,
+		CategoryID:   categoryID,
+		TargetAmount: decimal.NewFromInt(util.RandomInt(1000, 10000)),
+		TargetDate:   time.Date(time.Now().Year()+1, time.June, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	goal, err := testStore.CreateGoal(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, goal)
+
+	require.NotZero(t, goal.ID)
+	require.Equal(t, arg.Owner, goal.Owner)
+	require.Equal(t, arg.Title, goal.Title)
+	require.Equal(t, arg.AccountID, goal.AccountID)
+	require.Equal(t, arg.CategoryID, goal.CategoryID)
+	require.True(t, arg.TargetAmount.Equal(goal.TargetAmount))
+	require.WithinDuration(t, arg.TargetDate, goal.TargetDate, time.Second)
+	require.Nil(t, goal.ReclineID)
+	require.NotZero(t, goal.CreateAt)
+
+	return goal
+}
+
+func TestCreateGoal(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	category := createRandomCategory(t, user)
+
+	goal := createRandomGoal(t, user, account, &category.ID)
+
+	got, err := testStore.GetGoal(context.Background(), goal.ID)
+	require.NoError(t, err)
+	require.Equal(t, goal, got)
+}
+
+func TestListGoals(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+
+	for i := 0; i < 3; i++ {
+		createRandomGoal(t, user, account, nil)
+	}
+
+	goals, err := testStore.ListGoals(context.Background(), ListGoalsParams{
+		Owner: user.Username,
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, goals, 3)
+	for _, goal := range goals {
+		require.Equal(t, user.Username, goal.Owner)
+	}
+}
+
+func TestUpdateGoal(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	category := createRandomCategory(t, user)
+	goal := createRandomGoal(t, user, account, nil)
+
+	arg := UpdateGoalParams{
+		ID:           goal.ID,
+		Title:        util.RandomTitle(),
+		AccountID:    account.ID,
+		CategoryID:   &category.ID,
+		TargetAmount: goal.TargetAmount.Add(decimal.NewFromInt(500)),
+		TargetDate:   goal.TargetDate.AddDate(0, 6, 0),
+	}
+
+	updated, err := testStore.UpdateGoal(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Title, updated.Title)
+	require.Equal(t, arg.CategoryID, updated.CategoryID)
+	require.True(t, arg.TargetAmount.Equal(updated.TargetAmount))
+	require.WithinDuration(t, arg.TargetDate, updated.TargetDate, time.Second)
+}
+
+func TestDeleteGoal(t *testing.T) {
+	user := createRandomUser(t)
+	goal := createRandomGoal(t, user, createRandomAccount(t, user), nil)
+
+	require.NoError(t, testStore.DeleteGoal(context.Background(), goal.ID))
+
+	_, err := testStore.GetGoal(context.Background(), goal.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestSumGoalLines(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	savings := createRandomCategory(t, user)
+	other := createRandomCategory(t, user)
+
+	saved := decimal.Zero
+	projected := decimal.Zero
+	for i := 0; i < 4; i++ {
+		line := createRandomLine(t, user, month, year, account, savings)
+		projected = projected.Add(line.Amount)
+		if line.Checked {
+			saved = saved.Add(line.Amount)
+		}
+	}
+	createRandomLine(t, user, month, year, account, other)
+	createRandomLine(t, user, month, year, createRandomAccount(t, user), savings)
+
+	sums, err := testStore.SumGoalLines(context.Background(), SumGoalLinesParams{
+		AccountID:  account.ID,
+		CategoryID: savings.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, saved.Equal(sums.Saved))
+	require.True(t, projected.Equal(sums.Projected))
+}
+
+func TestBuildGoalProgress(t *testing.T) {
+	goal := Goal{
+		TargetAmount: decimal.NewFromInt(1200),
+		TargetDate:   time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC),
+		CreateAt:     time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	july := time.Date(2025, time.July, 2, 0, 0, 0, 0, time.UTC)
+
+	// Half way through the year, 600 should be saved
+	progress := BuildGoalProgress(goal, decimal.NewFromInt(700), decimal.NewFromInt(900), july)
+	require.Equal(t, GoalOnTrack, progress.Status)
+	require.Equal(t, 6, progress.MonthsLeft)
+	require.True(t, decimal.NewFromInt(500).Equal(progress.Remaining))
+	require.True(t, decimal.RequireFromString("83.34").Equal(progress.MonthlyContribution))
+	require.True(t, decimal.RequireFromString("58.33").Equal(progress.Percent))
+
+	progress = BuildGoalProgress(goal, decimal.NewFromInt(300), decimal.NewFromInt(300), july)
+	require.Equal(t, GoalBehind, progress.Status)
+	require.True(t, decimal.NewFromInt(150).Equal(progress.MonthlyContribution))
+
+	progress = BuildGoalProgress(goal, decimal.NewFromInt(1300), decimal.NewFromInt(1300), july)
+	require.Equal(t, GoalAchieved, progress.Status)
+	require.True(t, progress.Remaining.IsZero())
+	require.True(t, progress.MonthlyContribution.IsZero())
+
+	// Past the target date, the whole remaining amount is due
+	progress = BuildGoalProgress(goal, decimal.NewFromInt(1000), decimal.NewFromInt(1000), goal.TargetDate.AddDate(0, 0, 1))
+	require.Equal(t, GoalOverdue, progress.Status)
+	require.Zero(t, progress.MonthsLeft)
+	require.True(t, decimal.NewFromInt(200).Equal(progress.MonthlyContribution))
+}
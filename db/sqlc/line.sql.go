@@ -23,10 +23,12 @@ INSERT INTO lines (
   amount,
   checked,
   description,
-  due_date
+  due_date,
+  payee,
+  tags
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE($11::varchar, ''), COALESCE($12::varchar[], '{}')
+) RETURNING id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date, payee, tags
 `
 
 type CreateLineParams struct {
@@ -40,6 +42,8 @@ type CreateLineParams struct {
 	Checked     bool            `json:"checked"`
 	Description string          `json:"description"`
 	DueDate     time.Time       `json:"due_date"`
+	Payee       *string         `json:"payee"`
+	Tags        []string        `json:"tags"`
 }
 
 func (q *Queries) CreateLine(ctx context.Context, arg CreateLineParams) (Line, error) {
@@ -54,6 +58,8 @@ func (q *Queries) CreateLine(ctx context.Context, arg CreateLineParams) (Line, e
 		arg.Checked,
 		arg.Description,
 		arg.DueDate,
+		arg.Payee,
+		arg.Tags,
 	)
 	var i Line
 	err := row.Scan(
@@ -68,6 +74,8 @@ func (q *Queries) CreateLine(ctx context.Context, arg CreateLineParams) (Line, e
 		&i.Checked,
 		&i.Description,
 		&i.DueDate,
+		&i.Payee,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -81,8 +89,22 @@ func (q *Queries) DeleteLine(ctx context.Context, id int64) error {
 	return err
 }
 
+const getAccountFirstLineDate = `-- name: GetAccountFirstLineDate :one
+SELECT due_date FROM lines
+WHERE account_id = $1
+ORDER BY due_date
+LIMIT 1
+`
+
+func (q *Queries) GetAccountFirstLineDate(ctx context.Context, accountID int64) (time.Time, error) {
+	row := q.db.QueryRow(ctx, getAccountFirstLineDate, accountID)
+	var due_date time.Time
+	err := row.Scan(&due_date)
+	return due_date, err
+}
+
 const getExpliciteLine = `-- name: GetExpliciteLine :one
-SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date FROM lines
+SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date, categories.kind as category_kind, categories.color as category_color, categories.icon as category_icon, lines.category_id FROM lines
 JOIN accounts ON accounts.id = lines.account_id
 JOIN months ON months.id = lines.month_id
 JOIN categories ON categories.id = lines.category_id
@@ -90,16 +112,20 @@ WHERE lines.id = $1
 `
 
 type GetExpliciteLineRow struct {
-	ID          int64           `json:"id"`
-	Owner       string          `json:"owner"`
-	Title       string          `json:"title"`
-	Account     string          `json:"account"`
-	Month       string          `json:"month"`
-	Category    string          `json:"category"`
-	Amount      decimal.Decimal `json:"amount"`
-	Checked     bool            `json:"checked"`
-	Description string          `json:"description"`
-	DueDate     time.Time       `json:"due_date"`
+	ID            int64           `json:"id"`
+	Owner         string          `json:"owner"`
+	Title         string          `json:"title"`
+	Account       string          `json:"account"`
+	Month         string          `json:"month"`
+	Category      string          `json:"category"`
+	Amount        decimal.Decimal `json:"amount"`
+	Checked       bool            `json:"checked"`
+	Description   string          `json:"description"`
+	DueDate       time.Time       `json:"due_date"`
+	CategoryKind  string          `json:"category_kind"`
+	CategoryColor string          `json:"category_color"`
+	CategoryIcon  string          `json:"category_icon"`
+	CategoryID    int64           `json:"category_id"`
 }
 
 func (q *Queries) GetExpliciteLine(ctx context.Context, id int64) (GetExpliciteLineRow, error) {
@@ -116,12 +142,16 @@ func (q *Queries) GetExpliciteLine(ctx context.Context, id int64) (GetExpliciteL
 		&i.Checked,
 		&i.Description,
 		&i.DueDate,
+		&i.CategoryKind,
+		&i.CategoryColor,
+		&i.CategoryIcon,
+		&i.CategoryID,
 	)
 	return i, err
 }
 
 const getLine = `-- name: GetLine :one
-SELECT id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date FROM lines
+SELECT id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date, payee, tags FROM lines
 WHERE id = $1 LIMIT 1
 `
 
@@ -140,12 +170,14 @@ func (q *Queries) GetLine(ctx context.Context, id int64) (Line, error) {
 		&i.Checked,
 		&i.Description,
 		&i.DueDate,
+		&i.Payee,
+		&i.Tags,
 	)
 	return i, err
 }
 
 const getLineForUpdate = `-- name: GetLineForUpdate :one
-SELECT id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date FROM lines
+SELECT id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date, payee, tags FROM lines
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
 `
 
@@ -164,42 +196,269 @@ func (q *Queries) GetLineForUpdate(ctx context.Context, id int64) (Line, error)
 		&i.Checked,
 		&i.Description,
 		&i.DueDate,
+		&i.Payee,
+		&i.Tags,
 	)
 	return i, err
 }
 
+const listAccountHistory = `-- name: ListAccountHistory :many
+SELECT history.id, history.title, history.account, history.month, history.category, history.amount, history.checked, history.description, history.due_date, history.category_kind, history.category_color, history.category_icon, history.category_id, history.cleared_balance, history.projected_balance FROM (
+  SELECT lines.id, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date, categories.kind as category_kind, categories.color as category_color, categories.icon as category_icon, lines.category_id,
+    (accounts.init_balance + COALESCE(SUM(lines.amount) FILTER (WHERE lines.checked) OVER running, 0))::numeric AS cleared_balance,
+    (accounts.init_balance + SUM(lines.amount) OVER running)::numeric AS projected_balance
+  FROM lines
+  JOIN accounts ON accounts.id = lines.account_id
+  JOIN months ON months.id = lines.month_id
+  JOIN categories ON categories.id = lines.category_id
+  WHERE lines.account_id = $1
+  WINDOW running AS (ORDER BY lines.due_date, lines.id)
+) AS history
+ORDER BY history.due_date DESC, history.id DESC
+LIMIT $2
+OFFSET $3
+`
+
+type ListAccountHistoryParams struct {
+	AccountID int64 `json:"account_id"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+type ListAccountHistoryRow struct {
+	ID               int64           `json:"id"`
+	Title            string          `json:"title"`
+	Account          string          `json:"account"`
+	Month            string          `json:"month"`
+	Category         string          `json:"category"`
+	Amount           decimal.Decimal `json:"amount"`
+	Checked          bool            `json:"checked"`
+	Description      string          `json:"description"`
+	DueDate          time.Time       `json:"due_date"`
+	CategoryKind     string          `json:"category_kind"`
+	CategoryColor    string          `json:"category_color"`
+	CategoryIcon     string          `json:"category_icon"`
+	CategoryID       int64           `json:"category_id"`
+	ClearedBalance   decimal.Decimal `json:"cleared_balance"`
+	ProjectedBalance decimal.Decimal `json:"projected_balance"`
+}
+
+func (q *Queries) ListAccountHistory(ctx context.Context, arg ListAccountHistoryParams) ([]ListAccountHistoryRow, error) {
+	rows, err := q.db.Query(ctx, listAccountHistory,
+		arg.AccountID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountHistoryRow{}
+	for rows.Next() {
+		var i ListAccountHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Account,
+			&i.Month,
+			&i.Category,
+			&i.Amount,
+			&i.Checked,
+			&i.Description,
+			&i.DueDate,
+			&i.CategoryKind,
+			&i.CategoryColor,
+			&i.CategoryIcon,
+			&i.CategoryID,
+			&i.ClearedBalance,
+			&i.ProjectedBalance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountLinesBetween = `-- name: ListAccountLinesBetween :many
+SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date, categories.kind as category_kind, categories.color as category_color, categories.icon as category_icon, lines.category_id FROM lines
+JOIN accounts ON accounts.id = lines.account_id
+JOIN months ON months.id = lines.month_id
+JOIN categories ON categories.id = lines.category_id
+WHERE lines.account_id = $1
+  AND lines.due_date >= $2
+  AND lines.due_date <= $3
+ORDER BY lines.due_date, lines.id
+LIMIT $4
+OFFSET $5
+`
+
+type ListAccountLinesBetweenParams struct {
+	AccountID int64     `json:"account_id"`
+	FromDate  time.Time `json:"from_date"`
+	ToDate    time.Time `json:"to_date"`
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
+}
+
+type ListAccountLinesBetweenRow struct {
+	ID            int64           `json:"id"`
+	Owner         string          `json:"owner"`
+	Title         string          `json:"title"`
+	Account       string          `json:"account"`
+	Month         string          `json:"month"`
+	Category      string          `json:"category"`
+	Amount        decimal.Decimal `json:"amount"`
+	Checked       bool            `json:"checked"`
+	Description   string          `json:"description"`
+	DueDate       time.Time       `json:"due_date"`
+	CategoryKind  string          `json:"category_kind"`
+	CategoryColor string          `json:"category_color"`
+	CategoryIcon  string          `json:"category_icon"`
+	CategoryID    int64           `json:"category_id"`
+}
+
+func (q *Queries) ListAccountLinesBetween(ctx context.Context, arg ListAccountLinesBetweenParams) ([]ListAccountLinesBetweenRow, error) {
+	rows, err := q.db.Query(ctx, listAccountLinesBetween,
+		arg.AccountID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountLinesBetweenRow{}
+	for rows.Next() {
+		var i ListAccountLinesBetweenRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Title,
+			&i.Account,
+			&i.Month,
+			&i.Category,
+			&i.Amount,
+			&i.Checked,
+			&i.Description,
+			&i.DueDate,
+			&i.CategoryKind,
+			&i.CategoryColor,
+			&i.CategoryIcon,
+			&i.CategoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChargeLines = `-- name: ListChargeLines :many
+SELECT lines.id, lines.title, lines.payee, lines.category_id, lines.amount, lines.due_date FROM lines
+JOIN categories ON categories.id = lines.category_id
+WHERE lines.owner = $1
+  AND lines.amount < 0
+  AND categories.kind <> 'TRANSFER'
+ORDER BY lines.due_date, lines.id
+`
+
+type ListChargeLinesRow struct {
+	ID         int64           `json:"id"`
+	Title      string          `json:"title"`
+	Payee      string          `json:"payee"`
+	CategoryID int64           `json:"category_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	DueDate    time.Time       `json:"due_date"`
+}
+
+func (q *Queries) ListChargeLines(ctx context.Context, owner string) ([]ListChargeLinesRow, error) {
+	rows, err := q.db.Query(ctx, listChargeLines, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListChargeLinesRow{}
+	for rows.Next() {
+		var i ListChargeLinesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Payee,
+			&i.CategoryID,
+			&i.Amount,
+			&i.DueDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listExplicitLines = `-- name: ListExplicitLines :many
-SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date FROM lines
+SELECT lines.id, lines.owner, lines.title, accounts.title as account, months.title as month, categories.title as category, lines.amount, lines.checked, lines.description, lines.due_date, categories.kind as category_kind, categories.color as category_color, categories.icon as category_icon, lines.category_id FROM lines
 JOIN accounts ON accounts.id = lines.account_id
 JOIN months ON months.id = lines.month_id
 JOIN categories ON categories.id = lines.category_id
 WHERE lines.owner = $1
-ORDER BY lines.due_date DESC
-LIMIT $2
-OFFSET $3
+  AND ($2::bigint IS NULL OR lines.account_id = $2)
+  AND ($3::bigint IS NULL OR lines.month_id = $3)
+  AND ($4::bigint IS NULL OR lines.year_id = $4)
+  AND ($5::bigint IS NULL OR lines.category_id = $5)
+ORDER BY lines.due_date DESC, lines.id DESC
+LIMIT $6
+OFFSET $7
 `
 
 type ListExplicitLinesParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	Owner      string `json:"owner"`
+	AccountID  *int64 `json:"account_id"`
+	MonthID    *int64 `json:"month_id"`
+	YearID     *int64 `json:"year_id"`
+	CategoryID *int64 `json:"category_id"`
+	Limit      int32  `json:"limit"`
+	Offset     int32  `json:"offset"`
 }
 
 type ListExplicitLinesRow struct {
-	ID          int64           `json:"id"`
-	Owner       string          `json:"owner"`
-	Title       string          `json:"title"`
-	Account     string          `json:"account"`
-	Month       string          `json:"month"`
-	Category    string          `json:"category"`
-	Amount      decimal.Decimal `json:"amount"`
-	Checked     bool            `json:"checked"`
-	Description string          `json:"description"`
-	DueDate     time.Time       `json:"due_date"`
+	ID            int64           `json:"id"`
+	Owner         string          `json:"owner"`
+	Title         string          `json:"title"`
+	Account       string          `json:"account"`
+	Month         string          `json:"month"`
+	Category      string          `json:"category"`
+	Amount        decimal.Decimal `json:"amount"`
+	Checked       bool            `json:"checked"`
+	Description   string          `json:"description"`
+	DueDate       time.Time       `json:"due_date"`
+	CategoryKind  string          `json:"category_kind"`
+	CategoryColor string          `json:"category_color"`
+	CategoryIcon  string          `json:"category_icon"`
+	CategoryID    int64           `json:"category_id"`
 }
 
 func (q *Queries) ListExplicitLines(ctx context.Context, arg ListExplicitLinesParams) ([]ListExplicitLinesRow, error) {
-	rows, err := q.db.Query(ctx, listExplicitLines, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listExplicitLines,
+		arg.Owner,
+		arg.AccountID,
+		arg.MonthID,
+		arg.YearID,
+		arg.CategoryID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -218,6 +477,10 @@ func (q *Queries) ListExplicitLines(ctx context.Context, arg ListExplicitLinesPa
 			&i.Checked,
 			&i.Description,
 			&i.DueDate,
+			&i.CategoryKind,
+			&i.CategoryColor,
+			&i.CategoryIcon,
+			&i.CategoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -230,21 +493,85 @@ func (q *Queries) ListExplicitLines(ctx context.Context, arg ListExplicitLinesPa
 }
 
 const listLines = `-- name: ListLines :many
-SELECT id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date FROM lines
+SELECT id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date, payee, tags FROM lines
 WHERE owner = $1
-ORDER BY due_date DESC
-LIMIT $2
-OFFSET $3
+  AND ($2::bigint IS NULL OR account_id = $2)
+  AND ($3::bigint IS NULL OR month_id = $3)
+  AND ($4::bigint IS NULL OR year_id = $4)
+  AND ($5::bigint IS NULL OR category_id = $5)
+ORDER BY due_date DESC, id DESC
+LIMIT $6
+OFFSET $7
 `
 
 type ListLinesParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	Owner      string `json:"owner"`
+	AccountID  *int64 `json:"account_id"`
+	MonthID    *int64 `json:"month_id"`
+	YearID     *int64 `json:"year_id"`
+	CategoryID *int64 `json:"category_id"`
+	Limit      int32  `json:"limit"`
+	Offset     int32  `json:"offset"`
 }
 
 func (q *Queries) ListLines(ctx context.Context, arg ListLinesParams) ([]Line, error) {
-	rows, err := q.db.Query(ctx, listLines, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listLines,
+		arg.Owner,
+		arg.AccountID,
+		arg.MonthID,
+		arg.YearID,
+		arg.CategoryID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Line{}
+	for rows.Next() {
+		var i Line
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Title,
+			&i.AccountID,
+			&i.MonthID,
+			&i.YearID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Checked,
+			&i.Description,
+			&i.DueDate,
+			&i.Payee,
+			&i.Tags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinesBetween = `-- name: ListLinesBetween :many
+SELECT id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date, payee, tags FROM lines
+WHERE owner = $1
+  AND due_date >= $2
+  AND due_date <= $3
+ORDER BY due_date, id
+`
+
+type ListLinesBetweenParams struct {
+	Owner    string    `json:"owner"`
+	FromDate time.Time `json:"from_date"`
+	ToDate   time.Time `json:"to_date"`
+}
+
+func (q *Queries) ListLinesBetween(ctx context.Context, arg ListLinesBetweenParams) ([]Line, error) {
+	rows, err := q.db.Query(ctx, listLinesBetween, arg.Owner, arg.FromDate, arg.ToDate)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +591,110 @@ func (q *Queries) ListLines(ctx context.Context, arg ListLinesParams) ([]Line, e
 			&i.Checked,
 			&i.Description,
 			&i.DueDate,
+			&i.Payee,
+			&i.Tags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUncheckedLinesBefore = `-- name: ListUncheckedLinesBefore :many
+SELECT id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date, payee, tags FROM lines
+WHERE owner = $1
+  AND checked = false
+  AND due_date < $2
+ORDER BY due_date, id
+`
+
+type ListUncheckedLinesBeforeParams struct {
+	Owner  string    `json:"owner"`
+	ToDate time.Time `json:"to_date"`
+}
+
+func (q *Queries) ListUncheckedLinesBefore(ctx context.Context, arg ListUncheckedLinesBeforeParams) ([]Line, error) {
+	rows, err := q.db.Query(ctx, listUncheckedLinesBefore, arg.Owner, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Line{}
+	for rows.Next() {
+		var i Line
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Title,
+			&i.AccountID,
+			&i.MonthID,
+			&i.YearID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Checked,
+			&i.Description,
+			&i.DueDate,
+			&i.Payee,
+			&i.Tags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUpcomingLines = `-- name: ListUpcomingLines :many
+SELECT lines.id, lines.title, accounts.title as account, categories.title as category, lines.amount, lines.description, lines.due_date FROM lines
+JOIN accounts ON accounts.id = lines.account_id
+JOIN categories ON categories.id = lines.category_id
+WHERE lines.owner = $1
+  AND lines.checked = false
+  AND lines.due_date >= $2
+  AND lines.due_date < $3
+ORDER BY lines.due_date, lines.id
+`
+
+type ListUpcomingLinesParams struct {
+	Owner    string    `json:"owner"`
+	FromDate time.Time `json:"from_date"`
+	ToDate   time.Time `json:"to_date"`
+}
+
+type ListUpcomingLinesRow struct {
+	ID          int64           `json:"id"`
+	Title       string          `json:"title"`
+	Account     string          `json:"account"`
+	Category    string          `json:"category"`
+	Amount      decimal.Decimal `json:"amount"`
+	Description string          `json:"description"`
+	DueDate     time.Time       `json:"due_date"`
+}
+
+func (q *Queries) ListUpcomingLines(ctx context.Context, arg ListUpcomingLinesParams) ([]ListUpcomingLinesRow, error) {
+	rows, err := q.db.Query(ctx, listUpcomingLines, arg.Owner, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUpcomingLinesRow{}
+	for rows.Next() {
+		var i ListUpcomingLinesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Account,
+			&i.Category,
+			&i.Amount,
+			&i.Description,
+			&i.DueDate,
 		); err != nil {
 			return nil, err
 		}
@@ -275,11 +706,55 @@ func (q *Queries) ListLines(ctx context.Context, arg ListLinesParams) ([]Line, e
 	return items, nil
 }
 
+const reassignLinesCategory = `-- name: ReassignLinesCategory :execrows
+UPDATE lines
+SET category_id = $1
+WHERE category_id = $2
+`
+
+type ReassignLinesCategoryParams struct {
+	TargetID int64 `json:"target_id"`
+	SourceID int64 `json:"source_id"`
+}
+
+func (q *Queries) ReassignLinesCategory(ctx context.Context, arg ReassignLinesCategoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignLinesCategory, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const sumAccountLinesBefore = `-- name: SumAccountLinesBefore :one
+SELECT
+  COALESCE(SUM(amount) FILTER (WHERE checked), 0)::numeric AS balance,
+  COALESCE(SUM(amount), 0)::numeric AS final_balance
+FROM lines
+WHERE account_id = $1 AND due_date < $2
+`
+
+type SumAccountLinesBeforeParams struct {
+	AccountID int64     `json:"account_id"`
+	Before    time.Time `json:"before"`
+}
+
+type SumAccountLinesBeforeRow struct {
+	Balance      decimal.Decimal `json:"balance"`
+	FinalBalance decimal.Decimal `json:"final_balance"`
+}
+
+func (q *Queries) SumAccountLinesBefore(ctx context.Context, arg SumAccountLinesBeforeParams) (SumAccountLinesBeforeRow, error) {
+	row := q.db.QueryRow(ctx, sumAccountLinesBefore, arg.AccountID, arg.Before)
+	var i SumAccountLinesBeforeRow
+	err := row.Scan(&i.Balance, &i.FinalBalance)
+	return i, err
+}
+
 const updateLine = `-- name: UpdateLine :one
 UPDATE lines
 SET title = $2, account_id = $3, month_id = $4, category_id = $5, year_id = $6, amount = $7, checked = $8, description = $9, due_date = $10
 WHERE id = $1
-RETURNING id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date
+RETURNING id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date, payee, tags
 `
 
 type UpdateLineParams struct {
@@ -321,6 +796,52 @@ func (q *Queries) UpdateLine(ctx context.Context, arg UpdateLineParams) (Line, e
 		&i.Checked,
 		&i.Description,
 		&i.DueDate,
+		&i.Payee,
+		&i.Tags,
+	)
+	return i, err
+}
+
+const updateLineRuleFields = `-- name: UpdateLineRuleFields :one
+UPDATE lines
+SET category_id = $2, payee = $3, tags = $4, checked = $5, description = $6
+WHERE id = $1
+RETURNING id, owner, title, account_id, month_id, year_id, category_id, amount, checked, description, due_date, payee, tags
+`
+
+type UpdateLineRuleFieldsParams struct {
+	ID          int64    `json:"id"`
+	CategoryID  int64    `json:"category_id"`
+	Payee       string   `json:"payee"`
+	Tags        []string `json:"tags"`
+	Checked     bool     `json:"checked"`
+	Description string   `json:"description"`
+}
+
+func (q *Queries) UpdateLineRuleFields(ctx context.Context, arg UpdateLineRuleFieldsParams) (Line, error) {
+	row := q.db.QueryRow(ctx, updateLineRuleFields,
+		arg.ID,
+		arg.CategoryID,
+		arg.Payee,
+		arg.Tags,
+		arg.Checked,
+		arg.Description,
+	)
+	var i Line
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Title,
+		&i.AccountID,
+		&i.MonthID,
+		&i.YearID,
+		&i.CategoryID,
+		&i.Amount,
+		&i.Checked,
+		&i.Description,
+		&i.DueDate,
+		&i.Payee,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moth13/finance_tracker/anomaly"
+	"github.com/moth13/finance_tracker/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomLineAnomaly(t *testing.T, user User, line Line, kind string) LineAnomaly {
+	arg := CreateLineAnomalyParams{
+		Owner:  user.Username,
+		LineID: line.ID,
+		Kind:   kind,
+		Reason: util.RandomString(20),
+	}
+
+	lineAnomaly, err := testStore.CreateLineAnomaly(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, lineAnomaly.ID)
+	require.Equal(t, arg.Owner, lineAnomaly.Owner)
+	require.Equal(t, arg.LineID, lineAnomaly.LineID)
+	require.Equal(t, arg.Kind, lineAnomaly.Kind)
+	require.Equal(t, arg.Reason, lineAnomaly.Reason)
+
+	return lineAnomaly
+}
+
+func TestCreateLineAnomaly(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	line := createRandomLine(t, user, month, year, account, category)
+
+	first := createRandomLineAnomaly(t, user, line, anomaly.KindDuplicate)
+	require.False(t, first.Dismissed)
+
+	dismissed, err := testStore.DismissLineAnomaly(context.Background(), first.ID)
+	require.NoError(t, err)
+	require.True(t, dismissed.Dismissed)
+
+	// Detecting the anomaly again updates its reason and keeps it dismissed
+	again := createRandomLineAnomaly(t, user, line, anomaly.KindDuplicate)
+	require.Equal(t, first.ID, again.ID)
+	require.True(t, again.Dismissed)
+
+	got, err := testStore.GetLineAnomaly(context.Background(), first.ID)
+	require.NoError(t, err)
+	require.Equal(t, again, got)
+}
+
+func TestListLineAnomalies(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	line := createRandomLine(t, user, month, year, account, category)
+	createRandomLineAnomaly(t, user, line, anomaly.KindUnusualAmount)
+	dismissed := createRandomLineAnomaly(t, user, line, anomaly.KindDuplicate)
+	_, err := testStore.DismissLineAnomaly(context.Background(), dismissed.ID)
+	require.NoError(t, err)
+
+	anomalies, err := testStore.ListLineAnomalies(context.Background(), ListLineAnomaliesParams{
+		Owner:  user.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, anomalies, 1)
+	require.Equal(t, anomaly.KindUnusualAmount, anomalies[0].Kind)
+	require.Equal(t, line.Title, anomalies[0].Title)
+	require.True(t, line.Amount.Equal(anomalies[0].Amount))
+
+	anomalies, err = testStore.ListLineAnomalies(context.Background(), ListLineAnomaliesParams{
+		Owner:            user.Username,
+		IncludeDismissed: true,
+		Limit:            5,
+		Offset:           0,
+	})
+	require.NoError(t, err)
+	require.Len(t, anomalies, 2)
+}
+
+func TestDeleteStaleLineAnomalies(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	line := createRandomLine(t, user, month, year, account, category)
+
+	kept := createRandomLineAnomaly(t, user, line, anomaly.KindUnusualAmount)
+	createRandomLineAnomaly(t, user, line, anomaly.KindNewPayee)
+	dismissed := createRandomLineAnomaly(t, user, line, anomaly.KindDuplicate)
+	_, err := testStore.DismissLineAnomaly(context.Background(), dismissed.ID)
+	require.NoError(t, err)
+
+	removed, err := testStore.DeleteStaleLineAnomalies(context.Background(), DeleteStaleLineAnomaliesParams{
+		Owner:   user.Username,
+		KeepIds: []int64{kept.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed)
+
+	_, err = testStore.GetLineAnomaly(context.Background(), dismissed.ID)
+	require.NoError(t, err)
+}
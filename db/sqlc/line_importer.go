@@ -0,0 +1,246 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/rules"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// categoryPathSeparator separates the levels of an imported category path
+const categoryPathSeparator = ":"
+
+// defaultImportCategory is given to imported lines without category when no fallback is provided
+const defaultImportCategory = "Uncategorized"
+
+// lineImporter creates imported lines within a transaction, remembering what it had to create on the way
+type lineImporter struct {
+	q     *Queries
+	owner string
+	// createPeriods creates the missing years and months instead of failing
+	createPeriods bool
+
+	categories map[string]int64
+	// rules is loaded with the first imported line
+	rules *rules.Engine
+
+	createdCategories []Category
+	createdYears      []Year
+	createdMonths     []Month
+}
+
+func newLineImporter(q *Queries, owner string, createPeriods bool) *lineImporter {
+	return &lineImporter{
+		q:                 q,
+		owner:             owner,
+		createPeriods:     createPeriods,
+		categories:        map[string]int64{},
+		createdCategories: []Category{},
+		createdYears:      []Year{},
+		createdMonths:     []Month{},
+	}
+}
+
+// importLine creates a line in the account and updates balances.
+// It returns false when the line has already been imported.
+func (li *lineImporter) importLine(ctx context.Context, accountID int64, fallbackCategoryID int64, line ImportLineParams) (Line, util.Balance, bool, error) {
+	var balance util.Balance
+
+	// Skip lines already imported
+	_, err := li.q.GetLineImport(ctx, GetLineImportParams{
+		AccountID: accountID,
+		DueDate:   line.DueDate,
+		Amount:    line.Amount,
+		Reference: line.Reference,
+	})
+	if err == nil {
+		return Line{}, balance, false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return Line{}, balance, false, err
+	}
+
+	month, err := li.month(ctx, line.DueDate)
+	if err != nil {
+		return Line{}, balance, false, err
+	}
+
+	categoryID, err := li.categoryID(ctx, line.Category, fallbackCategoryID)
+	if err != nil {
+		return Line{}, balance, false, err
+	}
+
+	if li.rules == nil {
+		li.rules, err = loadRuleEngine(ctx, li.q, li.owner)
+		if err != nil {
+			return Line{}, balance, false, err
+		}
+	}
+	ruled, _ := li.rules.Apply(rules.Line{
+		Title:       line.Title,
+		Amount:      line.Amount,
+		AccountID:   accountID,
+		DueDate:     line.DueDate,
+		CategoryID:  categoryID,
+		Checked:     line.Checked,
+		Description: line.Description,
+	})
+
+	argAdd := addMoneyTxParams{
+		Amount:      decimal.Zero,
+		FinalAmount: line.Amount,
+		AccountID:   accountID,
+		MonthID:     month.ID,
+		YearID:      month.YearID,
+		DueDate:     line.DueDate,
+	}
+
+	if ruled.Checked {
+		argAdd.Amount = line.Amount
+	}
+
+	// Update balance for each parts, ie add the amount of the line
+	balance, err = addMoneyTx(ctx, li.q, argAdd)
+	if err != nil {
+		return Line{}, balance, false, err
+	}
+
+	created, err := li.q.CreateLine(ctx, CreateLineParams{
+		Title:       line.Title,
+		Owner:       li.owner,
+		AccountID:   accountID,
+		MonthID:     month.ID,
+		CategoryID:  ruled.CategoryID,
+		YearID:      month.YearID,
+		Amount:      line.Amount,
+		Checked:     ruled.Checked,
+		Description: ruled.Description,
+		DueDate:     line.DueDate,
+		Payee:       &ruled.Payee,
+		Tags:        ruled.Tags,
+	})
+	if err != nil {
+		return Line{}, balance, false, err
+	}
+
+	_, err = li.q.CreateLineImport(ctx, CreateLineImportParams{
+		Owner:     li.owner,
+		AccountID: accountID,
+		LineID:    created.ID,
+		DueDate:   line.DueDate,
+		Amount:    line.Amount,
+		Reference: line.Reference,
+	})
+	if err != nil {
+		return Line{}, balance, false, err
+	}
+
+	return created, balance, true, nil
+}
+
+// categoryID finds a category by its path, as in Housing:Rent, creating the missing levels when needed
+func (li *lineImporter) categoryID(ctx context.Context, path string, fallbackID int64) (int64, error) {
+	var levels []string
+	for _, level := range strings.Split(path, categoryPathSeparator) {
+		if level = strings.TrimSpace(level); level != "" {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) == 0 {
+		if fallbackID != 0 {
+			return fallbackID, nil
+		}
+		levels = []string{defaultImportCategory}
+	}
+
+	// Each level is a child of the previous one
+	var parentID *int64
+	for i, level := range levels {
+		key := strings.Join(levels[:i+1], categoryPathSeparator)
+		id, ok := li.categories[key]
+		if !ok {
+			category, err := li.q.GetCategoryByTitle(ctx, GetCategoryByTitleParams{
+				Owner:    li.owner,
+				Title:    level,
+				ParentID: parentID,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				category, err = li.q.CreateCategory(ctx, CreateCategoryParams{
+					Title:    level,
+					Owner:    li.owner,
+					ParentID: parentID,
+					Kind:     util.EXPENSE,
+				})
+				if err == nil {
+					li.createdCategories = append(li.createdCategories, category)
+				}
+			}
+			if err != nil {
+				return 0, err
+			}
+
+			id = category.ID
+			li.categories[key] = id
+		}
+		parentID = &id
+	}
+
+	return *parentID, nil
+}
+
+// month finds the month covering a date, creating it with its year when allowed
+func (li *lineImporter) month(ctx context.Context, date time.Time) (Month, error) {
+	month, err := li.q.GetMonthByDate(ctx, GetMonthByDateParams{
+		Owner: li.owner,
+		Date:  date,
+	})
+	if err == nil || !errors.Is(err, pgx.ErrNoRows) {
+		return month, err
+	}
+
+	if !li.createPeriods {
+		return month, fmt.Errorf("no month covers %s", date.Format("2006-01-02"))
+	}
+
+	year, err := li.q.GetYearByDate(ctx, GetYearByDateParams{
+		Owner: li.owner,
+		Date:  date,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		year, err = li.q.CreateYear(ctx, CreateYearParams{
+			Title:       fmt.Sprintf("%d", date.Year()),
+			Owner:       li.owner,
+			Description: "",
+			StartDate:   time.Date(date.Year(), 1, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:     time.Date(date.Year(), 12, 31, 0, 0, 0, 0, time.UTC),
+		})
+		if err == nil {
+			li.createdYears = append(li.createdYears, year)
+		}
+	}
+	if err != nil {
+		return month, err
+	}
+
+	startDate := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	month, err = li.q.CreateMonth(ctx, CreateMonthParams{
+		Title:       startDate.Format("January 2006"),
+		Owner:       li.owner,
+		Description: "",
+		YearID:      year.ID,
+		StartDate:   startDate,
+		EndDate:     startDate.AddDate(0, 1, -1),
+	})
+	if err != nil {
+		return month, err
+	}
+
+	li.createdMonths = append(li.createdMonths, month)
+	return month, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -167,3 +168,213 @@ func TestListLines(t *testing.T) {
 		require.Equal(t, lastLine.Owner, line.Owner)
 	}
 }
+
+func TestListExplicitLinesFiltered(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	otherAccount := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	for i := 0; i < 3; i++ {
+		createRandomLine(t, user, month, year, account, category)
+		createRandomLine(t, user, month, year, otherAccount, category)
+	}
+
+	arg := ListExplicitLinesParams{
+		Owner:     user.Username,
+		AccountID: &account.ID,
+		MonthID:   &month.ID,
+		Limit:     10,
+		Offset:    0,
+	}
+
+	lines, err := testStore.ListExplicitLines(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+
+	for _, line := range lines {
+		require.Equal(t, account.Title, line.Account)
+		require.Equal(t, month.Title, line.Month)
+		require.Equal(t, category.Title, line.Category)
+	}
+}
+
+func TestListUpcomingLines(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	for i := 0; i < 5; i++ {
+		createRandomLine(t, user, month, year, account, category)
+	}
+
+	arg := ListUpcomingLinesParams{
+		Owner:    user.Username,
+		FromDate: month.StartDate,
+		ToDate:   month.EndDate.AddDate(0, 0, 1),
+	}
+
+	lines, err := testStore.ListUpcomingLines(context.Background(), arg)
+	require.NoError(t, err)
+
+	for _, line := range lines {
+		require.Equal(t, account.Title, line.Account)
+		require.False(t, line.DueDate.Before(arg.FromDate))
+		require.True(t, line.DueDate.Before(arg.ToDate))
+	}
+}
+
+func TestListUncheckedLinesBefore(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	for i := 0; i < 5; i++ {
+		createRandomLine(t, user, month, year, account, category)
+	}
+
+	arg := ListUncheckedLinesBeforeParams{
+		Owner:  user.Username,
+		ToDate: month.EndDate.AddDate(0, 0, 1),
+	}
+
+	lines, err := testStore.ListUncheckedLinesBefore(context.Background(), arg)
+	require.NoError(t, err)
+
+	for _, line := range lines {
+		require.Equal(t, user.Username, line.Owner)
+		require.False(t, line.Checked)
+		require.True(t, line.DueDate.Before(arg.ToDate))
+	}
+}
+
+func TestSumAccountLinesBefore(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	balance := decimal.Zero
+	finalBalance := decimal.Zero
+	var first, last time.Time
+	for i := 0; i < 5; i++ {
+		line := createRandomLine(t, user, month, year, account, category)
+		if line.Checked {
+			balance = balance.Add(line.Amount)
+		}
+		finalBalance = finalBalance.Add(line.Amount)
+
+		if first.IsZero() || line.DueDate.Before(first) {
+			first = line.DueDate
+		}
+		if line.DueDate.After(last) {
+			last = line.DueDate
+		}
+	}
+
+	sums, err := testStore.SumAccountLinesBefore(context.Background(), SumAccountLinesBeforeParams{
+		AccountID: account.ID,
+		Before:    last.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.True(t, balance.Equal(sums.Balance))
+	require.True(t, finalBalance.Equal(sums.FinalBalance))
+
+	sums, err = testStore.SumAccountLinesBefore(context.Background(), SumAccountLinesBeforeParams{
+		AccountID: account.ID,
+		Before:    first,
+	})
+	require.NoError(t, err)
+	require.True(t, sums.Balance.IsZero())
+	require.True(t, sums.FinalBalance.IsZero())
+}
+
+func TestListAccountLinesBetween(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	otherAccount := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	var first, last time.Time
+	for i := 0; i < 5; i++ {
+		line := createRandomLine(t, user, month, year, account, category)
+		if first.IsZero() || line.DueDate.Before(first) {
+			first = line.DueDate
+		}
+		if line.DueDate.After(last) {
+			last = line.DueDate
+		}
+	}
+	createRandomLine(t, user, month, year, otherAccount, category)
+
+	lines, err := testStore.ListAccountLinesBetween(context.Background(), ListAccountLinesBetweenParams{
+		AccountID: account.ID,
+		FromDate:  first,
+		ToDate:    last,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, lines, 5)
+	for i, line := range lines {
+		require.Equal(t, account.Title, line.Account)
+		if i > 0 {
+			require.False(t, line.DueDate.Before(lines[i-1].DueDate))
+		}
+	}
+
+	// Lines dated after the period are left out, whatever month they belong to
+	lines, err = testStore.ListAccountLinesBetween(context.Background(), ListAccountLinesBetweenParams{
+		AccountID: account.ID,
+		FromDate:  first,
+		ToDate:    first,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, lines)
+	for _, line := range lines {
+		require.True(t, line.DueDate.Equal(first))
+	}
+}
+
+func TestListAccountHistory(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	balance := decimal.Zero
+	finalBalance := decimal.Zero
+	for i := 0; i < 5; i++ {
+		line := createRandomLine(t, user, month, year, account, category)
+		if line.Checked {
+			balance = balance.Add(line.Amount)
+		}
+		finalBalance = finalBalance.Add(line.Amount)
+	}
+
+	history, err := testStore.ListAccountHistory(context.Background(), ListAccountHistoryParams{
+		AccountID: account.ID,
+		Limit:     5,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 5)
+
+	// The latest line comes first, with the balances after every line
+	require.True(t, account.InitBalance.Add(balance).Equal(history[0].ClearedBalance))
+	require.True(t, account.InitBalance.Add(finalBalance).Equal(history[0].ProjectedBalance))
+	for i := 1; i < len(history); i++ {
+		require.False(t, history[i].DueDate.After(history[i-1].DueDate))
+		require.True(t, history[i-1].ProjectedBalance.Sub(history[i-1].Amount).Equal(history[i].ProjectedBalance))
+	}
+}
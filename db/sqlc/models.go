@@ -21,10 +21,85 @@ type Account struct {
 	FinalBalance decimal.Decimal `json:"final_balance"`
 }
 
+// end of day balances of the lines of an account, without its initial balance
+type BalanceSnapshot struct {
+	AccountID int64     `json:"account_id"`
+	Date      time.Time `json:"date"`
+	// sum of the checked lines up to the date
+	Balance decimal.Decimal `json:"balance"`
+	// sum of all lines up to the date
+	FinalBalance decimal.Decimal `json:"final_balance"`
+}
+
+type Budget struct {
+	ID         int64  `json:"id"`
+	Owner      string `json:"owner"`
+	CategoryID int64  `json:"category_id"`
+	// first month of a recurring budget, or its only month
+	MonthID int64 `json:"month_id"`
+	// recurring budgets apply to every later month until another budget of the category replaces them
+	Recurring bool            `json:"recurring"`
+	Amount    decimal.Decimal `json:"amount"`
+	CreateAt  time.Time       `json:"create_at"`
+}
+
+type BudgetAlert struct {
+	ID         int64  `json:"id"`
+	Owner      string `json:"owner"`
+	BudgetID   int64  `json:"budget_id"`
+	CategoryID int64  `json:"category_id"`
+	MonthID    int64  `json:"month_id"`
+	// percentage of the budget reached, 80 or 100
+	Threshold int32           `json:"threshold"`
+	Amount    decimal.Decimal `json:"amount"`
+	// what the month spent in the category when the threshold was reached
+	Spent    decimal.Decimal `json:"spent"`
+	Read     bool            `json:"read"`
+	CreateAt time.Time       `json:"create_at"`
+}
+
+type CalendarToken struct {
+	Owner string `json:"owner"`
+	// sha256 of the secret token used in the feed url
+	TokenHash string    `json:"token_hash"`
+	CreateAt  time.Time `json:"create_at"`
+}
+
 type Category struct {
 	ID    int64  `json:"id"`
 	Title string `json:"title"`
 	Owner string `json:"owner"`
+	// categories without parent are at the top of the tree
+	ParentID *int64 `json:"parent_id"`
+	// INCOME, EXPENSE, TRANSFER or SAVINGS
+	Kind string `json:"kind"`
+	// hexadecimal color of the category badge, empty for the default one
+	Color string `json:"color"`
+	Icon  string `json:"icon"`
+}
+
+type EnvelopeAssignment struct {
+	ID         int64  `json:"id"`
+	Owner      string `json:"owner"`
+	CategoryID int64  `json:"category_id"`
+	MonthID    int64  `json:"month_id"`
+	// income put in the envelope of the category for the month, negative to take it back
+	Amount   decimal.Decimal `json:"amount"`
+	CreateAt time.Time       `json:"create_at"`
+}
+
+type Goal struct {
+	ID        int64  `json:"id"`
+	Owner     string `json:"owner"`
+	Title     string `json:"title"`
+	AccountID int64  `json:"account_id"`
+	// category of the contributions, without it the whole account balance counts
+	CategoryID   *int64          `json:"category_id"`
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	TargetDate   time.Time       `json:"target_date"`
+	// recline planning the monthly contribution
+	ReclineID *int64    `json:"recline_id"`
+	CreateAt  time.Time `json:"create_at"`
 }
 
 type Line struct {
@@ -40,6 +115,32 @@ type Line struct {
 	Checked     bool            `json:"checked"`
 	Description string          `json:"description"`
 	DueDate     time.Time       `json:"due_date"`
+	Payee       string          `json:"payee"`
+	Tags        []string        `json:"tags"`
+}
+
+type LineAnomaly struct {
+	ID     int64  `json:"id"`
+	Owner  string `json:"owner"`
+	LineID int64  `json:"line_id"`
+	// UNUSUAL_AMOUNT, NEW_PAYEE or DUPLICATE
+	Kind   string `json:"kind"`
+	Reason string `json:"reason"`
+	// the user looked at the line, detecting it again keeps it dismissed
+	Dismissed bool      `json:"dismissed"`
+	CreateAt  time.Time `json:"create_at"`
+}
+
+type LineImport struct {
+	ID        int64           `json:"id"`
+	Owner     string          `json:"owner"`
+	AccountID int64           `json:"account_id"`
+	LineID    int64           `json:"line_id"`
+	DueDate   time.Time       `json:"due_date"`
+	Amount    decimal.Decimal `json:"amount"`
+	// bank reference, cheque number or payee used for dedupe
+	Reference string    `json:"reference"`
+	CreateAt  time.Time `json:"create_at"`
 }
 
 type Month struct {
@@ -67,6 +168,26 @@ type Recline struct {
 	DueDate     time.Time       `json:"due_date"`
 }
 
+type Rule struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	Title string `json:"title"`
+	// rules with a higher priority run first and win over the others
+	Priority int32 `json:"priority"`
+	// regular expression matched against the line title
+	MatchTitle     *string             `json:"match_title"`
+	MatchMinAmount decimal.NullDecimal `json:"match_min_amount"`
+	MatchMaxAmount decimal.NullDecimal `json:"match_max_amount"`
+	MatchAccountID *int64              `json:"match_account_id"`
+	MatchDay       *int32              `json:"match_day"`
+	SetCategoryID  *int64              `json:"set_category_id"`
+	SetPayee       *string             `json:"set_payee"`
+	SetTags        []string            `json:"set_tags"`
+	SetChecked     *bool               `json:"set_checked"`
+	SetDescription *string             `json:"set_description"`
+	CreateAt       time.Time           `json:"create_at"`
+}
+
 type Session struct {
 	ID           uuid.UUID `json:"id"`
 	Username     string    `json:"username"`
@@ -86,6 +207,12 @@ type User struct {
 	Currency          string    `json:"currency"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreateAt          time.Time `json:"create_at"`
+	// WARN or ENFORCE when a line amount does not match the kind of its category
+	CategoryKindCheck string `json:"category_kind_check"`
+	// language of the default categories, en or fr
+	Locale string `json:"locale"`
+	// budget alerts are also sent by email
+	BudgetAlertEmail bool `json:"budget_alert_email"`
 }
 
 type Year struct {
@@ -119,6 +119,35 @@ func (q *Queries) GetMonth(ctx context.Context, id int64) (Month, error) {
 	return i, err
 }
 
+const getMonthByDate = `-- name: GetMonthByDate :one
+SELECT id, owner, title, description, year_id, balance, final_balance, start_date, end_date FROM months
+WHERE owner = $1 AND start_date <= $2 AND end_date >= $2
+ORDER BY start_date DESC
+LIMIT 1
+`
+
+type GetMonthByDateParams struct {
+	Owner string    `json:"owner"`
+	Date  time.Time `json:"date"`
+}
+
+func (q *Queries) GetMonthByDate(ctx context.Context, arg GetMonthByDateParams) (Month, error) {
+	row := q.db.QueryRow(ctx, getMonthByDate, arg.Owner, arg.Date)
+	var i Month
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Title,
+		&i.Description,
+		&i.YearID,
+		&i.Balance,
+		&i.FinalBalance,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
 const getMonthForUpdate = `-- name: GetMonthForUpdate :one
 SELECT id, owner, title, description, year_id, balance, final_balance, start_date, end_date FROM months
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
@@ -6,6 +6,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,47 +16,123 @@ type Querier interface {
 	AddMonthBalance(ctx context.Context, arg AddMonthBalanceParams) (Month, error)
 	AddYearBalance(ctx context.Context, arg AddYearBalanceParams) (Year, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateBalanceSnapshots(ctx context.Context, arg CreateBalanceSnapshotsParams) (int64, error)
+	CreateBudget(ctx context.Context, arg CreateBudgetParams) (Budget, error)
+	CreateBudgetAlert(ctx context.Context, arg CreateBudgetAlertParams) (BudgetAlert, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateGoal(ctx context.Context, arg CreateGoalParams) (Goal, error)
 	CreateLine(ctx context.Context, arg CreateLineParams) (Line, error)
+	CreateLineAnomaly(ctx context.Context, arg CreateLineAnomalyParams) (LineAnomaly, error)
+	CreateLineImport(ctx context.Context, arg CreateLineImportParams) (LineImport, error)
 	CreateMonth(ctx context.Context, arg CreateMonthParams) (Month, error)
 	CreateRecLine(ctx context.Context, arg CreateRecLineParams) (Recline, error)
+	CreateRule(ctx context.Context, arg CreateRuleParams) (Rule, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateYear(ctx context.Context, arg CreateYearParams) (Year, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteBalanceSnapshotsFrom(ctx context.Context, arg DeleteBalanceSnapshotsFromParams) (int64, error)
+	DeleteBudget(ctx context.Context, id int64) error
+	DeleteCalendarToken(ctx context.Context, owner string) error
 	DeleteCategory(ctx context.Context, id int64) error
+	DeleteGoal(ctx context.Context, id int64) error
 	DeleteLine(ctx context.Context, id int64) error
 	DeleteMonth(ctx context.Context, id int64) error
 	DeleteRecLine(ctx context.Context, id int64) error
+	DeleteRule(ctx context.Context, id int64) error
+	DeleteStaleLineAnomalies(ctx context.Context, arg DeleteStaleLineAnomaliesParams) (int64, error)
 	DeleteUser(ctx context.Context, username string) error
 	DeleteYear(ctx context.Context, id int64) error
+	DismissLineAnomaly(ctx context.Context, id int64) (LineAnomaly, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByTitle(ctx context.Context, arg GetAccountByTitleParams) (Account, error)
+	GetAccountFirstLineDate(ctx context.Context, accountID int64) (time.Time, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetBudget(ctx context.Context, id int64) (Budget, error)
+	GetBudgetAlert(ctx context.Context, id int64) (BudgetAlert, error)
+	GetCalendarTokenByHash(ctx context.Context, tokenHash string) (CalendarToken, error)
 	GetCategory(ctx context.Context, id int64) (Category, error)
+	GetCategoryByTitle(ctx context.Context, arg GetCategoryByTitleParams) (Category, error)
 	GetCategoryForUpdate(ctx context.Context, id int64) (Category, error)
+	GetCategoryUsage(ctx context.Context, id int64) (GetCategoryUsageRow, error)
 	GetExpliciteLine(ctx context.Context, id int64) (GetExpliciteLineRow, error)
+	GetFirstBalanceSnapshot(ctx context.Context, accountID int64) (BalanceSnapshot, error)
+	GetGoal(ctx context.Context, id int64) (Goal, error)
+	GetLastBalanceSnapshot(ctx context.Context, accountID int64) (BalanceSnapshot, error)
 	GetLine(ctx context.Context, id int64) (Line, error)
+	GetLineAnomaly(ctx context.Context, id int64) (LineAnomaly, error)
 	GetLineForUpdate(ctx context.Context, id int64) (Line, error)
+	GetLineImport(ctx context.Context, arg GetLineImportParams) (LineImport, error)
 	GetMonth(ctx context.Context, id int64) (Month, error)
+	GetMonthByDate(ctx context.Context, arg GetMonthByDateParams) (Month, error)
 	GetMonthForUpdate(ctx context.Context, id int64) (Month, error)
 	GetRecLine(ctx context.Context, id int64) (Recline, error)
+	GetRecLineByTitle(ctx context.Context, arg GetRecLineByTitleParams) (Recline, error)
 	GetRecLineForUpdate(ctx context.Context, id int64) (Recline, error)
+	GetRule(ctx context.Context, id int64) (Rule, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetUser(ctx context.Context, username string) (User, error)
 	GetYear(ctx context.Context, id int64) (Year, error)
+	GetYearByDate(ctx context.Context, arg GetYearByDateParams) (Year, error)
 	GetYearForUpdate(ctx context.Context, id int64) (Year, error)
+	ListAccountHistory(ctx context.Context, arg ListAccountHistoryParams) ([]ListAccountHistoryRow, error)
+	ListAccountIDs(ctx context.Context, arg ListAccountIDsParams) ([]int64, error)
+	ListAccountLinesBetween(ctx context.Context, arg ListAccountLinesBetweenParams) ([]ListAccountLinesBetweenRow, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListBalanceSnapshots(ctx context.Context, arg ListBalanceSnapshotsParams) ([]BalanceSnapshot, error)
+	ListBudgetAlerts(ctx context.Context, arg ListBudgetAlertsParams) ([]BudgetAlert, error)
+	ListBudgets(ctx context.Context, arg ListBudgetsParams) ([]Budget, error)
 	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	ListCategoryAncestors(ctx context.Context, id int64) ([]int64, error)
+	ListChargeLines(ctx context.Context, owner string) ([]ListChargeLinesRow, error)
+	ListDismissedLineAnomalies(ctx context.Context, arg ListDismissedLineAnomaliesParams) ([]LineAnomaly, error)
+	ListEnvelopeAssignments(ctx context.Context, arg ListEnvelopeAssignmentsParams) ([]EnvelopeAssignment, error)
 	ListExplicitLines(ctx context.Context, arg ListExplicitLinesParams) ([]ListExplicitLinesRow, error)
+	ListExplicitRecLines(ctx context.Context, owner string) ([]ListExplicitRecLinesRow, error)
+	ListGoals(ctx context.Context, arg ListGoalsParams) ([]Goal, error)
+	ListLineAnomalies(ctx context.Context, arg ListLineAnomaliesParams) ([]ListLineAnomaliesRow, error)
 	ListLines(ctx context.Context, arg ListLinesParams) ([]Line, error)
+	ListLinesBetween(ctx context.Context, arg ListLinesBetweenParams) ([]Line, error)
+	ListMonthBudgets(ctx context.Context, arg ListMonthBudgetsParams) ([]Budget, error)
 	ListMonths(ctx context.Context, arg ListMonthsParams) ([]Month, error)
 	ListRecLines(ctx context.Context, arg ListRecLinesParams) ([]Recline, error)
+	ListRules(ctx context.Context, arg ListRulesParams) ([]Rule, error)
+	ListUncheckedLinesBefore(ctx context.Context, arg ListUncheckedLinesBeforeParams) ([]Line, error)
+	ListUpcomingLines(ctx context.Context, arg ListUpcomingLinesParams) ([]ListUpcomingLinesRow, error)
+	ListUsernames(ctx context.Context, arg ListUsernamesParams) ([]string, error)
 	ListYears(ctx context.Context, arg ListYearsParams) ([]Year, error)
+	LockCategoryTree(ctx context.Context, owner string) error
+	MarkBudgetAlertRead(ctx context.Context, id int64) (BudgetAlert, error)
+	MoveCategory(ctx context.Context, arg MoveCategoryParams) (Category, error)
+	MoveCategoryChildren(ctx context.Context, arg MoveCategoryChildrenParams) (int64, error)
+	ReassignBudgetsCategory(ctx context.Context, arg ReassignBudgetsCategoryParams) (int64, error)
+	ReassignEnvelopeAssignmentsCategory(ctx context.Context, arg ReassignEnvelopeAssignmentsCategoryParams) (int64, error)
+	ReassignGoalsCategory(ctx context.Context, arg ReassignGoalsCategoryParams) (int64, error)
+	ReassignLinesCategory(ctx context.Context, arg ReassignLinesCategoryParams) (int64, error)
+	ReassignRecLinesCategory(ctx context.Context, arg ReassignRecLinesCategoryParams) (int64, error)
+	ReassignRulesCategory(ctx context.Context, arg ReassignRulesCategoryParams) (int64, error)
+	ReportLinesByCategory(ctx context.Context, arg ReportLinesByCategoryParams) ([]ReportLinesByCategoryRow, error)
+	SetEnvelopeAssignment(ctx context.Context, arg SetEnvelopeAssignmentParams) (EnvelopeAssignment, error)
+	SumAccountLinesBefore(ctx context.Context, arg SumAccountLinesBeforeParams) (SumAccountLinesBeforeRow, error)
+	SumEnvelopeActivity(ctx context.Context, arg SumEnvelopeActivityParams) ([]SumEnvelopeActivityRow, error)
+	SumEnvelopeAssignments(ctx context.Context, arg SumEnvelopeAssignmentsParams) ([]SumEnvelopeAssignmentsRow, error)
+	SumGoalLines(ctx context.Context, arg SumGoalLinesParams) (SumGoalLinesRow, error)
+	SumLinesByAccountAndPeriod(ctx context.Context, arg SumLinesByAccountAndPeriodParams) ([]SumLinesByAccountAndPeriodRow, error)
+	SumLinesByCategory(ctx context.Context, arg SumLinesByCategoryParams) ([]SumLinesByCategoryRow, error)
+	SumLinesByTitle(ctx context.Context, arg SumLinesByTitleParams) ([]SumLinesByTitleRow, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateBudget(ctx context.Context, arg UpdateBudgetParams) (Budget, error)
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	UpdateGoal(ctx context.Context, arg UpdateGoalParams) (Goal, error)
 	UpdateLine(ctx context.Context, arg UpdateLineParams) (Line, error)
+	UpdateLineRuleFields(ctx context.Context, arg UpdateLineRuleFieldsParams) (Line, error)
 	UpdateMonth(ctx context.Context, arg UpdateMonthParams) (Month, error)
 	UpdateRecLine(ctx context.Context, arg UpdateRecLineParams) (Recline, error)
+	UpdateRule(ctx context.Context, arg UpdateRuleParams) (Rule, error)
+	UpdateUserPreferences(ctx context.Context, arg UpdateUserPreferencesParams) (User, error)
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
 	UpdateYear(ctx context.Context, arg UpdateYearParams) (Year, error)
+	UpsertCalendarToken(ctx context.Context, arg UpsertCalendarTokenParams) (CalendarToken, error)
 }
 
 var _ Querier = (*Queries)(nil)
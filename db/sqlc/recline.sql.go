@@ -95,6 +95,35 @@ func (q *Queries) GetRecLine(ctx context.Context, id int64) (Recline, error) {
 	return i, err
 }
 
+const getRecLineByTitle = `-- name: GetRecLineByTitle :one
+SELECT id, owner, title, account_id, amount, category_id, description, recurrency, due_date FROM reclines
+WHERE owner = $1 AND account_id = $2 AND title = $3
+LIMIT 1
+`
+
+type GetRecLineByTitleParams struct {
+	Owner     string `json:"owner"`
+	AccountID int64  `json:"account_id"`
+	Title     string `json:"title"`
+}
+
+func (q *Queries) GetRecLineByTitle(ctx context.Context, arg GetRecLineByTitleParams) (Recline, error) {
+	row := q.db.QueryRow(ctx, getRecLineByTitle, arg.Owner, arg.AccountID, arg.Title)
+	var i Recline
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Title,
+		&i.AccountID,
+		&i.Amount,
+		&i.CategoryID,
+		&i.Description,
+		&i.Recurrency,
+		&i.DueDate,
+	)
+	return i, err
+}
+
 const getRecLineForUpdate = `-- name: GetRecLineForUpdate :one
 SELECT id, owner, title, account_id, amount, category_id, description, recurrency, due_date FROM reclines
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
@@ -117,6 +146,54 @@ func (q *Queries) GetRecLineForUpdate(ctx context.Context, id int64) (Recline, e
 	return i, err
 }
 
+const listExplicitRecLines = `-- name: ListExplicitRecLines :many
+SELECT reclines.id, reclines.title, accounts.title as account, categories.title as category, reclines.amount, reclines.description, reclines.recurrency, reclines.due_date FROM reclines
+JOIN accounts ON accounts.id = reclines.account_id
+JOIN categories ON categories.id = reclines.category_id
+WHERE reclines.owner = $1
+ORDER BY reclines.id
+`
+
+type ListExplicitRecLinesRow struct {
+	ID          int64           `json:"id"`
+	Title       string          `json:"title"`
+	Account     string          `json:"account"`
+	Category    string          `json:"category"`
+	Amount      decimal.Decimal `json:"amount"`
+	Description string          `json:"description"`
+	Recurrency  string          `json:"recurrency"`
+	DueDate     time.Time       `json:"due_date"`
+}
+
+func (q *Queries) ListExplicitRecLines(ctx context.Context, owner string) ([]ListExplicitRecLinesRow, error) {
+	rows, err := q.db.Query(ctx, listExplicitRecLines, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListExplicitRecLinesRow{}
+	for rows.Next() {
+		var i ListExplicitRecLinesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Account,
+			&i.Category,
+			&i.Amount,
+			&i.Description,
+			&i.Recurrency,
+			&i.DueDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRecLines = `-- name: ListRecLines :many
 SELECT id, owner, title, account_id, amount, category_id, description, recurrency, due_date FROM reclines
 WHERE owner = $1
@@ -161,6 +238,25 @@ func (q *Queries) ListRecLines(ctx context.Context, arg ListRecLinesParams) ([]R
 	return items, nil
 }
 
+const reassignRecLinesCategory = `-- name: ReassignRecLinesCategory :execrows
+UPDATE reclines
+SET category_id = $1
+WHERE category_id = $2
+`
+
+type ReassignRecLinesCategoryParams struct {
+	TargetID int64 `json:"target_id"`
+	SourceID int64 `json:"source_id"`
+}
+
+func (q *Queries) ReassignRecLinesCategory(ctx context.Context, arg ReassignRecLinesCategoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignRecLinesCategory, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateRecLine = `-- name: UpdateRecLine :one
 UPDATE reclines
 SET title = $2, account_id = $3, category_id = $4, amount = $5, description = $6, recurrency = $7, due_date = $8
@@ -108,3 +108,22 @@ func TestListRecLines(t *testing.T) {
 		require.Equal(t, lastLine.Owner, line.Owner)
 	}
 }
+
+func TestListExplicitRecLines(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	category := createRandomCategory(t, user)
+
+	for i := 0; i < 3; i++ {
+		createRandomRecLine(t, user, account, category)
+	}
+
+	reclines, err := testStore.ListExplicitRecLines(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, reclines, 3)
+
+	for _, recline := range reclines {
+		require.Equal(t, account.Title, recline.Account)
+		require.Equal(t, category.Title, recline.Category)
+	}
+}
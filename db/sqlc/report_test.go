@@ -0,0 +1,346 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// createReportLine creates a line of a given amount on a given date
+func createReportLine(t *testing.T, user User, month Month, year Year, account Account, category Category, amount int64, dueDate time.Time) Line {
+	line, err := testStore.CreateLine(context.Background(), CreateLineParams{
+		Title:       util.RandomTitle(),
+		Owner:       user.Username,
+		AccountID:   account.ID,
+		MonthID:     month.ID,
+		YearID:      year.ID,
+		CategoryID:  category.ID,
+		Amount:      decimal.NewFromInt(amount),
+		DueDate:     dueDate,
+		Description: util.RandomString(14),
+	})
+	require.NoError(t, err)
+
+	return line
+}
+
+// createKindCategory creates a category of a given kind
+func createKindCategory(t *testing.T, user User, kind string) Category {
+	category, err := testStore.CreateCategory(context.Background(), CreateCategoryParams{
+		Title: util.RandomTitle(),
+		Owner: user.Username,
+		Kind:  kind,
+	})
+	require.NoError(t, err)
+
+	return category
+}
+
+func TestReportLinesByCategory(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	food := createRandomCategory(t, user)
+	rent := createRandomCategory(t, user)
+	salary := createKindCategory(t, user, util.INCOME)
+	transfer := createKindCategory(t, user, util.TRANSFER)
+
+	january := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+	createReportLine(t, user, month, year, account, food, -30, january)
+	createReportLine(t, user, month, year, account, food, -70, february)
+	createReportLine(t, user, month, year, account, rent, -300, january)
+	createReportLine(t, user, month, year, account, salary, 2000, january)
+	createReportLine(t, user, month, year, account, transfer, -500, january)
+	createReportLine(t, user, month, year, account, food, -1000, january.AddDate(1, 0, 0))
+
+	arg := ReportLinesByCategoryParams{
+		FromDate: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Owner:    user.Username,
+		ToDate:   time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Transfers are left out, the largest expense comes first
+	rows, err := testStore.ReportLinesByCategory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, rent.ID, rows[0].CategoryID)
+	require.True(t, decimal.NewFromInt(300).Equal(rows[0].Expense))
+	require.True(t, decimal.NewFromInt(75).Equal(rows[0].ExpenseShare))
+	require.Equal(t, food.ID, rows[1].CategoryID)
+	require.Equal(t, int64(2), rows[1].Count)
+	require.True(t, decimal.NewFromInt(100).Equal(rows[1].Expense))
+	require.Equal(t, salary.ID, rows[2].CategoryID)
+	require.True(t, decimal.NewFromInt(2000).Equal(rows[2].Income))
+	require.True(t, decimal.NewFromInt(100).Equal(rows[2].IncomeShare))
+
+	arg.GroupBy = ReportByMonth
+	rows, err = testStore.ReportLinesByCategory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.WithinDuration(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), rows[3].Period, time.Second)
+	require.Equal(t, food.ID, rows[3].CategoryID)
+	require.True(t, decimal.NewFromInt(100).Equal(rows[3].ExpenseShare))
+
+	otherAccount := createRandomAccount(t, user)
+	arg.AccountID = &otherAccount.ID
+	rows, err = testStore.ReportLinesByCategory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
+
+func TestPreviousPeriod(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Whole months
+	from, to := PreviousPeriod(date(2024, time.March, 1), date(2024, time.March, 31))
+	require.Equal(t, date(2024, time.February, 1), from)
+	require.Equal(t, date(2024, time.February, 29), to)
+
+	from, to = PreviousPeriod(date(2024, time.January, 1), date(2024, time.December, 31))
+	require.Equal(t, date(2023, time.January, 1), from)
+	require.Equal(t, date(2023, time.December, 31), to)
+
+	// Any other days
+	from, to = PreviousPeriod(date(2024, time.March, 10), date(2024, time.March, 19))
+	require.Equal(t, date(2024, time.February, 29), from)
+	require.Equal(t, date(2024, time.March, 9), to)
+}
+
+func TestBuildCategoryReport(t *testing.T) {
+	from := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	row := func(period time.Time, income, expense int64) ReportLinesByCategoryRow {
+		return ReportLinesByCategoryRow{
+			Period:  period,
+			Count:   1,
+			Income:  decimal.NewFromInt(income),
+			Expense: decimal.NewFromInt(expense),
+		}
+	}
+
+	report := BuildCategoryReport(from, to, ReportByMonth, []ReportLinesByCategoryRow{
+		row(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), 0, 40),
+		row(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), 1000, 0),
+		row(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), 0, 60),
+	}, nil)
+	require.True(t, decimal.NewFromInt(1000).Equal(report.Income))
+	require.True(t, decimal.NewFromInt(100).Equal(report.Expense))
+
+	// Periods are cut to the report dates
+	require.Len(t, report.Periods, 2)
+	require.Equal(t, from, report.Periods[0].From)
+	require.Equal(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), report.Periods[0].To)
+	require.Len(t, report.Periods[0].Categories, 2)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), report.Periods[1].From)
+	require.Equal(t, to, report.Periods[1].To)
+	require.True(t, decimal.NewFromInt(60).Equal(report.Periods[1].Expense))
+
+	// Without lines, an ungrouped report still covers its dates
+	report = BuildCategoryReport(from, to, "", nil, nil)
+	require.Len(t, report.Periods, 1)
+	require.Equal(t, from, report.Periods[0].From)
+	require.Equal(t, to, report.Periods[0].To)
+	require.Empty(t, report.Periods[0].Categories)
+}
+
+func TestBuildCategoryReportRollUp(t *testing.T) {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	root := Category{ID: 1, Title: "Home", Kind: util.EXPENSE}
+	child := Category{ID: 2, Title: "Energy", Kind: util.EXPENSE, ParentID: &root.ID}
+	grandChild := Category{ID: 3, Title: "Electricity", Kind: util.EXPENSE, ParentID: &child.ID}
+	row := func(category Category, expense int64) ReportLinesByCategoryRow {
+		return ReportLinesByCategoryRow{
+			Period:     from,
+			CategoryID: category.ID,
+			Category:   category.Title,
+			Count:      1,
+			Income:     decimal.Zero,
+			Expense:    decimal.NewFromInt(expense),
+		}
+	}
+
+	report := BuildCategoryReport(from, to, "", []ReportLinesByCategoryRow{
+		row(grandChild, 50),
+		row(root, 30),
+	}, []Category{root, child, grandChild})
+
+	// Lines are counted once in the period totals
+	require.True(t, decimal.NewFromInt(80).Equal(report.Expense))
+	require.True(t, decimal.NewFromInt(80).Equal(report.Periods[0].Expense))
+
+	categories := report.Periods[0].Categories
+	require.Len(t, categories, 3)
+	require.Equal(t, grandChild.ID, categories[0].CategoryID)
+	require.True(t, decimal.NewFromInt(50).Equal(categories[0].TotalExpense))
+	require.Equal(t, root.ID, categories[1].CategoryID)
+	require.True(t, decimal.NewFromInt(30).Equal(categories[1].Expense))
+	require.True(t, decimal.NewFromInt(80).Equal(categories[1].TotalExpense))
+
+	// The child has no line of its own but still totals its descendants
+	require.Equal(t, child.ID, categories[2].CategoryID)
+	require.Equal(t, root.ID, *categories[2].ParentID)
+	require.True(t, categories[2].Expense.IsZero())
+	require.True(t, decimal.NewFromInt(50).Equal(categories[2].TotalExpense))
+}
+
+func TestSumLinesByAccountAndPeriod(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	createReportLine(t, user, month, year, account, category, -30, time.Date(2023, time.December, 10, 0, 0, 0, 0, time.UTC))
+	createReportLine(t, user, month, year, account, category, -70, time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC))
+	createReportLine(t, user, month, year, account, category, 500, time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC))
+	createReportLine(t, user, month, year, account, category, 900, time.Date(2024, time.April, 3, 0, 0, 0, 0, time.UTC))
+
+	// Lines before the first period are summed in it
+	rows, err := testStore.SumLinesByAccountAndPeriod(context.Background(), SumLinesByAccountAndPeriodParams{
+		GroupBy:  NetWorthByMonth,
+		FromDate: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Owner:    user.Username,
+		ToDate:   time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, account.ID, rows[0].AccountID)
+	require.WithinDuration(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), rows[0].Period, time.Second)
+	require.True(t, decimal.NewFromInt(-100).Equal(rows[0].Amount))
+	require.WithinDuration(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), rows[1].Period, time.Second)
+	require.True(t, decimal.NewFromInt(500).Equal(rows[1].Amount))
+}
+
+func TestBuildNetWorth(t *testing.T) {
+	checking := Account{ID: 1, Title: "Checking", InitBalance: decimal.NewFromInt(100)}
+	loan := Account{ID: 2, Title: "Loan", InitBalance: decimal.NewFromInt(-1000)}
+	from := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	rows := []SumLinesByAccountAndPeriodRow{
+		{AccountID: 1, Period: from, Amount: decimal.NewFromInt(50)},
+		{AccountID: 2, Period: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(200)},
+		{AccountID: 1, Period: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(-20)},
+	}
+
+	// Months are cut to the dates of the net worth
+	netWorth := BuildNetWorth(from, to, NetWorthByMonth, []Account{checking, loan}, rows)
+	require.Len(t, netWorth.Points, 3)
+	require.Equal(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), netWorth.Points[0].Date)
+	require.True(t, decimal.NewFromInt(-850).Equal(netWorth.Points[0].NetWorth))
+	require.True(t, decimal.NewFromInt(-650).Equal(netWorth.Points[1].NetWorth))
+	require.Equal(t, to, netWorth.Points[2].Date)
+	require.True(t, decimal.NewFromInt(-670).Equal(netWorth.Points[2].NetWorth))
+	require.Len(t, netWorth.Points[2].Accounts, 2)
+	require.True(t, decimal.NewFromInt(130).Equal(netWorth.Points[2].Accounts[0].Balance))
+	require.True(t, decimal.NewFromInt(-800).Equal(netWorth.Points[2].Accounts[1].Balance))
+
+	netWorth = BuildNetWorth(from, to, NetWorthByDay, []Account{checking, loan}, rows)
+	require.Len(t, netWorth.Points, 56)
+	require.True(t, decimal.NewFromInt(-850).Equal(netWorth.Points[16].NetWorth))
+	require.True(t, decimal.NewFromInt(-650).Equal(netWorth.Points[17].NetWorth))
+}
+
+func TestSumLinesByTitle(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	otherMonth := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	transfer := createKindCategory(t, user, util.TRANSFER)
+
+	dueDate := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+	line := createReportLine(t, user, month, year, account, category, -30, dueDate)
+	_, err := testStore.CreateLine(context.Background(), CreateLineParams{
+		Title:      line.Title,
+		Owner:      user.Username,
+		AccountID:  account.ID,
+		MonthID:    month.ID,
+		YearID:     year.ID,
+		CategoryID: category.ID,
+		Amount:     decimal.NewFromInt(10),
+		DueDate:    dueDate,
+	})
+	require.NoError(t, err)
+	createReportLine(t, user, month, year, account, transfer, -500, dueDate)
+	createReportLine(t, user, otherMonth, year, account, category, -70, dueDate)
+
+	// Lines of the same title are summed, transfers are left out
+	rows, err := testStore.SumLinesByTitle(context.Background(), SumLinesByTitleParams{
+		Owner:   user.Username,
+		MonthID: &month.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, line.Title, rows[0].Title)
+	require.Equal(t, int64(2), rows[0].Count)
+	require.True(t, decimal.NewFromInt(10).Equal(rows[0].Income))
+	require.True(t, decimal.NewFromInt(30).Equal(rows[0].Expense))
+
+	rows, err = testStore.SumLinesByTitle(context.Background(), SumLinesByTitleParams{
+		Owner:  user.Username,
+		YearID: &year.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+}
+
+func TestBuildPeriodComparison(t *testing.T) {
+	row := func(categoryID int64, title string, income, expense int64) SumLinesByTitleRow {
+		return SumLinesByTitleRow{
+			CategoryID: categoryID,
+			Category:   fmt.Sprintf("category %d", categoryID),
+			Title:      title,
+			Count:      1,
+			Income:     decimal.NewFromInt(income),
+			Expense:    decimal.NewFromInt(expense),
+		}
+	}
+
+	comparison := BuildPeriodComparison(ComparedPeriod{ID: 1}, ComparedPeriod{ID: 2}, []SumLinesByTitleRow{
+		row(1, "Salary", 2000, 0),
+		row(2, "Groceries", 0, 200),
+		row(2, "Market", 0, 50),
+		row(3, "Gym", 0, 40),
+	}, []SumLinesByTitleRow{
+		row(1, "Salary", 2100, 0),
+		row(2, "Groceries", 0, 300),
+		row(2, "Market", 0, 50),
+		row(4, "Cinema", 0, 30),
+	})
+
+	require.True(t, decimal.NewFromInt(100).Equal(comparison.Income.Delta))
+	require.True(t, decimal.NewFromInt(5).Equal(comparison.Income.Percent.Decimal))
+	require.True(t, decimal.NewFromInt(90).Equal(comparison.Expense.Delta))
+	require.True(t, decimal.NewFromInt(10).Equal(comparison.Net.Delta))
+
+	// The largest change comes first
+	require.Len(t, comparison.Categories, 4)
+	require.Equal(t, int64(1), comparison.Categories[0].CategoryID)
+	require.Equal(t, int64(2), comparison.Categories[1].CategoryID)
+	require.True(t, decimal.NewFromInt(-100).Equal(comparison.Categories[1].Delta))
+	require.True(t, decimal.NewFromInt(-40).Equal(comparison.Categories[1].Percent.Decimal))
+
+	require.Len(t, comparison.New, 1)
+	require.Equal(t, int64(4), comparison.New[0].CategoryID)
+	require.Equal(t, CategoryNew, comparison.New[0].Status)
+	require.False(t, comparison.New[0].Percent.Valid)
+	require.Len(t, comparison.Disappeared, 1)
+	require.Equal(t, int64(3), comparison.Disappeared[0].CategoryID)
+	require.True(t, decimal.NewFromInt(100).Equal(comparison.Disappeared[0].Percent.Decimal))
+
+	// Unchanged lines are left out
+	require.Len(t, comparison.Lines, 4)
+	require.Equal(t, "Salary", comparison.Lines[0].Title)
+	require.Equal(t, "Groceries", comparison.Lines[1].Title)
+}
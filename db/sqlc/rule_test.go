@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomRule(t *testing.T, user User, category Category) Rule {
+	pattern := "(?i)" + util.RandomString(6)
+	arg := CreateRuleParams{
+		Owner:         user.Username,
+		Title:         util.RandomTitle(),
+		Priority:      int32(util.RandomInt(0, 10)),
+		MatchTitle:    &pattern,
+		SetCategoryID: &category.ID,
+		SetTags:       []string{util.RandomString(4)},
+	}
+
+	rule, err := testStore.CreateRule(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, rule)
+
+	require.NotZero(t, rule.ID)
+	require.Equal(t, arg.Owner, rule.Owner)
+	require.Equal(t, arg.Title, rule.Title)
+	require.Equal(t, arg.Priority, rule.Priority)
+	require.Equal(t, *arg.MatchTitle, *rule.MatchTitle)
+	require.Equal(t, *arg.SetCategoryID, *rule.SetCategoryID)
+	require.Equal(t, arg.SetTags, rule.SetTags)
+	require.False(t, rule.MatchMinAmount.Valid)
+	require.Nil(t, rule.MatchAccountID)
+	require.Nil(t, rule.SetChecked)
+
+	return rule
+}
+
+func TestCreateRule(t *testing.T) {
+	user := createRandomUser(t)
+	category := createRandomCategory(t, user)
+
+	createRandomRule(t, user, category)
+}
+
+func TestUpdateRule(t *testing.T) {
+	user := createRandomUser(t)
+	category := createRandomCategory(t, user)
+	rule := createRandomRule(t, user, category)
+
+	day := int32(12)
+	checked := true
+	updated, err := testStore.UpdateRule(context.Background(), UpdateRuleParams{
+		ID:         rule.ID,
+		Title:      rule.Title,
+		Priority:   rule.Priority + 1,
+		MatchDay:   &day,
+		SetChecked: &checked,
+	})
+	require.NoError(t, err)
+	require.Equal(t, rule.Priority+1, updated.Priority)
+	require.Nil(t, updated.MatchTitle)
+	require.Nil(t, updated.SetCategoryID)
+	require.Nil(t, updated.SetTags)
+	require.Equal(t, day, *updated.MatchDay)
+	require.True(t, *updated.SetChecked)
+}
+
+func TestDeleteRule(t *testing.T) {
+	user := createRandomUser(t)
+	category := createRandomCategory(t, user)
+	rule := createRandomRule(t, user, category)
+
+	err := testStore.DeleteRule(context.Background(), rule.ID)
+	require.NoError(t, err)
+
+	_, err = testStore.GetRule(context.Background(), rule.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestListRules(t *testing.T) {
+	user := createRandomUser(t)
+	category := createRandomCategory(t, user)
+
+	for i := 0; i < 5; i++ {
+		createRandomRule(t, user, category)
+	}
+
+	rules, err := testStore.ListRules(context.Background(), ListRulesParams{
+		Owner:  user.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, rules, 5)
+
+	// Higher priorities come first
+	for i := 1; i < len(rules); i++ {
+		require.GreaterOrEqual(t, rules[i-1].Priority, rules[i].Priority)
+	}
+}
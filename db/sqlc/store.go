@@ -5,13 +5,38 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/moth13/finance_tracker/forecast"
 )
 
 // Store provides all function to execute db queries and transactions
 type Store interface {
 	Querier
 	AddLineTx(ctx context.Context, arg AddLineTxParams) (AddLineTxResult, error)
+	BackupTx(ctx context.Context, owner string) (Backup, error)
+	BalanceHistoryTx(ctx context.Context, arg BalanceHistoryTxParams) ([]BalanceSnapshot, error)
+	CategoryReportTx(ctx context.Context, arg CategoryReportTxParams) (CategoryReport, error)
+	CategoryTreeTx(ctx context.Context, arg CategoryTreeTxParams) ([]*CategoryNode, error)
+	CompareMonthsTx(ctx context.Context, arg CompareMonthsTxParams) (PeriodComparison, error)
+	CompareYearsTx(ctx context.Context, arg CompareYearsTxParams) (PeriodComparison, error)
+	CreateGoalTx(ctx context.Context, arg CreateGoalTxParams) (CreateGoalTxResult, error)
+	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	DeleteCategoryTx(ctx context.Context, arg DeleteCategoryTxParams) (MergeCategoryTxResult, error)
 	DeleteLineTx(ctx context.Context, arg DeleteLineTxParams) (DeleteLineTxResult, error)
+	DetectAnomaliesTx(ctx context.Context, arg DetectAnomaliesTxParams) (DetectAnomaliesTxResult, error)
+	ForecastTx(ctx context.Context, arg ForecastTxParams) (forecast.Forecast, error)
+	GoalProgressTx(ctx context.Context, arg GoalProgressTxParams) (GoalProgress, error)
+	ImportBookTx(ctx context.Context, arg ImportBookTxParams) (ImportBookTxResult, error)
+	ImportLinesTx(ctx context.Context, arg ImportLinesTxParams) (ImportLinesTxResult, error)
+	ListGoalProgressTx(ctx context.Context, arg ListGoalProgressTxParams) ([]GoalProgress, error)
+	MergeCategoryTx(ctx context.Context, arg MergeCategoryTxParams) (MergeCategoryTxResult, error)
+	MonthBudgetTx(ctx context.Context, arg MonthBudgetTxParams) (MonthBudget, error)
+	MonthEnvelopesTx(ctx context.Context, arg MonthEnvelopesTxParams) (MonthEnvelopes, error)
+	MoveCategoryTx(ctx context.Context, arg MoveCategoryTxParams) (Category, error)
+	NetWorthTx(ctx context.Context, arg NetWorthTxParams) (NetWorth, error)
+	RefreshBalanceSnapshotsTx(ctx context.Context, arg RefreshBalanceSnapshotsTxParams) (RefreshBalanceSnapshotsTxResult, error)
+	RestoreBackupTx(ctx context.Context, arg RestoreBackupTxParams) (RestoreBackupTxResult, error)
+	RunRulesTx(ctx context.Context, arg RunRulesTxParams) (RunRulesTxResult, error)
+	SeedCategoriesTx(ctx context.Context, arg SeedCategoriesTxParams) (SeedCategoriesTxResult, error)
 	UpdateLineTx(ctx context.Context, arg UpdateLineTxParams) (UpdateLineTxResult, error)
 }
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/anomaly"
 	"github.com/moth13/finance_tracker/util"
 	decimal "github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
@@ -191,6 +192,7 @@ func TestDeleteLineTx(t *testing.T) {
 
 		line := <-del_line
 		require.NotEmpty(t, line)
+		require.Equal(t, line.ID, result.Line.ID)
 
 		// Check Line deletion
 		deletedLine, err := testStore.GetLine(context.Background(), line.ID)
@@ -313,6 +315,8 @@ func TestUpdateLineTx(t *testing.T) {
 
 		line := <-up_line
 		require.NotEmpty(t, line)
+		require.Equal(t, line.ID, result.Previous.ID)
+		require.True(t, line.Amount.Equal(result.Previous.Amount))
 
 		// Check Line update
 		updatedLine, err := testStore.GetLine(context.Background(), line.ID)
@@ -346,3 +350,1258 @@ func TestUpdateLineTx(t *testing.T) {
 	require.True(t, updateYear.Balance.Equal(year.Balance.Add(line_balance)))
 	require.True(t, updateYear.FinalBalance.Equal(year.FinalBalance.Add(line_final_balance)))
 }
+
+func TestImportLinesTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	categoryTitle := util.RandomTitle()
+	arg := ImportLinesTxParams{
+		Owner:      user.Username,
+		AccountID:  account.ID,
+		CategoryID: category.ID,
+		Lines: []ImportLineParams{
+			{
+				Title:     util.RandomTitle(),
+				Amount:    util.RandomMoney(),
+				Checked:   true,
+				DueDate:   month.StartDate,
+				Category:  categoryTitle,
+				Reference: util.RandomString(8),
+			},
+			{
+				Title:     util.RandomTitle(),
+				Amount:    util.RandomMoney(),
+				DueDate:   month.StartDate,
+				Reference: util.RandomString(8),
+			},
+		},
+	}
+
+	result, err := testStore.ImportLinesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Lines, 2)
+	require.Zero(t, result.Skipped)
+
+	require.Equal(t, month.ID, result.Lines[0].MonthID)
+	require.Equal(t, year.ID, result.Lines[0].YearID)
+	require.NotEqual(t, category.ID, result.Lines[0].CategoryID)
+	require.Equal(t, category.ID, result.Lines[1].CategoryID)
+
+	importedCategory, err := testStore.GetCategory(context.Background(), result.Lines[0].CategoryID)
+	require.NoError(t, err)
+	require.Equal(t, categoryTitle, importedCategory.Title)
+
+	total := arg.Lines[0].Amount.Add(arg.Lines[1].Amount)
+	require.True(t, result.Balance.AccountFinalBalance.Equal(total))
+	require.True(t, result.Balance.AccountBalance.Equal(arg.Lines[0].Amount))
+
+	// Importing the same file again doesn't create any line
+	result, err = testStore.ImportLinesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, result.Lines)
+	require.Equal(t, 2, result.Skipped)
+}
+
+func TestImportBookTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	accountTitle := util.RandomTitle()
+	initBalance := util.RandomMoney()
+	dueDate := time.Date(1990+int(util.RandomInt(0, 20)), 3, 14, 0, 0, 0, 0, time.UTC)
+	arg := ImportBookTxParams{
+		Owner:  user.Username,
+		DryRun: true,
+		Accounts: []ImportAccountParams{
+			{Title: accountTitle, InitBalance: initBalance},
+		},
+		Lines: []ImportLineParams{
+			{
+				Account:   accountTitle,
+				Title:     util.RandomTitle(),
+				Amount:    util.RandomMoney(),
+				Checked:   true,
+				DueDate:   dueDate,
+				Category:  util.RandomTitle(),
+				Reference: util.RandomString(8),
+			},
+		},
+		RecLines: []ImportRecLineParams{
+			{
+				Account:    accountTitle,
+				Title:      util.RandomTitle(),
+				Amount:     util.RandomMoney(),
+				Recurrency: util.MONTHLY,
+				DueDate:    dueDate,
+			},
+		},
+	}
+
+	// A dry run reports without writing anything
+	report, err := testStore.ImportBookTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Len(t, report.Accounts, 1)
+	require.Len(t, report.Years, 1)
+	require.Len(t, report.Months, 1)
+	require.Len(t, report.RecLines, 1)
+	require.Equal(t, 1, report.Lines)
+
+	_, err = testStore.GetAccountByTitle(context.Background(), GetAccountByTitleParams{Owner: user.Username, Title: accountTitle})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	arg.DryRun = false
+	result, err := testStore.ImportBookTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Lines)
+
+	account, err := testStore.GetAccount(context.Background(), result.Accounts[0].ID)
+	require.NoError(t, err)
+	require.True(t, account.InitBalance.Equal(initBalance))
+	require.True(t, account.Balance.Equal(arg.Lines[0].Amount))
+
+	month, err := testStore.GetMonthByDate(context.Background(), GetMonthByDateParams{Owner: user.Username, Date: dueDate})
+	require.NoError(t, err)
+	require.Equal(t, result.Months[0].ID, month.ID)
+	require.Equal(t, result.Years[0].ID, month.YearID)
+}
+
+func TestBackupRestoreTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	recline := createRandomRecLine(t, user, account, category)
+	budget := createRandomBudget(t, user, category, month, true)
+	envelope := assignRandomEnvelope(t, user, category, month)
+	goal := createRandomGoal(t, user, account, &category.ID)
+
+	// Children are listed before their parent once moved, restore must still create parents first
+	parent := createRandomCategory(t, user)
+	_, err := testStore.MoveCategoryTx(context.Background(), MoveCategoryTxParams{
+		ID:       category.ID,
+		ParentID: &parent.ID,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := testStore.AddLineTx(context.Background(), AddLineTxParams{
+			Title:      util.RandomTitle(),
+			Owner:      user.Username,
+			Amount:     util.RandomMoney(),
+			Checked:    i%2 == 0,
+			DueDate:    month.StartDate,
+			AccountID:  account.ID,
+			MonthID:    month.ID,
+			YearID:     year.ID,
+			CategoryID: category.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	account, err = testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+
+	alertEmail, kindCheck := true, util.ENFORCE
+	_, err = testStore.UpdateUserPreferences(context.Background(), UpdateUserPreferencesParams{
+		Username:          user.Username,
+		CategoryKindCheck: &kindCheck,
+		BudgetAlertEmail:  &alertEmail,
+	})
+	require.NoError(t, err)
+
+	// Only the dismissed anomaly is backed up
+	lines, err := testStore.ListLines(context.Background(), ListLinesParams{Owner: user.Username, Limit: 1})
+	require.NoError(t, err)
+	dismissed := createRandomLineAnomaly(t, user, lines[0], anomaly.KindDuplicate)
+	_, err = testStore.DismissLineAnomaly(context.Background(), dismissed.ID)
+	require.NoError(t, err)
+	createRandomLineAnomaly(t, user, lines[0], anomaly.KindNewPayee)
+
+	backup, err := testStore.BackupTx(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, BackupVersion, backup.Version)
+	require.Equal(t, user.Email, backup.User.Email)
+	require.Len(t, backup.Accounts, 1)
+	require.Len(t, backup.Years, 1)
+	require.Len(t, backup.Months, 1)
+	require.Len(t, backup.Categories, 2)
+	require.Len(t, backup.Lines, 3)
+	require.Len(t, backup.RecLines, 1)
+	require.Equal(t, recline.Title, backup.RecLines[0].Title)
+	require.Len(t, backup.Budgets, 1)
+	require.Len(t, backup.Envelopes, 1)
+	require.Len(t, backup.Goals, 1)
+	require.Len(t, backup.Anomalies, 1)
+	require.Equal(t, dismissed.ID, backup.Anomalies[0].ID)
+
+	// Restore under another user
+	target := createRandomUser(t)
+	result, err := testStore.RestoreBackupTx(context.Background(), RestoreBackupTxParams{
+		Owner:  target.Username,
+		Backup: backup,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.FullName, result.User.FullName)
+	require.Equal(t, user.Locale, result.User.Locale)
+	require.Equal(t, util.ENFORCE, result.User.CategoryKindCheck)
+	require.True(t, result.User.BudgetAlertEmail)
+	require.Equal(t, 1, result.Accounts)
+	require.Equal(t, 3, result.Lines)
+	require.Equal(t, 1, result.RecLines)
+	require.Equal(t, 1, result.Budgets)
+	require.Equal(t, 1, result.Envelopes)
+	require.Equal(t, 1, result.Goals)
+	require.Equal(t, 1, result.Anomalies)
+
+	restored, err := testStore.BackupTx(context.Background(), target.Username)
+	require.NoError(t, err)
+	require.Len(t, restored.Accounts, 1)
+	require.NotEqual(t, account.ID, restored.Accounts[0].ID)
+	require.Equal(t, target.Username, restored.Accounts[0].Owner)
+	require.True(t, account.Balance.Equal(restored.Accounts[0].Balance))
+	require.True(t, account.FinalBalance.Equal(restored.Accounts[0].FinalBalance))
+	require.Equal(t, restored.Years[0].ID, restored.Months[0].YearID)
+	require.Len(t, restored.Categories, 2)
+	restoredParent, restoredChild := restored.Categories[0], restored.Categories[1]
+	require.Nil(t, restoredParent.ParentID)
+	require.Equal(t, restoredParent.ID, *restoredChild.ParentID)
+	for _, line := range restored.Lines {
+		require.Equal(t, restored.Accounts[0].ID, line.AccountID)
+		require.Equal(t, restoredChild.ID, line.CategoryID)
+	}
+	require.Len(t, restored.Budgets, 1)
+	require.Equal(t, restoredChild.ID, restored.Budgets[0].CategoryID)
+	require.Equal(t, restored.Months[0].ID, restored.Budgets[0].MonthID)
+	require.Equal(t, budget.Recurring, restored.Budgets[0].Recurring)
+	require.True(t, budget.Amount.Equal(restored.Budgets[0].Amount))
+	require.Len(t, restored.Envelopes, 1)
+	require.Equal(t, restoredChild.ID, restored.Envelopes[0].CategoryID)
+	require.Equal(t, restored.Months[0].ID, restored.Envelopes[0].MonthID)
+	require.True(t, envelope.Amount.Equal(restored.Envelopes[0].Amount))
+	require.Len(t, restored.Goals, 1)
+	require.Equal(t, goal.Title, restored.Goals[0].Title)
+	require.Equal(t, restored.Accounts[0].ID, restored.Goals[0].AccountID)
+	require.Equal(t, restoredChild.ID, *restored.Goals[0].CategoryID)
+	require.Len(t, restored.Anomalies, 1)
+	require.Equal(t, anomaly.KindDuplicate, restored.Anomalies[0].Kind)
+	require.NotEqual(t, lines[0].ID, restored.Anomalies[0].LineID)
+	restoredLine, err := testStore.GetLine(context.Background(), restored.Anomalies[0].LineID)
+	require.NoError(t, err)
+	require.Equal(t, target.Username, restoredLine.Owner)
+
+	// The target now owns accounts, a second restore is refused
+	_, err = testStore.RestoreBackupTx(context.Background(), RestoreBackupTxParams{
+		Owner:  target.Username,
+		Backup: backup,
+	})
+	require.ErrorIs(t, err, ErrRestoreTargetNotEmpty)
+
+	// Version 1 backups have no budgets and are still restored
+	legacy := backup
+	legacy.Version = 1
+	legacy.Budgets = nil
+	legacy.Envelopes = nil
+	legacy.Goals = nil
+	result, err = testStore.RestoreBackupTx(context.Background(), RestoreBackupTxParams{
+		Owner:  createRandomUser(t).Username,
+		Backup: legacy,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Lines)
+	require.Zero(t, result.Budgets)
+
+	backup.Version = BackupVersion + 1
+	_, err = testStore.RestoreBackupTx(context.Background(), RestoreBackupTxParams{
+		Owner:  createRandomUser(t).Username,
+		Backup: backup,
+	})
+	require.ErrorIs(t, err, ErrUnsupportedBackupVersion)
+}
+
+func TestRunRulesTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	coffee := createRandomCategory(t, user)
+	ctx := context.Background()
+
+	arg := AddLineTxParams{
+		Owner:       user.Username,
+		Title:       "Coffee shop " + util.RandomString(6),
+		Description: util.RandomString(14),
+		Checked:     false,
+		Amount:      decimal.RequireFromString("-3.5"),
+		AccountID:   account.ID,
+		MonthID:     month.ID,
+		YearID:      year.ID,
+		CategoryID:  category.ID,
+		DueDate:     time.Now(),
+	}
+	added, err := testStore.AddLineTx(ctx, arg)
+	require.NoError(t, err)
+	require.Empty(t, added.Rules)
+
+	pattern := "(?i)^coffee"
+	payee := "Coffee shop"
+	checked := true
+	rule, err := testStore.CreateRule(ctx, CreateRuleParams{
+		Owner:         user.Username,
+		Title:         "Coffee",
+		MatchTitle:    &pattern,
+		SetCategoryID: &coffee.ID,
+		SetPayee:      &payee,
+		SetTags:       []string{"coffee"},
+		SetChecked:    &checked,
+	})
+	require.NoError(t, err)
+
+	// A dry run reports the change without writing it
+	result, err := testStore.RunRulesTx(ctx, RunRulesTxParams{Owner: user.Username, DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Lines)
+	require.Len(t, result.Changes, 1)
+	change := result.Changes[0]
+	require.Equal(t, added.Line.ID, change.LineID)
+	require.Equal(t, []int64{rule.ID}, change.Rules)
+	require.Equal(t, category.ID, change.Before.CategoryID)
+	require.Equal(t, coffee.ID, change.After.CategoryID)
+	require.Equal(t, []string{"coffee"}, change.After.Tags)
+
+	line, err := testStore.GetLine(ctx, added.Line.ID)
+	require.NoError(t, err)
+	require.Equal(t, category.ID, line.CategoryID)
+	require.False(t, line.Checked)
+
+	result, err = testStore.RunRulesTx(ctx, RunRulesTxParams{Owner: user.Username})
+	require.NoError(t, err)
+	require.Len(t, result.Changes, 1)
+
+	line, err = testStore.GetLine(ctx, added.Line.ID)
+	require.NoError(t, err)
+	require.Equal(t, coffee.ID, line.CategoryID)
+	require.Equal(t, payee, line.Payee)
+	require.Equal(t, []string{"coffee"}, line.Tags)
+	require.True(t, line.Checked)
+
+	// Checking the line moved it into the balance
+	updated, err := testStore.GetAccount(ctx, account.ID)
+	require.NoError(t, err)
+	require.True(t, updated.Balance.Equal(account.Balance.Add(arg.Amount)))
+
+	// Running again changes nothing
+	result, err = testStore.RunRulesTx(ctx, RunRulesTxParams{Owner: user.Username})
+	require.NoError(t, err)
+	require.Empty(t, result.Changes)
+
+	// New lines go through the rules
+	added, err = testStore.AddLineTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, []int64{rule.ID}, added.Rules)
+	require.Equal(t, coffee.ID, added.Line.CategoryID)
+	require.Equal(t, payee, added.Line.Payee)
+	require.True(t, added.Line.Checked)
+}
+
+func TestMoveCategoryTx(t *testing.T) {
+	user := createRandomUser(t)
+	root := createRandomCategory(t, user)
+	child := createRandomChildCategory(t, user, root)
+	other := createRandomCategory(t, user)
+
+	moved, err := testStore.MoveCategoryTx(context.Background(), MoveCategoryTxParams{
+		ID:       root.ID,
+		ParentID: &other.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, other.ID, *moved.ParentID)
+
+	// A category can't go under itself or its descendants
+	_, err = testStore.MoveCategoryTx(context.Background(), MoveCategoryTxParams{
+		ID:       root.ID,
+		ParentID: &root.ID,
+	})
+	require.ErrorIs(t, err, ErrCategoryCycle)
+
+	_, err = testStore.MoveCategoryTx(context.Background(), MoveCategoryTxParams{
+		ID:       other.ID,
+		ParentID: &child.ID,
+	})
+	require.ErrorIs(t, err, ErrCategoryCycle)
+
+	moved, err = testStore.MoveCategoryTx(context.Background(), MoveCategoryTxParams{ID: root.ID})
+	require.NoError(t, err)
+	require.Nil(t, moved.ParentID)
+}
+
+func TestMoveCategoryTxConcurrent(t *testing.T) {
+	user := createRandomUser(t)
+	category1 := createRandomCategory(t, user)
+	category2 := createRandomCategory(t, user)
+
+	// Moving each category under the other at the same time must let only one move pass
+	errs := make(chan error)
+	go func() {
+		_, err := testStore.MoveCategoryTx(context.Background(), MoveCategoryTxParams{
+			ID:       category1.ID,
+			ParentID: &category2.ID,
+		})
+		errs <- err
+	}()
+	go func() {
+		_, err := testStore.MoveCategoryTx(context.Background(), MoveCategoryTxParams{
+			ID:       category2.ID,
+			ParentID: &category1.ID,
+		})
+		errs <- err
+	}()
+
+	cycles := 0
+	for i := 0; i < 2; i++ {
+		err := <-errs
+		if err != nil {
+			require.ErrorIs(t, err, ErrCategoryCycle)
+			cycles++
+		}
+	}
+	require.Equal(t, 1, cycles)
+}
+
+func TestCategoryTreeTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	root := createRandomCategory(t, user)
+	child := createRandomChildCategory(t, user, root)
+
+	rootLine := createRandomLine(t, user, month, year, account, root)
+	childLine := createRandomLine(t, user, month, year, account, child)
+
+	tree, err := testStore.CategoryTreeTx(context.Background(), CategoryTreeTxParams{
+		Owner:   user.Username,
+		MonthID: &month.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	require.Equal(t, root.ID, tree[0].ID)
+	require.True(t, rootLine.Amount.Equal(tree[0].Amount))
+	require.True(t, rootLine.Amount.Add(childLine.Amount).Equal(tree[0].Total))
+	require.Len(t, tree[0].Children, 1)
+	require.Equal(t, child.ID, tree[0].Children[0].ID)
+	require.True(t, childLine.Amount.Equal(tree[0].Children[0].Total))
+}
+
+func TestImportLinesTxCategoryPath(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	parentTitle := util.RandomTitle()
+	childTitle := util.RandomTitle()
+	arg := ImportLinesTxParams{
+		Owner:      user.Username,
+		AccountID:  account.ID,
+		CategoryID: category.ID,
+	}
+	for i := 0; i < 2; i++ {
+		arg.Lines = append(arg.Lines, ImportLineParams{
+			Title:     util.RandomTitle(),
+			Amount:    util.RandomMoney(),
+			DueDate:   month.StartDate,
+			Category:  parentTitle + ":" + childTitle,
+			Reference: util.RandomString(8),
+		})
+	}
+
+	result, err := testStore.ImportLinesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Lines, 2)
+	require.Equal(t, result.Lines[0].CategoryID, result.Lines[1].CategoryID)
+
+	child, err := testStore.GetCategory(context.Background(), result.Lines[0].CategoryID)
+	require.NoError(t, err)
+	require.Equal(t, childTitle, child.Title)
+	require.NotNil(t, child.ParentID)
+
+	parent, err := testStore.GetCategory(context.Background(), *child.ParentID)
+	require.NoError(t, err)
+	require.Equal(t, parentTitle, parent.Title)
+	require.Nil(t, parent.ParentID)
+}
+
+func TestMergeCategoryTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	source := createRandomCategory(t, user)
+	child := createRandomChildCategory(t, user, source)
+	target := createRandomCategory(t, user)
+
+	line := createRandomLine(t, user, month, year, account, source)
+	recline := createRandomRecLine(t, user, account, source)
+	rule := createRandomRule(t, user, source)
+	goal := createRandomGoal(t, user, account, &source.ID)
+	sourceBudget := createRandomBudget(t, user, source, month, false)
+	targetBudget := createRandomBudget(t, user, target, month, false)
+	recurringBudget := createRandomBudget(t, user, source, month, true)
+	sourceEnvelope := assignRandomEnvelope(t, user, source, month)
+	targetEnvelope := assignRandomEnvelope(t, user, target, month)
+
+	// The target can't be below the source
+	_, err := testStore.MergeCategoryTx(context.Background(), MergeCategoryTxParams{
+		SourceID: source.ID,
+		TargetID: child.ID,
+	})
+	require.ErrorIs(t, err, ErrCategoryCycle)
+
+	result, err := testStore.MergeCategoryTx(context.Background(), MergeCategoryTxParams{
+		SourceID: source.ID,
+		TargetID: target.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, target.ID, result.Category.ID)
+	require.Equal(t, int64(1), result.Lines)
+	require.Equal(t, int64(1), result.RecLines)
+	require.Equal(t, int64(1), result.Rules)
+	require.Equal(t, int64(1), result.Goals)
+	require.Equal(t, int64(2), result.Budgets)
+	require.Equal(t, int64(1), result.Envelopes)
+	require.Equal(t, int64(1), result.Children)
+
+	line, err = testStore.GetLine(context.Background(), line.ID)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, line.CategoryID)
+
+	recline, err = testStore.GetRecLine(context.Background(), recline.ID)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, recline.CategoryID)
+
+	rule, err = testStore.GetRule(context.Background(), rule.ID)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, *rule.SetCategoryID)
+
+	goal, err = testStore.GetGoal(context.Background(), goal.ID)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, *goal.CategoryID)
+
+	// The budgets of the same month are added together
+	merged, err := testStore.GetBudget(context.Background(), targetBudget.ID)
+	require.NoError(t, err)
+	require.True(t, sourceBudget.Amount.Add(targetBudget.Amount).Equal(merged.Amount))
+
+	budgets, err := testStore.ListMonthBudgets(context.Background(), ListMonthBudgetsParams{
+		Owner:   user.Username,
+		MonthID: month.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, budgets, 1)
+	require.Equal(t, target.ID, budgets[0].CategoryID)
+
+	_, err = testStore.GetBudget(context.Background(), recurringBudget.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// The assigned amounts of the same month are added together
+	envelopes, err := testStore.SumEnvelopeAssignments(context.Background(), SumEnvelopeAssignmentsParams{
+		Owner:   user.Username,
+		MonthID: month.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, envelopes, 1)
+	require.Equal(t, target.ID, envelopes[0].CategoryID)
+	require.True(t, sourceEnvelope.Amount.Add(targetEnvelope.Amount).Equal(envelopes[0].Amount))
+
+	child, err = testStore.GetCategory(context.Background(), child.ID)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, *child.ParentID)
+
+	_, err = testStore.GetCategory(context.Background(), source.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestDeleteCategoryTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	root := createRandomCategory(t, user)
+	used := createRandomChildCategory(t, user, root)
+	child := createRandomChildCategory(t, user, used)
+	replacement := createRandomCategory(t, user)
+
+	line := createRandomLine(t, user, month, year, account, used)
+
+	// A category in use needs a replacement
+	_, err := testStore.DeleteCategoryTx(context.Background(), DeleteCategoryTxParams{ID: used.ID})
+	require.ErrorIs(t, err, ErrCategoryInUse)
+
+	result, err := testStore.DeleteCategoryTx(context.Background(), DeleteCategoryTxParams{
+		ID:            used.ID,
+		ReplacementID: &replacement.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Lines)
+
+	line, err = testStore.GetLine(context.Background(), line.ID)
+	require.NoError(t, err)
+	require.Equal(t, replacement.ID, line.CategoryID)
+
+	// The children of an unused category go up to its parent
+	unused := createRandomChildCategory(t, user, root)
+	grandChild := createRandomChildCategory(t, user, unused)
+
+	result, err = testStore.DeleteCategoryTx(context.Background(), DeleteCategoryTxParams{ID: unused.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Children)
+
+	grandChild, err = testStore.GetCategory(context.Background(), grandChild.ID)
+	require.NoError(t, err)
+	require.Equal(t, root.ID, *grandChild.ParentID)
+
+	_, err = testStore.GetCategory(context.Background(), unused.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	child, err = testStore.GetCategory(context.Background(), child.ID)
+	require.NoError(t, err)
+	require.Equal(t, replacement.ID, *child.ParentID)
+}
+
+func TestAddLineTxCategoryKind(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	arg := AddLineTxParams{
+		Title:      util.RandomTitle(),
+		Owner:      user.Username,
+		Amount:     decimal.NewFromInt(1200),
+		DueDate:    time.Now(),
+		AccountID:  account.ID,
+		MonthID:    month.ID,
+		YearID:     year.ID,
+		CategoryID: category.ID,
+	}
+
+	// An income in an expense category is only a warning by default
+	result, err := testStore.AddLineTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 1)
+
+	check := util.ENFORCE
+	_, err = testStore.UpdateUserPreferences(context.Background(), UpdateUserPreferencesParams{
+		Username:          user.Username,
+		CategoryKindCheck: &check,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.AddLineTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrCategoryKindMismatch)
+
+	// Updates are checked too
+	_, err = testStore.UpdateLineTx(context.Background(), UpdateLineTxParams{
+		ID:     result.Line.ID,
+		Amount: decimal.NewNullDecimal(decimal.NewFromInt(1500)),
+	})
+	require.ErrorIs(t, err, ErrCategoryKindMismatch)
+
+	arg.Amount = decimal.NewFromInt(-35)
+	result, err = testStore.AddLineTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, result.Warnings)
+}
+
+func TestCreateUserTx(t *testing.T) {
+	arg := CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomUsername(),
+			HashedPassword: "secret",
+			FullName:       util.RandomFullName(),
+			Email:          util.RandomEmail(),
+			Currency:       util.EUR,
+			Locale:         util.FR,
+		},
+	}
+
+	result, err := testStore.CreateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, result.User.Username)
+	require.NotEmpty(t, result.Categories)
+
+	// The french tree has a top level income category with children of the same kind
+	tree, err := testStore.CategoryTreeTx(context.Background(), CategoryTreeTxParams{Owner: arg.Username})
+	require.NoError(t, err)
+	require.Len(t, tree, len(DefaultCategories(util.FR)))
+	require.Equal(t, "Revenus", tree[0].Title)
+	require.Equal(t, util.INCOME, tree[0].Kind)
+	require.NotEmpty(t, tree[0].Children)
+	require.Equal(t, util.INCOME, tree[0].Children[0].Kind)
+
+	// Users can opt out
+	arg.Username = util.RandomUsername()
+	arg.Email = util.RandomEmail()
+	arg.SkipDefaultCategories = true
+
+	result, err = testStore.CreateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, result.Categories)
+}
+
+func TestSeedCategoriesTx(t *testing.T) {
+	user := createRandomUser(t)
+	arg := SeedCategoriesTxParams{
+		Owner:  user.Username,
+		Locale: util.EN,
+	}
+
+	result, err := testStore.SeedCategoriesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Created)
+	require.Empty(t, result.Restored)
+	income := result.Created[0]
+	require.Equal(t, "Income", income.Title)
+
+	// Applying the defaults again only creates what was deleted
+	child := result.Created[1]
+	require.NoError(t, testStore.DeleteCategory(context.Background(), child.ID))
+
+	result, err = testStore.SeedCategoriesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Created, 1)
+	require.Equal(t, child.Title, result.Created[0].Title)
+
+	// A reset restores the look of the default categories
+	_, err = testStore.UpdateCategory(context.Background(), UpdateCategoryParams{
+		ID:    income.ID,
+		Title: income.Title,
+		Kind:  util.TRANSFER,
+	})
+	require.NoError(t, err)
+
+	arg.Reset = true
+	result, err = testStore.SeedCategoriesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, result.Created)
+	require.Len(t, result.Restored, 1)
+	require.Equal(t, income.ID, result.Restored[0].ID)
+	require.Equal(t, income.Kind, result.Restored[0].Kind)
+	require.Equal(t, income.Color, result.Restored[0].Color)
+}
+
+func TestMonthBudgetTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	root := createRandomCategory(t, user)
+	child := createRandomChildCategory(t, user, root)
+	budget := createRandomBudget(t, user, root, month, false)
+
+	rootLine := createRandomLine(t, user, month, year, account, root)
+	childLine := createRandomLine(t, user, month, year, account, child)
+
+	projected := rootLine.Amount.Add(childLine.Amount).Neg()
+	spent := decimal.Zero
+	for _, line := range []Line{rootLine, childLine} {
+		if line.Checked {
+			spent = spent.Sub(line.Amount)
+		}
+	}
+
+	report, err := testStore.MonthBudgetTx(context.Background(), MonthBudgetTxParams{
+		Owner:   user.Username,
+		MonthID: month.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, month.ID, report.MonthID)
+	require.Len(t, report.Budgets, 1)
+	require.Equal(t, budget, report.Budgets[0].Budget)
+	require.ElementsMatch(t, []int64{root.ID, child.ID}, report.Budgets[0].CategoryIDs)
+	require.True(t, projected.Equal(report.Budgets[0].Projected))
+	require.True(t, spent.Equal(report.Budgets[0].Spent))
+	require.True(t, budget.Amount.Sub(spent).Equal(report.Budgets[0].Remaining))
+	require.True(t, budget.Amount.Equal(report.Budgeted))
+}
+
+func TestMonthEnvelopesTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	january := createMonthStarting(t, user, year, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	february := createMonthStarting(t, user, year, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC))
+	category := createRandomCategory(t, user)
+
+	januaryAssignment := assignRandomEnvelope(t, user, category, january)
+	februaryAssignment := assignRandomEnvelope(t, user, category, february)
+	januaryLine := createRandomLine(t, user, january, year, account, category)
+	februaryLine := createRandomLine(t, user, february, year, account, category)
+
+	envelopes, err := testStore.MonthEnvelopesTx(context.Background(), MonthEnvelopesTxParams{
+		Owner:   user.Username,
+		MonthID: february.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, february.ID, envelopes.MonthID)
+	require.Len(t, envelopes.Envelopes, 1)
+
+	envelope := envelopes.Envelopes[0]
+	carried := januaryAssignment.Amount.Add(januaryLine.Amount)
+	require.Equal(t, category.ID, envelope.Category.ID)
+	require.True(t, carried.Equal(envelope.Carried))
+	require.True(t, februaryAssignment.Amount.Equal(envelope.Assigned))
+	require.True(t, februaryLine.Amount.Equal(envelope.Activity))
+	require.True(t, carried.Add(februaryAssignment.Amount).Add(februaryLine.Amount).Equal(envelope.Available))
+	require.True(t, februaryAssignment.Amount.Neg().Equal(envelopes.ToBeBudgeted))
+}
+
+func TestAddLineTxBudgetAlerts(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	budget, err := testStore.CreateBudget(context.Background(), CreateBudgetParams{
+		Owner:      user.Username,
+		CategoryID: category.ID,
+		MonthID:    month.ID,
+		Amount:     decimal.NewFromInt(100),
+	})
+	require.NoError(t, err)
+
+	arg := AddLineTxParams{
+		Title:      util.RandomTitle(),
+		Owner:      user.Username,
+		Amount:     decimal.NewFromInt(-85),
+		DueDate:    util.RandomFutureDate(),
+		AccountID:  account.ID,
+		MonthID:    month.ID,
+		YearID:     year.ID,
+		CategoryID: category.ID,
+	}
+
+	result, err := testStore.AddLineTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Alerts, 1)
+	require.Equal(t, budget.ID, result.Alerts[0].BudgetID)
+	require.Equal(t, int32(80), result.Alerts[0].Threshold)
+
+	// The warning is not raised twice
+	arg.Amount = decimal.NewFromInt(-5)
+	result, err = testStore.AddLineTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, result.Alerts)
+
+	amount := decimal.NewFromInt(-20)
+	updated, err := testStore.UpdateLineTx(context.Background(), UpdateLineTxParams{
+		ID:     result.Line.ID,
+		Amount: decimal.NewNullDecimal(amount),
+	})
+	require.NoError(t, err)
+	require.Len(t, updated.Alerts, 1)
+	require.Equal(t, int32(100), updated.Alerts[0].Threshold)
+}
+
+func TestCreateGoalTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	category := createRandomCategory(t, user)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	arg := CreateGoalTxParams{
+		Owner:            user.Username,
+		Title:            util.RandomTitle(),
+		AccountID:        account.ID,
+		CategoryID:       &category.ID,
+		TargetAmount:     decimal.NewFromInt(1200),
+		TargetDate:       today.AddDate(1, 0, 0),
+		PlanContribution: true,
+		Date:             today,
+	}
+
+	result, err := testStore.CreateGoalTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, result.Progress.Goal.ID)
+	require.Equal(t, GoalOnTrack, result.Progress.Status)
+	require.Equal(t, 13, result.Progress.MonthsLeft)
+
+	// The contribution is planned every month on the goal account and category
+	require.NotNil(t, result.RecLine)
+	require.Equal(t, result.RecLine.ID, *result.Progress.Goal.ReclineID)
+	require.Equal(t, account.ID, result.RecLine.AccountID)
+	require.Equal(t, category.ID, result.RecLine.CategoryID)
+	require.Equal(t, util.MONTHLY, result.RecLine.Recurrency)
+	require.True(t, result.Progress.MonthlyContribution.Equal(result.RecLine.Amount))
+
+	goals, err := testStore.ListGoalProgressTx(context.Background(), ListGoalProgressTxParams{
+		Owner: user.Username,
+		Date:  today,
+	})
+	require.NoError(t, err)
+	require.Len(t, goals, 1)
+	require.Equal(t, result.Progress.Goal.ID, goals[0].Goal.ID)
+
+	// Without category, the contribution can't be planned
+	arg.CategoryID = nil
+	_, err = testStore.CreateGoalTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrGoalContributionCategory)
+}
+
+func TestCategoryReportTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	createReportLine(t, user, month, year, account, category, -40, time.Date(2024, time.February, 5, 0, 0, 0, 0, time.UTC))
+	createReportLine(t, user, month, year, account, category, -25, time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC))
+	createReportLine(t, user, month, year, account, category, -35, time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC))
+
+	report, err := testStore.CategoryReportTx(context.Background(), CategoryReportTxParams{
+		Owner: user.Username,
+		From:  time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:    time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.True(t, decimal.NewFromInt(60).Equal(report.Expense))
+	require.Len(t, report.Periods, 1)
+	require.Equal(t, int64(2), report.Periods[0].Categories[0].Count)
+
+	// February is the previous period of March
+	require.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), report.Previous.From)
+	require.True(t, decimal.NewFromInt(40).Equal(report.Previous.Expense))
+}
+
+func TestForecastTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	otherAccount := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	createReportLine(t, user, month, year, account, category, -40, time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC))
+	recline, err := testStore.CreateRecLine(context.Background(), CreateRecLineParams{
+		Title:       util.RandomTitle(),
+		Owner:       user.Username,
+		AccountID:   account.ID,
+		CategoryID:  category.ID,
+		Amount:      decimal.NewFromInt(-100),
+		DueDate:     time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC),
+		Recurrency:  util.MONTHLY,
+		Description: util.RandomString(14),
+	})
+	require.NoError(t, err)
+
+	// The line of March 10 was already created from the recline and is only counted once
+	_, err = testStore.CreateLine(context.Background(), CreateLineParams{
+		Title:      recline.Title,
+		Owner:      user.Username,
+		AccountID:  account.ID,
+		MonthID:    month.ID,
+		YearID:     year.ID,
+		CategoryID: category.ID,
+		Amount:     recline.Amount,
+		DueDate:    time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	forecast, err := testStore.ForecastTx(context.Background(), ForecastTxParams{
+		Owner:     user.Username,
+		AccountID: &account.ID,
+		From:      from,
+		Days:      31,
+	})
+	require.NoError(t, err)
+	require.Len(t, forecast.Accounts, 1)
+	require.NotEqual(t, otherAccount.ID, forecast.Accounts[0].AccountID)
+	require.True(t, account.InitBalance.Equal(forecast.Accounts[0].Start))
+	require.True(t, account.InitBalance.Sub(decimal.NewFromInt(140)).Equal(forecast.Lowest.Balance))
+	require.Equal(t, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC), forecast.Lowest.Date)
+	require.Len(t, forecast.Days, 31)
+}
+
+func TestForecastTxCheckedOccurrence(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	from := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	recline, err := testStore.CreateRecLine(context.Background(), CreateRecLineParams{
+		Title:       util.RandomTitle(),
+		Owner:       user.Username,
+		AccountID:   account.ID,
+		CategoryID:  category.ID,
+		Amount:      decimal.NewFromInt(-500),
+		Description: util.RandomString(14),
+		Recurrency:  util.MONTHLY,
+		DueDate:     time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	// The rent of today is paid already, it is in the balance and not planned again
+	_, err = testStore.AddLineTx(context.Background(), AddLineTxParams{
+		Title:      recline.Title,
+		Owner:      user.Username,
+		Amount:     recline.Amount,
+		Checked:    true,
+		DueDate:    from,
+		AccountID:  account.ID,
+		MonthID:    month.ID,
+		YearID:     year.ID,
+		CategoryID: category.ID,
+	})
+	require.NoError(t, err)
+
+	account, err = testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+
+	forecast, err := testStore.ForecastTx(context.Background(), ForecastTxParams{
+		Owner:     user.Username,
+		AccountID: &account.ID,
+		From:      from,
+		Days:      10,
+	})
+	require.NoError(t, err)
+	require.True(t, account.InitBalance.Add(account.Balance).Equal(forecast.Lowest.Balance))
+}
+
+func TestNetWorthTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	otherAccount := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+
+	createReportLine(t, user, month, year, account, category, -40, time.Date(2024, time.February, 5, 0, 0, 0, 0, time.UTC))
+	createReportLine(t, user, month, year, otherAccount, category, 300, time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC))
+
+	netWorth, err := testStore.NetWorthTx(context.Background(), NetWorthTxParams{
+		Owner:   user.Username,
+		From:    time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC),
+		GroupBy: NetWorthByMonth,
+	})
+	require.NoError(t, err)
+	require.Len(t, netWorth.Points, 2)
+
+	initBalance := account.InitBalance.Add(otherAccount.InitBalance)
+	require.True(t, initBalance.Add(decimal.NewFromInt(260)).Equal(netWorth.Points[0].NetWorth))
+	require.Len(t, netWorth.Points[0].Accounts, 2)
+	require.True(t, account.InitBalance.Sub(decimal.NewFromInt(40)).Equal(netWorth.Points[0].Accounts[0].Balance))
+	require.True(t, netWorth.Points[0].NetWorth.Equal(netWorth.Points[1].NetWorth))
+}
+
+func TestCompareMonthsTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	base := createRandomMonth(t, user, year)
+	compare := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	dueDate := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	createReportLine(t, user, base, year, account, category, -40, dueDate)
+	createReportLine(t, user, compare, year, account, category, -60, dueDate)
+
+	comparison, err := testStore.CompareMonthsTx(context.Background(), CompareMonthsTxParams{
+		Owner:   user.Username,
+		Base:    base,
+		Compare: compare,
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.ID, comparison.Base.ID)
+	require.Equal(t, compare.ID, comparison.Compare.ID)
+	require.True(t, decimal.NewFromInt(20).Equal(comparison.Expense.Delta))
+	require.Len(t, comparison.Categories, 1)
+	require.True(t, decimal.NewFromInt(-20).Equal(comparison.Categories[0].Delta))
+	require.Len(t, comparison.Lines, 2)
+}
+
+func TestBalanceHistoryTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 9)
+
+	createReportLine(t, user, month, year, account, category, 50, from.AddDate(0, 0, -1))
+	createReportLine(t, user, month, year, account, category, -20, from.AddDate(0, 0, 5))
+
+	history, err := testStore.BalanceHistoryTx(context.Background(), BalanceHistoryTxParams{
+		AccountID: account.ID,
+		From:      from,
+		To:        to,
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 10)
+	require.True(t, decimal.NewFromInt(50).Equal(history[0].FinalBalance))
+	require.True(t, decimal.NewFromInt(30).Equal(history[9].FinalBalance))
+
+	// A line before the last snapshot recomputes the days after it
+	_, err = testStore.AddLineTx(context.Background(), AddLineTxParams{
+		Title:       util.RandomTitle(),
+		Owner:       user.Username,
+		Amount:      decimal.NewFromInt(15),
+		Checked:     true,
+		Description: util.RandomString(14),
+		DueDate:     from.AddDate(0, 0, 2),
+		AccountID:   account.ID,
+		MonthID:     month.ID,
+		YearID:      year.ID,
+		CategoryID:  category.ID,
+	})
+	require.NoError(t, err)
+
+	last, err := testStore.GetLastBalanceSnapshot(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, from.AddDate(0, 0, 1).Equal(last.Date))
+
+	history, err = testStore.BalanceHistoryTx(context.Background(), BalanceHistoryTxParams{
+		AccountID: account.ID,
+		From:      from,
+		To:        to,
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 10)
+	require.True(t, decimal.NewFromInt(50).Equal(history[1].FinalBalance))
+	require.True(t, decimal.NewFromInt(65).Equal(history[2].FinalBalance))
+	require.True(t, decimal.NewFromInt(15).Equal(history[2].Balance))
+	require.True(t, decimal.NewFromInt(45).Equal(history[9].FinalBalance))
+}
+
+func TestBalanceHistoryTxBeforeFirstLine(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	dueDate := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+	createReportLine(t, user, month, year, account, category, 50, dueDate)
+
+	history, err := testStore.BalanceHistoryTx(context.Background(), BalanceHistoryTxParams{
+		AccountID: account.ID,
+		From:      dueDate.AddDate(0, 0, -3),
+		To:        dueDate.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 5)
+	require.True(t, dueDate.AddDate(0, 0, -3).Equal(history[0].Date))
+	require.True(t, history[2].FinalBalance.IsZero())
+	require.True(t, decimal.NewFromInt(50).Equal(history[3].FinalBalance))
+	require.True(t, decimal.NewFromInt(50).Equal(history[4].FinalBalance))
+
+	// A period long before the first line is served without storing the days up to it
+	history, err = testStore.BalanceHistoryTx(context.Background(), BalanceHistoryTxParams{
+		AccountID: account.ID,
+		From:      time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(1990, time.January, 10, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 10)
+	for _, snapshot := range history {
+		require.True(t, snapshot.FinalBalance.IsZero())
+	}
+
+	first, err := testStore.GetFirstBalanceSnapshot(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, dueDate.Equal(first.Date))
+}
+
+func TestRefreshBalanceSnapshotsTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	date := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	result, err := testStore.RefreshBalanceSnapshotsTx(context.Background(), RefreshBalanceSnapshotsTxParams{
+		Date: date,
+		Days: 5,
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, result.Accounts, 1)
+
+	first, err := testStore.GetFirstBalanceSnapshot(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, date.AddDate(0, 0, -4).Equal(first.Date))
+
+	last, err := testStore.GetLastBalanceSnapshot(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, date.Equal(last.Date))
+
+	// Up to date snapshots aren't computed again
+	again, err := testStore.RefreshBalanceSnapshotsTx(context.Background(), RefreshBalanceSnapshotsTxParams{
+		Date: date,
+		Days: 5,
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, again.Accounts, result.Accounts)
+}
+
+func TestDetectAnomaliesTx(t *testing.T) {
+	user := createRandomUser(t)
+	account := createRandomAccount(t, user)
+	year := createRandomYear(t, user)
+	month := createRandomMonth(t, user, year)
+	category := createRandomCategory(t, user)
+	dueDate := time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+	var lines []Line
+	for i, amount := range []int64{-20, -20, -20, -40} {
+		line, err := testStore.CreateLine(context.Background(), CreateLineParams{
+			Title:       "FREE MOBILE",
+			Owner:       user.Username,
+			AccountID:   account.ID,
+			MonthID:     month.ID,
+			YearID:      year.ID,
+			CategoryID:  category.ID,
+			Amount:      decimal.NewFromInt(amount),
+			DueDate:     dueDate.AddDate(0, i, 0),
+			Description: util.RandomString(14),
+		})
+		require.NoError(t, err)
+		lines = append(lines, line)
+	}
+
+	result, err := testStore.DetectAnomaliesTx(context.Background(), DetectAnomaliesTxParams{Owner: user.Username})
+	require.NoError(t, err)
+	require.Len(t, result.Anomalies, 1)
+	require.Equal(t, lines[3].ID, result.Anomalies[0].LineID)
+	require.Equal(t, anomaly.KindUnusualAmount, result.Anomalies[0].Kind)
+
+	// Once the line is fixed, the anomaly goes away
+	_, err = testStore.UpdateLine(context.Background(), UpdateLineParams{
+		ID:          lines[3].ID,
+		Title:       lines[3].Title,
+		AccountID:   lines[3].AccountID,
+		MonthID:     lines[3].MonthID,
+		CategoryID:  lines[3].CategoryID,
+		YearID:      lines[3].YearID,
+		Amount:      decimal.NewFromInt(-20),
+		Checked:     lines[3].Checked,
+		Description: lines[3].Description,
+		DueDate:     lines[3].DueDate,
+	})
+	require.NoError(t, err)
+
+	result, err = testStore.DetectAnomaliesTx(context.Background(), DetectAnomaliesTxParams{Owner: user.Username})
+	require.NoError(t, err)
+	require.Empty(t, result.Anomalies)
+	require.Equal(t, int64(1), result.Removed)
+}
@@ -2,8 +2,10 @@ package db
 
 import (
 	"context"
+	"log"
 	"time"
 
+	"github.com/moth13/finance_tracker/rules"
 	"github.com/moth13/finance_tracker/util"
 	decimal "github.com/shopspring/decimal"
 )
@@ -26,36 +28,59 @@ type AddLineTxParams struct {
 type AddLineTxResult struct {
 	Line    Line         `json:"line"`
 	Balance util.Balance `json:"balance"`
+	// Rules lists the rules which matched the new line
+	Rules []int64 `json:"rules"`
+	// Warnings lists the checks the line failed without being refused
+	Warnings []string `json:"warnings,omitempty"`
+	// Alerts lists the budget thresholds the line made its month reach
+	Alerts []BudgetAlert `json:"alerts,omitempty"`
 }
 
 func (store *SQLStore) AddLineTx(ctx context.Context, arg AddLineTxParams) (AddLineTxResult, error) {
 	var result AddLineTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
-		var err error
+		engine, err := loadRuleEngine(ctx, q, arg.Owner)
+		if err != nil {
+			return err
+		}
+
+		line, matched := engine.Apply(rules.Line{
+			Title:       arg.Title,
+			Amount:      arg.Amount,
+			AccountID:   arg.AccountID,
+			DueDate:     arg.DueDate,
+			CategoryID:  arg.CategoryID,
+			Checked:     arg.Checked,
+			Description: arg.Description,
+		})
+		result.Rules = matched
 
 		argLine := CreateLineParams{
 			Title:       arg.Title,
 			Owner:       arg.Owner,
-			Description: arg.Description,
-			Checked:     arg.Checked,
+			Description: line.Description,
+			Checked:     line.Checked,
 			Amount:      arg.Amount,
 			AccountID:   arg.AccountID,
 			MonthID:     arg.MonthID,
 			YearID:      arg.YearID,
-			CategoryID:  arg.CategoryID,
+			CategoryID:  line.CategoryID,
 			DueDate:     arg.DueDate,
+			Payee:       &line.Payee,
+			Tags:        line.Tags,
 		}
 
 		argAdd := addMoneyTxParams{
-			Amount: decimal.Zero,
+			Amount:      decimal.Zero,
 			FinalAmount: arg.Amount,
-			AccountID: arg.AccountID,
-			MonthID: arg.MonthID,
-			YearID: arg.YearID,
+			AccountID:   arg.AccountID,
+			MonthID:     arg.MonthID,
+			YearID:      arg.YearID,
+			DueDate:     arg.DueDate,
 		}
 
-		if arg.Checked {
+		if argLine.Checked {
 			argAdd.Amount = arg.Amount
 		}
 
@@ -65,7 +90,13 @@ func (store *SQLStore) AddLineTx(ctx context.Context, arg AddLineTxParams) (AddL
 			return err
 		}
 
-		if _, err := q.GetCategory(ctx, argLine.CategoryID); err != nil {
+		category, err := q.GetCategory(ctx, argLine.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		result.Warnings, err = checkCategoryKind(ctx, q, arg.Owner, category, arg.Amount)
+		if err != nil {
 			return err
 		}
 
@@ -76,6 +107,15 @@ func (store *SQLStore) AddLineTx(ctx context.Context, arg AddLineTxParams) (AddL
 
 		return err
 	})
+	if err != nil {
+		return result, err
+	}
+
+	// Budgets are checked once the line is committed, failing to do so doesn't undo the line
+	result.Alerts, err = store.evaluateBudgetAlerts(ctx, result.Line.Owner, result.Line.MonthID)
+	if err != nil {
+		log.Printf("cannot evaluate budget alerts of %s: %v", result.Line.Owner, err)
+	}
 
-	return result, err
+	return result, nil
 }
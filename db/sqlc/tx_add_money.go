@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/moth13/finance_tracker/util"
 	decimal "github.com/shopspring/decimal"
@@ -13,6 +14,8 @@ type addMoneyTxParams struct {
 	AccountID   int64
 	MonthID     int64
 	YearID      int64
+	// DueDate is the date of the line, the balance snapshots are stale from it
+	DueDate time.Time
 }
 
 func addMoneyTx(ctx context.Context,
@@ -50,6 +53,14 @@ func addMoneyTx(ctx context.Context,
 		return
 	}
 
+	_, err = q.DeleteBalanceSnapshotsFrom(ctx, DeleteBalanceSnapshotsFromParams{
+		AccountID: arg.AccountID,
+		FromDate:  arg.DueDate,
+	})
+	if err != nil {
+		return
+	}
+
 	balance.AccountBalance = account.Balance
 	balance.AccountFinalBalance = account.FinalBalance
 	balance.MonthBalance = month.Balance
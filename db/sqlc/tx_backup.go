@@ -0,0 +1,586 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// BackupVersion is the schema version of the backups written by this server.
+// Version 2 added budgets, envelope assignments and goals, version 1 backups are still restored.
+const BackupVersion = 2
+
+// backupPageSize is the number of rows read at once while building a backup
+const backupPageSize = 500
+
+var (
+	// ErrUnsupportedBackupVersion is returned when a backup comes from an unknown schema
+	ErrUnsupportedBackupVersion = errors.New("unsupported backup version")
+	// ErrRestoreTargetNotEmpty is returned when restoring into a user who already owns accounts
+	ErrRestoreTargetNotEmpty = errors.New("restore target already owns accounts")
+	// ErrInvalidBackup is returned when a backup references rows it does not contain
+	ErrInvalidBackup = errors.New("invalid backup")
+)
+
+// BackupUser is the profile of a backed up user, without credentials
+type BackupUser struct {
+	Username          string    `json:"username"`
+	FullName          string    `json:"full_name"`
+	Email             string    `json:"email"`
+	Currency          string    `json:"currency"`
+	Locale            string    `json:"locale"`
+	CategoryKindCheck string    `json:"category_kind_check"`
+	BudgetAlertEmail  *bool     `json:"budget_alert_email"`
+	CreateAt          time.Time `json:"create_at"`
+}
+
+// Backup contains everything a user owns. Only dismissed anomalies are kept, the next detection finds the others again.
+type Backup struct {
+	Version    int                  `json:"version"`
+	CreatedAt  time.Time            `json:"created_at"`
+	User       BackupUser           `json:"user"`
+	Accounts   []Account            `json:"accounts"`
+	Years      []Year               `json:"years"`
+	Months     []Month              `json:"months"`
+	Categories []Category           `json:"categories"`
+	Lines      []Line               `json:"lines"`
+	RecLines   []Recline            `json:"reclines"`
+	Rules      []Rule               `json:"rules"`
+	Budgets    []Budget             `json:"budgets"`
+	Envelopes  []EnvelopeAssignment `json:"envelopes"`
+	Goals      []Goal               `json:"goals"`
+	Anomalies  []LineAnomaly        `json:"anomalies"`
+}
+
+// RestoreBackupTxParams contains all infos to restore a backup under a user
+type RestoreBackupTxParams struct {
+	Owner  string `json:"owner"`
+	Backup Backup `json:"backup"`
+}
+
+// RestoreBackupTxResult counts what has been recreated
+type RestoreBackupTxResult struct {
+	User       User `json:"user"`
+	Accounts   int  `json:"accounts"`
+	Years      int  `json:"years"`
+	Months     int  `json:"months"`
+	Categories int  `json:"categories"`
+	Lines      int  `json:"lines"`
+	RecLines   int  `json:"reclines"`
+	Rules      int  `json:"rules"`
+	Budgets    int  `json:"budgets"`
+	Envelopes  int  `json:"envelopes"`
+	Goals      int  `json:"goals"`
+	Anomalies  int  `json:"anomalies"`
+}
+
+// BackupTx reads everything a user owns within a single transaction
+func (store *SQLStore) BackupTx(ctx context.Context, owner string) (Backup, error) {
+	backup := Backup{
+		Version:   BackupVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		user, err := q.GetUser(ctx, owner)
+		if err != nil {
+			return err
+		}
+		backup.User = BackupUser{
+			Username:          user.Username,
+			FullName:          user.FullName,
+			Email:             user.Email,
+			Currency:          user.Currency,
+			Locale:            user.Locale,
+			CategoryKindCheck: user.CategoryKindCheck,
+			BudgetAlertEmail:  &user.BudgetAlertEmail,
+			CreateAt:          user.CreateAt,
+		}
+
+		backup.Accounts, err = listAll(func(limit, offset int32) ([]Account, error) {
+			return q.ListAccounts(ctx, ListAccountsParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Years, err = listAll(func(limit, offset int32) ([]Year, error) {
+			return q.ListYears(ctx, ListYearsParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Months, err = listAll(func(limit, offset int32) ([]Month, error) {
+			return q.ListMonths(ctx, ListMonthsParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Categories, err = listAll(func(limit, offset int32) ([]Category, error) {
+			return q.ListCategories(ctx, ListCategoriesParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Lines, err = listAll(func(limit, offset int32) ([]Line, error) {
+			return q.ListLines(ctx, ListLinesParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.RecLines, err = listAll(func(limit, offset int32) ([]Recline, error) {
+			return q.ListRecLines(ctx, ListRecLinesParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Rules, err = listAll(func(limit, offset int32) ([]Rule, error) {
+			return q.ListRules(ctx, ListRulesParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Budgets, err = listAll(func(limit, offset int32) ([]Budget, error) {
+			return q.ListBudgets(ctx, ListBudgetsParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Envelopes, err = listAll(func(limit, offset int32) ([]EnvelopeAssignment, error) {
+			return q.ListEnvelopeAssignments(ctx, ListEnvelopeAssignmentsParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Goals, err = listAll(func(limit, offset int32) ([]Goal, error) {
+			return q.ListGoals(ctx, ListGoalsParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		backup.Anomalies, err = listAll(func(limit, offset int32) ([]LineAnomaly, error) {
+			return q.ListDismissedLineAnomalies(ctx, ListDismissedLineAnomaliesParams{Owner: owner, Limit: limit, Offset: offset})
+		})
+		return err
+	})
+
+	return backup, err
+}
+
+// RestoreBackupTx recreates a backup under the owner, remapping every id and recomputing balances.
+// Categories are matched by title and parent so the ones the owner already has are reused.
+func (store *SQLStore) RestoreBackupTx(ctx context.Context, arg RestoreBackupTxParams) (RestoreBackupTxResult, error) {
+	var result RestoreBackupTxResult
+
+	backup := arg.Backup
+	if backup.Version < 1 || backup.Version > BackupVersion {
+		return result, fmt.Errorf("%w %d", ErrUnsupportedBackupVersion, backup.Version)
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		existing, err := q.ListAccounts(ctx, ListAccountsParams{Owner: arg.Owner, Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return ErrRestoreTargetNotEmpty
+		}
+
+		// Backups made before locales existed keep the locale of the target
+		argUser := UpdateUserProfileParams{
+			Username: arg.Owner,
+			FullName: backup.User.FullName,
+			Currency: backup.User.Currency,
+		}
+		if backup.User.Locale != "" {
+			argUser.Locale = &backup.User.Locale
+		}
+		result.User, err = q.UpdateUserProfile(ctx, argUser)
+		if err != nil {
+			return err
+		}
+
+		// Preferences are missing from backups made before they existed, the target keeps its own
+		argPreferences := UpdateUserPreferencesParams{
+			Username:         arg.Owner,
+			BudgetAlertEmail: backup.User.BudgetAlertEmail,
+		}
+		if backup.User.CategoryKindCheck != "" {
+			if !util.IsSupportedKindCheck(backup.User.CategoryKindCheck) {
+				return fmt.Errorf("%w: unsupported category kind check %s", ErrInvalidBackup, backup.User.CategoryKindCheck)
+			}
+			argPreferences.CategoryKindCheck = &backup.User.CategoryKindCheck
+		}
+		result.User, err = q.UpdateUserPreferences(ctx, argPreferences)
+		if err != nil {
+			return err
+		}
+
+		accounts := make(map[int64]int64, len(backup.Accounts))
+		for _, account := range backup.Accounts {
+			created, err := q.CreateAccount(ctx, CreateAccountParams{
+				Owner:       arg.Owner,
+				Title:       account.Title,
+				Description: account.Description,
+				InitBalance: account.InitBalance,
+			})
+			if err != nil {
+				return err
+			}
+			accounts[account.ID] = created.ID
+			result.Accounts++
+		}
+
+		years := make(map[int64]int64, len(backup.Years))
+		for _, year := range backup.Years {
+			created, err := q.CreateYear(ctx, CreateYearParams{
+				Title:       year.Title,
+				Owner:       arg.Owner,
+				Description: year.Description,
+				StartDate:   year.StartDate,
+				EndDate:     year.EndDate,
+			})
+			if err != nil {
+				return err
+			}
+			years[year.ID] = created.ID
+			result.Years++
+		}
+
+		months := make(map[int64]int64, len(backup.Months))
+		for _, month := range backup.Months {
+			yearID, err := remap(years, month.YearID, "year")
+			if err != nil {
+				return err
+			}
+
+			created, err := q.CreateMonth(ctx, CreateMonthParams{
+				Title:       month.Title,
+				Owner:       arg.Owner,
+				Description: month.Description,
+				YearID:      yearID,
+				StartDate:   month.StartDate,
+				EndDate:     month.EndDate,
+			})
+			if err != nil {
+				return err
+			}
+			months[month.ID] = created.ID
+			result.Months++
+		}
+
+		categories, err := restoreCategories(ctx, q, arg.Owner, backup.Categories, &result)
+		if err != nil {
+			return err
+		}
+
+		lines := make(map[int64]int64, len(backup.Lines))
+		for _, line := range backup.Lines {
+			var argLine CreateLineParams
+			if argLine.AccountID, err = remap(accounts, line.AccountID, "account"); err != nil {
+				return err
+			}
+			if argLine.MonthID, err = remap(months, line.MonthID, "month"); err != nil {
+				return err
+			}
+			if argLine.YearID, err = remap(years, line.YearID, "year"); err != nil {
+				return err
+			}
+			if argLine.CategoryID, err = remap(categories, line.CategoryID, "category"); err != nil {
+				return err
+			}
+			argLine.Title = line.Title
+			argLine.Owner = arg.Owner
+			argLine.Amount = line.Amount
+			argLine.Checked = line.Checked
+			argLine.Description = line.Description
+			argLine.DueDate = line.DueDate
+			argLine.Payee = &line.Payee
+			argLine.Tags = line.Tags
+
+			created, err := q.CreateLine(ctx, argLine)
+			if err != nil {
+				return err
+			}
+			lines[line.ID] = created.ID
+
+			// Balances are rebuilt from the lines rather than trusted from the backup
+			argAdd := addMoneyTxParams{
+				Amount:      decimal.Zero,
+				FinalAmount: line.Amount,
+				AccountID:   argLine.AccountID,
+				MonthID:     argLine.MonthID,
+				YearID:      argLine.YearID,
+				DueDate:     argLine.DueDate,
+			}
+			if line.Checked {
+				argAdd.Amount = line.Amount
+			}
+			if _, err := addMoneyTx(ctx, q, argAdd); err != nil {
+				return err
+			}
+			result.Lines++
+		}
+
+		reclines := make(map[int64]int64, len(backup.RecLines))
+		for _, recline := range backup.RecLines {
+			accountID, err := remap(accounts, recline.AccountID, "account")
+			if err != nil {
+				return err
+			}
+			categoryID, err := remap(categories, recline.CategoryID, "category")
+			if err != nil {
+				return err
+			}
+
+			created, err := q.CreateRecLine(ctx, CreateRecLineParams{
+				Title:       recline.Title,
+				Owner:       arg.Owner,
+				AccountID:   accountID,
+				CategoryID:  categoryID,
+				Amount:      recline.Amount,
+				Description: recline.Description,
+				Recurrency:  recline.Recurrency,
+				DueDate:     recline.DueDate,
+			})
+			if err != nil {
+				return err
+			}
+			reclines[recline.ID] = created.ID
+			result.RecLines++
+		}
+
+		for _, rule := range backup.Rules {
+			argRule := CreateRuleParams{
+				Owner:          arg.Owner,
+				Title:          rule.Title,
+				Priority:       rule.Priority,
+				MatchTitle:     rule.MatchTitle,
+				MatchMinAmount: rule.MatchMinAmount,
+				MatchMaxAmount: rule.MatchMaxAmount,
+				MatchDay:       rule.MatchDay,
+				SetPayee:       rule.SetPayee,
+				SetTags:        rule.SetTags,
+				SetChecked:     rule.SetChecked,
+				SetDescription: rule.SetDescription,
+			}
+			if rule.MatchAccountID != nil {
+				accountID, err := remap(accounts, *rule.MatchAccountID, "account")
+				if err != nil {
+					return err
+				}
+				argRule.MatchAccountID = &accountID
+			}
+			if rule.SetCategoryID != nil {
+				categoryID, err := remap(categories, *rule.SetCategoryID, "category")
+				if err != nil {
+					return err
+				}
+				argRule.SetCategoryID = &categoryID
+			}
+
+			if _, err := q.CreateRule(ctx, argRule); err != nil {
+				return err
+			}
+			result.Rules++
+		}
+
+		for _, budget := range backup.Budgets {
+			categoryID, err := remap(categories, budget.CategoryID, "category")
+			if err != nil {
+				return err
+			}
+			monthID, err := remap(months, budget.MonthID, "month")
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateBudget(ctx, CreateBudgetParams{
+				Owner:      arg.Owner,
+				CategoryID: categoryID,
+				MonthID:    monthID,
+				Recurring:  budget.Recurring,
+				Amount:     budget.Amount,
+			})
+			if err != nil {
+				return err
+			}
+			result.Budgets++
+		}
+
+		for _, envelope := range backup.Envelopes {
+			categoryID, err := remap(categories, envelope.CategoryID, "category")
+			if err != nil {
+				return err
+			}
+			monthID, err := remap(months, envelope.MonthID, "month")
+			if err != nil {
+				return err
+			}
+
+			_, err = q.SetEnvelopeAssignment(ctx, SetEnvelopeAssignmentParams{
+				Owner:      arg.Owner,
+				CategoryID: categoryID,
+				MonthID:    monthID,
+				Amount:     envelope.Amount,
+			})
+			if err != nil {
+				return err
+			}
+			result.Envelopes++
+		}
+
+		for _, goal := range backup.Goals {
+			accountID, err := remap(accounts, goal.AccountID, "account")
+			if err != nil {
+				return err
+			}
+
+			argGoal := CreateGoalParams{
+				Owner:        arg.Owner,
+				Title:        goal.Title,
+				AccountID:    accountID,
+				TargetAmount: goal.TargetAmount,
+				TargetDate:   goal.TargetDate,
+			}
+			if goal.CategoryID != nil {
+				categoryID, err := remap(categories, *goal.CategoryID, "category")
+				if err != nil {
+					return err
+				}
+				argGoal.CategoryID = &categoryID
+			}
+			if goal.ReclineID != nil {
+				reclineID, err := remap(reclines, *goal.ReclineID, "recline")
+				if err != nil {
+					return err
+				}
+				argGoal.ReclineID = &reclineID
+			}
+
+			if _, err := q.CreateGoal(ctx, argGoal); err != nil {
+				return err
+			}
+			result.Goals++
+		}
+
+		// Dismissed anomalies are kept dismissed, detecting them again doesn't bring them back
+		for _, anomaly := range backup.Anomalies {
+			lineID, err := remap(lines, anomaly.LineID, "line")
+			if err != nil {
+				return err
+			}
+
+			created, err := q.CreateLineAnomaly(ctx, CreateLineAnomalyParams{
+				Owner:  arg.Owner,
+				LineID: lineID,
+				Kind:   anomaly.Kind,
+				Reason: anomaly.Reason,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := q.DismissLineAnomaly(ctx, created.ID); err != nil {
+				return err
+			}
+			result.Anomalies++
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// restoreCategories recreates categories parents first, reusing the ones the owner already has at the same place
+func restoreCategories(ctx context.Context, q *Queries, owner string, backup []Category, result *RestoreBackupTxResult) (map[int64]int64, error) {
+	categories := make(map[int64]int64, len(backup))
+	pending := backup
+	for len(pending) > 0 {
+		var waiting []Category
+		for _, category := range pending {
+			var parentID *int64
+			if category.ParentID != nil {
+				id, ok := categories[*category.ParentID]
+				if !ok {
+					waiting = append(waiting, category)
+					continue
+				}
+				parentID = &id
+			}
+
+			found, err := q.GetCategoryByTitle(ctx, GetCategoryByTitleParams{
+				Owner:    owner,
+				Title:    category.Title,
+				ParentID: parentID,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				// Backups made before kinds existed only have expense categories
+				kind := category.Kind
+				if kind == "" {
+					kind = util.EXPENSE
+				}
+				found, err = q.CreateCategory(ctx, CreateCategoryParams{
+					Title:    category.Title,
+					Owner:    owner,
+					ParentID: parentID,
+					Kind:     kind,
+					Color:    category.Color,
+					Icon:     category.Icon,
+				})
+				result.Categories++
+			}
+			if err != nil {
+				return nil, err
+			}
+			categories[category.ID] = found.ID
+		}
+
+		// No progress means parents missing from the backup or a cycle
+		if len(waiting) == len(pending) {
+			return nil, fmt.Errorf("%w: unknown parent category %d", ErrInvalidBackup, *waiting[0].ParentID)
+		}
+		pending = waiting
+	}
+
+	return categories, nil
+}
+
+// listAll reads every page of a listing query
+func listAll[T any](list func(limit, offset int32) ([]T, error)) ([]T, error) {
+	items := []T{}
+	for offset := int32(0); ; offset += backupPageSize {
+		page, err := list(backupPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if len(page) < backupPageSize {
+			return items, nil
+		}
+	}
+}
+
+// remap returns the id given to a restored row, failing on references missing from the backup
+func remap(ids map[int64]int64, id int64, kind string) (int64, error) {
+	newID, ok := ids[id]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown %s %d", ErrInvalidBackup, kind, id)
+	}
+
+	return newID, nil
+}
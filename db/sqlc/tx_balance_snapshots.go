@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BalanceHistoryTxParams contains all infos to read the end of day balances of an account
+type BalanceHistoryTxParams struct {
+	AccountID int64     `json:"account_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+// BalanceHistoryTx returns the balance snapshots of an account from a date to another, both included.
+// Missing snapshots are computed first, so a day never has to be rebuilt twice. The days before the first line
+// of the account have zero balances and are not stored, so a distant date doesn't backfill every day since.
+func (store *SQLStore) BalanceHistoryTx(ctx context.Context, arg BalanceHistoryTxParams) ([]BalanceSnapshot, error) {
+	var result []BalanceSnapshot
+	from, to := dateOnly(arg.From), dateOnly(arg.To)
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		start, err := q.GetAccountFirstLineDate(ctx, arg.AccountID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		if err == nil && !start.After(to) {
+			if start.Before(from) {
+				start = from
+			}
+			if _, err := ensureBalanceSnapshots(ctx, q, arg.AccountID, start, to); err != nil {
+				return err
+			}
+		}
+
+		snapshots, err := q.ListBalanceSnapshots(ctx, ListBalanceSnapshotsParams{
+			AccountID: arg.AccountID,
+			FromDate:  from,
+			ToDate:    to,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = fillBalanceSnapshots(arg.AccountID, from, to, snapshots)
+		return nil
+	})
+
+	return result, err
+}
+
+// fillBalanceSnapshots returns a snapshot for every day from a date to another, the days without one have zero balances
+func fillBalanceSnapshots(accountID int64, from, to time.Time, snapshots []BalanceSnapshot) []BalanceSnapshot {
+	result := make([]BalanceSnapshot, 0, len(snapshots))
+	for day, i := from, 0; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if i < len(snapshots) && snapshots[i].Date.Equal(day) {
+			result = append(result, snapshots[i])
+			i++
+			continue
+		}
+		result = append(result, BalanceSnapshot{AccountID: accountID, Date: day})
+	}
+
+	return result
+}
+
+// RefreshBalanceSnapshotsTxParams contains all infos to bring the balance snapshots of every account up to date
+type RefreshBalanceSnapshotsTxParams struct {
+	Date time.Time `json:"date"`
+	// Days is the number of days up to Date every account has a snapshot for
+	Days int `json:"days"`
+}
+
+// RefreshBalanceSnapshotsTxResult contains the result of the refresh
+type RefreshBalanceSnapshotsTxResult struct {
+	Accounts  int   `json:"accounts"`
+	Snapshots int64 `json:"snapshots"`
+}
+
+// RefreshBalanceSnapshotsTx computes the missing balance snapshots of every account, one transaction per account
+func (store *SQLStore) RefreshBalanceSnapshotsTx(ctx context.Context, arg RefreshBalanceSnapshotsTxParams) (RefreshBalanceSnapshotsTxResult, error) {
+	var result RefreshBalanceSnapshotsTxResult
+
+	accountIDs, err := listAll(func(limit, offset int32) ([]int64, error) {
+		return store.ListAccountIDs(ctx, ListAccountIDsParams{Limit: limit, Offset: offset})
+	})
+	if err != nil {
+		return result, err
+	}
+
+	from := arg.Date.AddDate(0, 0, 1-arg.Days)
+	for _, accountID := range accountIDs {
+		err := store.execTx(ctx, func(q *Queries) error {
+			created, err := ensureBalanceSnapshots(ctx, q, accountID, from, arg.Date)
+			result.Snapshots += created
+			return err
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Accounts++
+	}
+
+	return result, nil
+}
+
+// ensureBalanceSnapshots computes the snapshots an account misses from a date to another.
+// The snapshots of an account always are consecutive days: a change removes them from its date on.
+func ensureBalanceSnapshots(ctx context.Context, q *Queries, accountID int64, from, to time.Time) (int64, error) {
+	from, to = dateOnly(from), dateOnly(to)
+	var created int64
+
+	first, err := q.GetFirstBalanceSnapshot(ctx, accountID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return created, err
+	}
+	if err != nil || first.Date.After(from) {
+		end := to
+		if err == nil {
+			end = first.Date.AddDate(0, 0, -1)
+		}
+
+		// The days before the snapshots start from the lines before them
+		before, err := q.SumAccountLinesBefore(ctx, SumAccountLinesBeforeParams{
+			AccountID: accountID,
+			Before:    from,
+		})
+		if err != nil {
+			return created, err
+		}
+
+		created, err = q.CreateBalanceSnapshots(ctx, CreateBalanceSnapshotsParams{
+			AccountID:    accountID,
+			Balance:      before.Balance,
+			FinalBalance: before.FinalBalance,
+			FromDate:     from,
+			ToDate:       end,
+		})
+		if err != nil {
+			return created, err
+		}
+	}
+
+	last, err := q.GetLastBalanceSnapshot(ctx, accountID)
+	if err != nil {
+		return created, err
+	}
+	if !last.Date.Before(to) {
+		return created, nil
+	}
+
+	// The days after the snapshots start from the last one
+	after, err := q.CreateBalanceSnapshots(ctx, CreateBalanceSnapshotsParams{
+		AccountID:    accountID,
+		Balance:      last.Balance,
+		FinalBalance: last.FinalBalance,
+		FromDate:     last.Date.AddDate(0, 0, 1),
+		ToDate:       to,
+	})
+
+	return created + after, err
+}
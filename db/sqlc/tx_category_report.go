@@ -0,0 +1,237 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Groupings of a category report
+const (
+	ReportByMonth = "month"
+	ReportByYear  = "year"
+)
+
+// CategoryReportRow is the report of a category, its totals rolling up the lines of its descendants
+type CategoryReportRow struct {
+	ReportLinesByCategoryRow
+	ParentID *int64 `json:"parent_id"`
+	// TotalIncome and TotalExpense sum the lines of the category and of all its descendants
+	TotalIncome  decimal.Decimal `json:"total_income"`
+	TotalExpense decimal.Decimal `json:"total_expense"`
+}
+
+// CategoryReportPeriod sums the income and expense of each category over a period.
+// Income and Expense only count each line once, in its own category.
+type CategoryReportPeriod struct {
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Income     decimal.Decimal     `json:"income"`
+	Expense    decimal.Decimal     `json:"expense"`
+	Categories []CategoryReportRow `json:"categories"`
+}
+
+// CategoryReport sums the lines of a period by category, transfers left out
+type CategoryReport struct {
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	GroupBy string          `json:"group_by"`
+	Income  decimal.Decimal `json:"income"`
+	Expense decimal.Decimal `json:"expense"`
+	// Periods has a single period covering the report unless it is grouped by month or year
+	Periods []CategoryReportPeriod `json:"periods"`
+	// Previous is the period of the same length right before the report, never grouped
+	Previous CategoryReportPeriod `json:"previous"`
+}
+
+// CategoryReportTxParams contains all infos to report the lines of a period by category
+type CategoryReportTxParams struct {
+	Owner     string    `json:"owner"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	AccountID *int64    `json:"account_id"`
+	// GroupBy is empty, ReportByMonth or ReportByYear
+	GroupBy string `json:"group_by"`
+}
+
+// CategoryReportTx reports the lines of a period by category, along with the previous period
+func (store *SQLStore) CategoryReportTx(ctx context.Context, arg CategoryReportTxParams) (CategoryReport, error) {
+	var result CategoryReport
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		categories, err := listAll(func(limit, offset int32) ([]Category, error) {
+			return q.ListCategories(ctx, ListCategoriesParams{Owner: arg.Owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.ReportLinesByCategory(ctx, ReportLinesByCategoryParams{
+			GroupBy:   arg.GroupBy,
+			FromDate:  arg.From,
+			Owner:     arg.Owner,
+			ToDate:    arg.To,
+			AccountID: arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		previousFrom, previousTo := PreviousPeriod(arg.From, arg.To)
+		previousRows, err := q.ReportLinesByCategory(ctx, ReportLinesByCategoryParams{
+			FromDate:  previousFrom,
+			Owner:     arg.Owner,
+			ToDate:    previousTo,
+			AccountID: arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = BuildCategoryReport(arg.From, arg.To, arg.GroupBy, rows, categories)
+		previous := BuildCategoryReport(previousFrom, previousTo, "", previousRows, categories)
+		result.Previous = previous.Periods[0]
+		return nil
+	})
+
+	return result, err
+}
+
+// PreviousPeriod returns the period of the same length ending the day before from.
+// Whole months are compared to as many whole months.
+func PreviousPeriod(from, to time.Time) (time.Time, time.Time) {
+	previousTo := from.AddDate(0, 0, -1)
+
+	if from.Day() == 1 && to.AddDate(0, 0, 1).Day() == 1 {
+		months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+		return from.AddDate(0, -months, 0), previousTo
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	return from.AddDate(0, 0, -days), previousTo
+}
+
+// BuildCategoryReport splits the rows of the report query into their periods and totals them.
+// Within each period the rows of sub-categories are rolled up to their ancestors, which get a row if they have no line.
+func BuildCategoryReport(from, to time.Time, groupBy string, rows []ReportLinesByCategoryRow, categories []Category) CategoryReport {
+	result := CategoryReport{
+		From:    from,
+		To:      to,
+		GroupBy: groupBy,
+		Periods: []CategoryReportPeriod{},
+	}
+
+	for _, row := range rows {
+		last := len(result.Periods) - 1
+		if last < 0 || !result.Periods[last].From.Equal(periodStart(row.Period, from)) {
+			result.Periods = append(result.Periods, newReportPeriod(row.Period, from, to, groupBy))
+			last++
+		}
+
+		period := &result.Periods[last]
+		period.Income = period.Income.Add(row.Income)
+		period.Expense = period.Expense.Add(row.Expense)
+		period.Categories = append(period.Categories, CategoryReportRow{ReportLinesByCategoryRow: row})
+
+		result.Income = result.Income.Add(row.Income)
+		result.Expense = result.Expense.Add(row.Expense)
+	}
+
+	// An ungrouped report always has its period, even without lines
+	if groupBy == "" && len(result.Periods) == 0 {
+		result.Periods = append(result.Periods, newReportPeriod(from, from, to, groupBy))
+	}
+
+	byID := make(map[int64]Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	for i := range result.Periods {
+		rollUpReport(&result.Periods[i], byID)
+	}
+
+	return result
+}
+
+// rollUpReport adds the income and expense of each category of a period to the totals of its ancestors
+func rollUpReport(period *CategoryReportPeriod, categories map[int64]Category) {
+	index := make(map[int64]int, len(period.Categories))
+	for i := range period.Categories {
+		row := &period.Categories[i]
+		row.ParentID = categories[row.CategoryID].ParentID
+		row.TotalIncome = row.Income
+		row.TotalExpense = row.Expense
+		index[row.CategoryID] = i
+	}
+
+	own := len(period.Categories)
+	for i := 0; i < own; i++ {
+		row := period.Categories[i]
+
+		// Seen ancestors stop the walk should the tree hold a cycle
+		seen := map[int64]bool{row.CategoryID: true}
+		for parentID := row.ParentID; parentID != nil && !seen[*parentID]; {
+			parent, ok := categories[*parentID]
+			if !ok {
+				break
+			}
+			seen[parent.ID] = true
+
+			j, ok := index[parent.ID]
+			if !ok {
+				period.Categories = append(period.Categories, CategoryReportRow{
+					ReportLinesByCategoryRow: ReportLinesByCategoryRow{
+						Period:       row.Period,
+						CategoryID:   parent.ID,
+						Category:     parent.Title,
+						CategoryKind: parent.Kind,
+						Income:       decimal.Zero,
+						Expense:      decimal.Zero,
+						IncomeShare:  decimal.Zero,
+						ExpenseShare: decimal.Zero,
+					},
+					ParentID:     parent.ParentID,
+					TotalIncome:  decimal.Zero,
+					TotalExpense: decimal.Zero,
+				})
+				j = len(period.Categories) - 1
+				index[parent.ID] = j
+			}
+
+			ancestor := &period.Categories[j]
+			ancestor.TotalIncome = ancestor.TotalIncome.Add(row.Income)
+			ancestor.TotalExpense = ancestor.TotalExpense.Add(row.Expense)
+			parentID = parent.ParentID
+		}
+	}
+}
+
+// newReportPeriod returns the month or year starting on start, cut to the dates of the report
+func newReportPeriod(start, from, to time.Time, groupBy string) CategoryReportPeriod {
+	end := to
+	switch groupBy {
+	case ReportByMonth:
+		end = start.AddDate(0, 1, -1)
+	case ReportByYear:
+		end = start.AddDate(1, 0, -1)
+	}
+	if end.After(to) {
+		end = to
+	}
+
+	return CategoryReportPeriod{
+		From:       periodStart(start, from),
+		To:         end,
+		Income:     decimal.Zero,
+		Expense:    decimal.Zero,
+		Categories: []CategoryReportRow{},
+	}
+}
+
+func periodStart(start, from time.Time) time.Time {
+	if start.Before(from) {
+		return from
+	}
+	return start
+}
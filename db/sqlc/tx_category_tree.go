@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// CategoryNode is a category with the amounts of its lines and its children
+type CategoryNode struct {
+	Category
+	// Amount sums the lines of the category itself
+	Amount decimal.Decimal `json:"amount"`
+	// Total sums the lines of the category and of all its descendants
+	Total    decimal.Decimal `json:"total"`
+	Children []*CategoryNode `json:"children"`
+}
+
+// CategoryTreeTxParams contains all infos to total the categories of a user
+type CategoryTreeTxParams struct {
+	Owner     string `json:"owner"`
+	AccountID *int64 `json:"account_id"`
+	MonthID   *int64 `json:"month_id"`
+	YearID    *int64 `json:"year_id"`
+}
+
+// CategoryTreeTx returns the categories of a user as a tree, each one totalling the lines matching the filters
+func (store *SQLStore) CategoryTreeTx(ctx context.Context, arg CategoryTreeTxParams) ([]*CategoryNode, error) {
+	var result []*CategoryNode
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		categories, err := listAll(func(limit, offset int32) ([]Category, error) {
+			return q.ListCategories(ctx, ListCategoriesParams{Owner: arg.Owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		sums, err := q.SumLinesByCategory(ctx, SumLinesByCategoryParams{
+			Owner:     arg.Owner,
+			AccountID: arg.AccountID,
+			MonthID:   arg.MonthID,
+			YearID:    arg.YearID,
+		})
+		if err != nil {
+			return err
+		}
+
+		amounts := make(map[int64]decimal.Decimal, len(sums))
+		for _, sum := range sums {
+			amounts[sum.CategoryID] = sum.Amount
+		}
+
+		result = BuildCategoryTree(categories, amounts)
+		return nil
+	})
+
+	return result, err
+}
+
+// BuildCategoryTree nests categories under their parent and rolls the amounts of children up to their ancestors.
+// Categories whose parent is not in the list are roots, children keep the order of the list.
+func BuildCategoryTree(categories []Category, amounts map[int64]decimal.Decimal) []*CategoryNode {
+	nodes := make(map[int64]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{
+			Category: category,
+			Amount:   amounts[category.ID],
+			Children: []*CategoryNode{},
+		}
+	}
+
+	roots := []*CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		parent, ok := nodes[*category.ParentID]
+		if !ok {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	for _, root := range roots {
+		rollUp(root)
+	}
+
+	return roots
+}
+
+func rollUp(node *CategoryNode) decimal.Decimal {
+	node.Total = node.Amount
+	for _, child := range node.Children {
+		node.Total = node.Total.Add(rollUp(child))
+	}
+
+	return node.Total
+}
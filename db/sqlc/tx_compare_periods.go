@@ -0,0 +1,260 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Status of a category only one of the compared periods has
+const (
+	CategoryNew         = "NEW"
+	CategoryDisappeared = "DISAPPEARED"
+)
+
+// maxLineDeltas is the number of line changes kept in a comparison
+const maxLineDeltas = 10
+
+// ComparedPeriod is a month or a year of a comparison
+type ComparedPeriod struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// ComparedAmount is an amount in both periods and how it changed
+type ComparedAmount struct {
+	Base    decimal.Decimal `json:"base"`
+	Compare decimal.Decimal `json:"compare"`
+	Delta   decimal.Decimal `json:"delta"`
+	// Percent is the delta over the base amount, null when there is no base amount
+	Percent decimal.NullDecimal `json:"percent"`
+}
+
+// CategoryDelta is the change of the net amount of a category, expenses being negative
+type CategoryDelta struct {
+	CategoryID   int64  `json:"category_id"`
+	Category     string `json:"category"`
+	CategoryKind string `json:"category_kind"`
+	ComparedAmount
+	// Status is CategoryNew or CategoryDisappeared when only one period has the category
+	Status string `json:"status"`
+}
+
+// LineDelta is the change of the lines sharing a title in a category
+type LineDelta struct {
+	Title      string `json:"title"`
+	CategoryID int64  `json:"category_id"`
+	Category   string `json:"category"`
+	ComparedAmount
+}
+
+// PeriodComparison compares the lines of two months or two years, transfers left out
+type PeriodComparison struct {
+	Base    ComparedPeriod `json:"base"`
+	Compare ComparedPeriod `json:"compare"`
+	Income  ComparedAmount `json:"income"`
+	Expense ComparedAmount `json:"expense"`
+	Net     ComparedAmount `json:"net"`
+	// Categories holds every category of either period, the largest change first
+	Categories  []CategoryDelta `json:"categories"`
+	New         []CategoryDelta `json:"new"`
+	Disappeared []CategoryDelta `json:"disappeared"`
+	// Lines holds the largest line changes
+	Lines []LineDelta `json:"lines"`
+}
+
+// CompareMonthsTxParams contains all infos to compare two months
+type CompareMonthsTxParams struct {
+	Owner   string `json:"owner"`
+	Base    Month  `json:"base"`
+	Compare Month  `json:"compare"`
+}
+
+// CompareMonthsTx compares the lines of a month to those of a base month
+func (store *SQLStore) CompareMonthsTx(ctx context.Context, arg CompareMonthsTxParams) (PeriodComparison, error) {
+	var result PeriodComparison
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		baseRows, err := q.SumLinesByTitle(ctx, SumLinesByTitleParams{Owner: arg.Owner, MonthID: &arg.Base.ID})
+		if err != nil {
+			return err
+		}
+
+		compareRows, err := q.SumLinesByTitle(ctx, SumLinesByTitleParams{Owner: arg.Owner, MonthID: &arg.Compare.ID})
+		if err != nil {
+			return err
+		}
+
+		result = BuildPeriodComparison(
+			ComparedPeriod{ID: arg.Base.ID, Title: arg.Base.Title, StartDate: arg.Base.StartDate, EndDate: arg.Base.EndDate},
+			ComparedPeriod{ID: arg.Compare.ID, Title: arg.Compare.Title, StartDate: arg.Compare.StartDate, EndDate: arg.Compare.EndDate},
+			baseRows,
+			compareRows,
+		)
+		return nil
+	})
+
+	return result, err
+}
+
+// CompareYearsTxParams contains all infos to compare two years
+type CompareYearsTxParams struct {
+	Owner   string `json:"owner"`
+	Base    Year   `json:"base"`
+	Compare Year   `json:"compare"`
+}
+
+// CompareYearsTx compares the lines of a year to those of a base year
+func (store *SQLStore) CompareYearsTx(ctx context.Context, arg CompareYearsTxParams) (PeriodComparison, error) {
+	var result PeriodComparison
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		baseRows, err := q.SumLinesByTitle(ctx, SumLinesByTitleParams{Owner: arg.Owner, YearID: &arg.Base.ID})
+		if err != nil {
+			return err
+		}
+
+		compareRows, err := q.SumLinesByTitle(ctx, SumLinesByTitleParams{Owner: arg.Owner, YearID: &arg.Compare.ID})
+		if err != nil {
+			return err
+		}
+
+		result = BuildPeriodComparison(
+			ComparedPeriod{ID: arg.Base.ID, Title: arg.Base.Title, StartDate: arg.Base.StartDate, EndDate: arg.Base.EndDate},
+			ComparedPeriod{ID: arg.Compare.ID, Title: arg.Compare.Title, StartDate: arg.Compare.StartDate, EndDate: arg.Compare.EndDate},
+			baseRows,
+			compareRows,
+		)
+		return nil
+	})
+
+	return result, err
+}
+
+// BuildPeriodComparison compares the sums of the lines of two periods by category and by title
+func BuildPeriodComparison(base, compare ComparedPeriod, baseRows, compareRows []SumLinesByTitleRow) PeriodComparison {
+	result := PeriodComparison{
+		Base:        base,
+		Compare:     compare,
+		Categories:  []CategoryDelta{},
+		New:         []CategoryDelta{},
+		Disappeared: []CategoryDelta{},
+		Lines:       []LineDelta{},
+	}
+
+	type lineKey struct {
+		categoryID int64
+		title      string
+	}
+	var income, expense [2]decimal.Decimal
+	categories := map[int64]*CategoryDelta{}
+	lines := map[lineKey]*LineDelta{}
+	for side, rows := range [][]SumLinesByTitleRow{baseRows, compareRows} {
+		for _, row := range rows {
+			amount := row.Income.Sub(row.Expense)
+			income[side] = income[side].Add(row.Income)
+			expense[side] = expense[side].Add(row.Expense)
+
+			category, ok := categories[row.CategoryID]
+			if !ok {
+				category = &CategoryDelta{CategoryID: row.CategoryID, Category: row.Category, CategoryKind: row.CategoryKind}
+				categories[row.CategoryID] = category
+			}
+			line, ok := lines[lineKey{row.CategoryID, row.Title}]
+			if !ok {
+				line = &LineDelta{Title: row.Title, CategoryID: row.CategoryID, Category: row.Category}
+				lines[lineKey{row.CategoryID, row.Title}] = line
+			}
+
+			if side == 0 {
+				category.Base = category.Base.Add(amount)
+				line.Base = line.Base.Add(amount)
+			} else {
+				category.Compare = category.Compare.Add(amount)
+				line.Compare = line.Compare.Add(amount)
+			}
+		}
+	}
+
+	result.Income = compareAmounts(income[0], income[1])
+	result.Expense = compareAmounts(expense[0], expense[1])
+	result.Net = compareAmounts(income[0].Sub(expense[0]), income[1].Sub(expense[1]))
+
+	for _, category := range categories {
+		inBase := hasCategory(baseRows, category.CategoryID)
+		inCompare := hasCategory(compareRows, category.CategoryID)
+		category.ComparedAmount = compareAmounts(category.Base, category.Compare)
+		switch {
+		case !inBase:
+			category.Status = CategoryNew
+			result.New = append(result.New, *category)
+		case !inCompare:
+			category.Status = CategoryDisappeared
+			result.Disappeared = append(result.Disappeared, *category)
+		}
+		result.Categories = append(result.Categories, *category)
+	}
+	sortCategoryDeltas(result.Categories)
+	sortCategoryDeltas(result.New)
+	sortCategoryDeltas(result.Disappeared)
+
+	for _, line := range lines {
+		line.ComparedAmount = compareAmounts(line.Base, line.Compare)
+		if !line.Delta.IsZero() {
+			result.Lines = append(result.Lines, *line)
+		}
+	}
+	sort.Slice(result.Lines, func(i, j int) bool {
+		a, b := result.Lines[i], result.Lines[j]
+		if !a.Delta.Abs().Equal(b.Delta.Abs()) {
+			return a.Delta.Abs().GreaterThan(b.Delta.Abs())
+		}
+		if a.CategoryID != b.CategoryID {
+			return a.CategoryID < b.CategoryID
+		}
+		return a.Title < b.Title
+	})
+	if len(result.Lines) > maxLineDeltas {
+		result.Lines = result.Lines[:maxLineDeltas]
+	}
+
+	return result
+}
+
+// compareAmounts returns how an amount changed from base to compare
+func compareAmounts(base, compare decimal.Decimal) ComparedAmount {
+	result := ComparedAmount{
+		Base:    base,
+		Compare: compare,
+		Delta:   compare.Sub(base),
+	}
+	if !base.IsZero() {
+		result.Percent = decimal.NewNullDecimal(result.Delta.Mul(decimal.NewFromInt(100)).Div(base.Abs()).Round(2))
+	}
+
+	return result
+}
+
+func hasCategory(rows []SumLinesByTitleRow, categoryID int64) bool {
+	for _, row := range rows {
+		if row.CategoryID == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// sortCategoryDeltas puts the largest change first
+func sortCategoryDeltas(categories []CategoryDelta) {
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		if !a.Delta.Abs().Equal(b.Delta.Abs()) {
+			return a.Delta.Abs().GreaterThan(b.Delta.Abs())
+		}
+		return a.CategoryID < b.CategoryID
+	})
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// ErrGoalContributionCategory is returned when planning the contribution of a goal without category
+var ErrGoalContributionCategory = errors.New("a category is required to plan the goal contribution")
+
+// CreateGoalTxParams contains all infos to create a savings goal
+type CreateGoalTxParams struct {
+	Owner        string          `json:"owner"`
+	Title        string          `json:"title"`
+	AccountID    int64           `json:"account_id"`
+	CategoryID   *int64          `json:"category_id"`
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	TargetDate   time.Time       `json:"target_date"`
+	// PlanContribution creates a monthly recline of the contribution, starting on Date
+	PlanContribution bool      `json:"plan_contribution"`
+	Date             time.Time `json:"date"`
+}
+
+// CreateGoalTxResult contains the created goal and its planned contribution
+type CreateGoalTxResult struct {
+	Progress GoalProgress `json:"progress"`
+	RecLine  *Recline     `json:"recline,omitempty"`
+}
+
+// CreateGoalTx creates a goal, with the recline of its monthly contribution when asked for
+func (store *SQLStore) CreateGoalTx(ctx context.Context, arg CreateGoalTxParams) (CreateGoalTxResult, error) {
+	var result CreateGoalTxResult
+
+	if arg.PlanContribution && arg.CategoryID == nil {
+		return result, ErrGoalContributionCategory
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		argGoal := CreateGoalParams{
+			Owner:        arg.Owner,
+			Title:        arg.Title,
+			AccountID:    arg.AccountID,
+			CategoryID:   arg.CategoryID,
+			TargetAmount: arg.TargetAmount,
+			TargetDate:   arg.TargetDate,
+		}
+
+		// Lines of the category may already be saved for the goal
+		progress, err := goalProgress(ctx, q, Goal{
+			AccountID:    arg.AccountID,
+			CategoryID:   arg.CategoryID,
+			TargetAmount: arg.TargetAmount,
+			TargetDate:   arg.TargetDate,
+			CreateAt:     arg.Date,
+		}, arg.Date)
+		if err != nil {
+			return err
+		}
+
+		if arg.PlanContribution && progress.MonthlyContribution.IsPositive() {
+			recline, err := q.CreateRecLine(ctx, CreateRecLineParams{
+				Title:       arg.Title,
+				Owner:       arg.Owner,
+				AccountID:   arg.AccountID,
+				CategoryID:  *arg.CategoryID,
+				Amount:      progress.MonthlyContribution,
+				Description: fmt.Sprintf("Contribution to %s", arg.Title),
+				Recurrency:  util.MONTHLY,
+				DueDate:     arg.Date,
+			})
+			if err != nil {
+				return err
+			}
+			result.RecLine = &recline
+			argGoal.ReclineID = &recline.ID
+		}
+
+		progress.Goal, err = q.CreateGoal(ctx, argGoal)
+		result.Progress = progress
+		return err
+	})
+
+	return result, err
+}
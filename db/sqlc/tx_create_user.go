@@ -0,0 +1,45 @@
+package db
+
+import "context"
+
+// CreateUserTxParams contains all infos to create a new user
+type CreateUserTxParams struct {
+	CreateUserParams
+	// SkipDefaultCategories leaves the new user without categories
+	SkipDefaultCategories bool `json:"skip_default_categories"`
+}
+
+// CreateUserTxResult contains all infos about the result of user creation
+type CreateUserTxResult struct {
+	User       User       `json:"user"`
+	Categories []Category `json:"categories"`
+}
+
+// CreateUserTx creates a user with the default categories of its locale, so its first lines have somewhere to go
+func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
+	var result CreateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.User, err = q.CreateUser(ctx, arg.CreateUserParams)
+		if err != nil {
+			return err
+		}
+
+		result.Categories = []Category{}
+		if arg.SkipDefaultCategories {
+			return nil
+		}
+
+		var seeded SeedCategoriesTxResult
+		err = seedCategories(ctx, q, SeedCategoriesTxParams{
+			Owner:  result.User.Username,
+			Locale: result.User.Locale,
+		}, &seeded)
+		result.Categories = seeded.Created
+
+		return err
+	})
+
+	return result, err
+}
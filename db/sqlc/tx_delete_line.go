@@ -15,6 +15,8 @@ type DeleteLineTxParams struct {
 // AddLineTxResult contains all infos about the result of line creation
 type DeleteLineTxResult struct {
 	Balance util.Balance `json:"balance"`
+	// Line is the deleted line
+	Line Line `json:"line"`
 }
 
 func (store *SQLStore) DeleteLineTx(ctx context.Context, arg DeleteLineTxParams) (DeleteLineTxResult, error) {
@@ -27,6 +29,7 @@ func (store *SQLStore) DeleteLineTx(ctx context.Context, arg DeleteLineTxParams)
 		if err != nil {
 			return err
 		}
+		result.Line = line
 
 		argAdd := addMoneyTxParams{
 			Amount: decimal.Zero,
@@ -34,6 +37,7 @@ func (store *SQLStore) DeleteLineTx(ctx context.Context, arg DeleteLineTxParams)
 			AccountID: line.AccountID,
 			MonthID: line.MonthID,
 			YearID: line.YearID,
+			DueDate: line.DueDate,
 		}
 
 		if line.Checked {
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+
+	"github.com/moth13/finance_tracker/anomaly"
+)
+
+// DetectAnomaliesTxParams contains all infos to look for unusual charges in the lines of a user
+type DetectAnomaliesTxParams struct {
+	Owner string `json:"owner"`
+}
+
+// DetectAnomaliesTxResult contains the anomalies found on the lines, dismissed ones included
+type DetectAnomaliesTxResult struct {
+	Anomalies []LineAnomaly `json:"anomalies"`
+	// Removed is the number of anomalies no longer found, as the line was fixed
+	Removed int64 `json:"removed"`
+}
+
+// DetectAnomaliesTx flags the unusual charges of a user and removes the flags which no longer hold.
+// Dismissed flags are kept, so a line dismissed once is not reported again.
+func (store *SQLStore) DetectAnomaliesTx(ctx context.Context, arg DetectAnomaliesTxParams) (DetectAnomaliesTxResult, error) {
+	result := DetectAnomaliesTxResult{Anomalies: []LineAnomaly{}}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		rows, err := q.ListChargeLines(ctx, arg.Owner)
+		if err != nil {
+			return err
+		}
+
+		lines := make([]anomaly.Line, 0, len(rows))
+		for _, row := range rows {
+			lines = append(lines, anomaly.Line{
+				ID:         row.ID,
+				Title:      row.Title,
+				Payee:      row.Payee,
+				CategoryID: row.CategoryID,
+				Amount:     row.Amount,
+				DueDate:    row.DueDate,
+			})
+		}
+
+		keepIDs := []int64{}
+		for _, flag := range anomaly.Detect(lines) {
+			lineAnomaly, err := q.CreateLineAnomaly(ctx, CreateLineAnomalyParams{
+				Owner:  arg.Owner,
+				LineID: flag.LineID,
+				Kind:   flag.Kind,
+				Reason: flag.Reason,
+			})
+			if err != nil {
+				return err
+			}
+			result.Anomalies = append(result.Anomalies, lineAnomaly)
+			keepIDs = append(keepIDs, lineAnomaly.ID)
+		}
+
+		result.Removed, err = q.DeleteStaleLineAnomalies(ctx, DeleteStaleLineAnomaliesParams{
+			Owner:   arg.Owner,
+			KeepIds: keepIDs,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/moth13/finance_tracker/forecast"
+)
+
+// ForecastTxParams contains all infos to forecast the balance of the accounts of a user
+type ForecastTxParams struct {
+	Owner string `json:"owner"`
+	// AccountID limits the forecast to one account when set
+	AccountID *int64    `json:"account_id"`
+	From      time.Time `json:"from"`
+	Days      int       `json:"days"`
+}
+
+// ForecastTx projects the daily balance of the accounts of a user from their unchecked lines and reclines
+func (store *SQLStore) ForecastTx(ctx context.Context, arg ForecastTxParams) (forecast.Forecast, error) {
+	var result forecast.Forecast
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		from := time.Date(arg.From.Year(), arg.From.Month(), arg.From.Day(), 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 0, arg.Days-1)
+		inForecast := func(accountID int64) bool {
+			return arg.AccountID == nil || *arg.AccountID == accountID
+		}
+
+		accounts, err := listAll(func(limit, offset int32) ([]Account, error) {
+			return q.ListAccounts(ctx, ListAccountsParams{Owner: arg.Owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		// The balance of an account leaves its initial balance out
+		starts := []forecast.Account{}
+		for _, account := range accounts {
+			if !inForecast(account.ID) {
+				continue
+			}
+			starts = append(starts, forecast.Account{
+				ID:      account.ID,
+				Title:   account.Title,
+				Balance: account.InitBalance.Add(account.Balance),
+			})
+		}
+
+		lines, err := q.ListUncheckedLinesBefore(ctx, ListUncheckedLinesBeforeParams{
+			Owner:  arg.Owner,
+			ToDate: to.AddDate(0, 0, 1),
+		})
+		if err != nil {
+			return err
+		}
+
+		movements := []forecast.Movement{}
+		for _, line := range lines {
+			if !inForecast(line.AccountID) {
+				continue
+			}
+			movements = append(movements, forecast.Movement{
+				AccountID: line.AccountID,
+				Title:     line.Title,
+				Amount:    line.Amount,
+				Date:      line.DueDate,
+			})
+		}
+
+		// Checked lines are in the balance already, they still stand for their recline occurrence
+		created, err := q.ListLinesBetween(ctx, ListLinesBetweenParams{
+			Owner:    arg.Owner,
+			FromDate: from,
+			ToDate:   to,
+		})
+		if err != nil {
+			return err
+		}
+
+		planned := map[plannedMovement]bool{}
+		for _, line := range created {
+			planned[plannedKey(forecast.Movement{
+				AccountID: line.AccountID,
+				Title:     line.Title,
+				Date:      line.DueDate,
+			})] = true
+		}
+
+		reclines, err := listAll(func(limit, offset int32) ([]Recline, error) {
+			return q.ListRecLines(ctx, ListRecLinesParams{Owner: arg.Owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		// An occurrence already created as a line is not counted twice
+		for _, recline := range reclines {
+			if !inForecast(recline.AccountID) {
+				continue
+			}
+			occurrences := forecast.Occurrences(forecast.Recurrence{
+				AccountID:  recline.AccountID,
+				Title:      recline.Title,
+				Amount:     recline.Amount,
+				DueDate:    recline.DueDate,
+				Recurrency: recline.Recurrency,
+			}, from, to)
+			for _, occurrence := range occurrences {
+				if !planned[plannedKey(occurrence)] {
+					movements = append(movements, occurrence)
+				}
+			}
+		}
+
+		result = forecast.Project(starts, movements, from, arg.Days)
+		return nil
+	})
+
+	return result, err
+}
+
+// plannedMovement identifies a movement by its account, title and day
+type plannedMovement struct {
+	accountID int64
+	title     string
+	date      time.Time
+}
+
+func plannedKey(movement forecast.Movement) plannedMovement {
+	date := movement.Date
+	return plannedMovement{
+		accountID: movement.AccountID,
+		title:     movement.Title,
+		date:      time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Status of a savings goal
+const (
+	GoalAchieved = "ACHIEVED"
+	GoalOnTrack  = "ON_TRACK"
+	GoalBehind   = "BEHIND"
+	GoalOverdue  = "OVERDUE"
+)
+
+// GoalProgress compares what was saved for a goal to its target
+type GoalProgress struct {
+	Goal Goal `json:"goal"`
+	// Saved only counts checked lines, Projected counts every line
+	Saved     decimal.Decimal `json:"saved"`
+	Projected decimal.Decimal `json:"projected"`
+	Remaining decimal.Decimal `json:"remaining"`
+	Percent   decimal.Decimal `json:"percent"`
+	// MonthsLeft counts the current month and the month of the target date
+	MonthsLeft int `json:"months_left"`
+	// MonthlyContribution is what to save every month left to reach the target on time
+	MonthlyContribution decimal.Decimal `json:"monthly_contribution"`
+	Status              string          `json:"status"`
+}
+
+// GoalProgressTxParams contains all infos to compute the progress of a goal
+type GoalProgressTxParams struct {
+	Goal Goal      `json:"goal"`
+	Date time.Time `json:"date"`
+}
+
+// GoalProgressTx returns the progress of a goal on a date
+func (store *SQLStore) GoalProgressTx(ctx context.Context, arg GoalProgressTxParams) (GoalProgress, error) {
+	var result GoalProgress
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = goalProgress(ctx, q, arg.Goal, arg.Date)
+		return err
+	})
+
+	return result, err
+}
+
+// ListGoalProgressTxParams contains all infos to compute the progress of the goals of a user
+type ListGoalProgressTxParams struct {
+	Owner string    `json:"owner"`
+	Date  time.Time `json:"date"`
+}
+
+// ListGoalProgressTx returns the progress of every goal of a user, the closest target first
+func (store *SQLStore) ListGoalProgressTx(ctx context.Context, arg ListGoalProgressTxParams) ([]GoalProgress, error) {
+	result := []GoalProgress{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		goals, err := listAll(func(limit, offset int32) ([]Goal, error) {
+			return q.ListGoals(ctx, ListGoalsParams{Owner: arg.Owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, goal := range goals {
+			progress, err := goalProgress(ctx, q, goal, arg.Date)
+			if err != nil {
+				return err
+			}
+			result = append(result, progress)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// goalProgress sums the lines of the goal category on its account.
+// Without category the whole account counts, its initial balance included.
+func goalProgress(ctx context.Context, q *Queries, goal Goal, date time.Time) (GoalProgress, error) {
+	if goal.CategoryID == nil {
+		account, err := q.GetAccount(ctx, goal.AccountID)
+		if err != nil {
+			return GoalProgress{}, err
+		}
+		saved := account.InitBalance.Add(account.Balance)
+		projected := account.InitBalance.Add(account.FinalBalance)
+		return BuildGoalProgress(goal, saved, projected, date), nil
+	}
+
+	sums, err := q.SumGoalLines(ctx, SumGoalLinesParams{
+		AccountID:  goal.AccountID,
+		CategoryID: *goal.CategoryID,
+	})
+	if err != nil {
+		return GoalProgress{}, err
+	}
+
+	return BuildGoalProgress(goal, sums.Saved, sums.Projected, date), nil
+}
+
+// BuildGoalProgress computes the monthly contribution left to reach a goal on time.
+// A goal is behind when less was saved than a steady saving from its creation to its target date would have.
+func BuildGoalProgress(goal Goal, saved, projected decimal.Decimal, date time.Time) GoalProgress {
+	result := GoalProgress{
+		Goal:       goal,
+		Saved:      saved,
+		Projected:  projected,
+		Remaining:  decimal.Max(goal.TargetAmount.Sub(saved), decimal.Zero),
+		Percent:    decimal.Zero,
+		MonthsLeft: monthsLeft(date, goal.TargetDate),
+	}
+	if goal.TargetAmount.IsPositive() {
+		result.Percent = saved.Mul(decimal.NewFromInt(100)).Div(goal.TargetAmount).Round(2)
+	}
+
+	result.MonthlyContribution = result.Remaining
+	if result.MonthsLeft > 1 {
+		result.MonthlyContribution = result.Remaining.Div(decimal.NewFromInt(int64(result.MonthsLeft))).RoundCeil(2)
+	}
+
+	switch {
+	case result.Remaining.IsZero():
+		result.Status = GoalAchieved
+	case result.MonthsLeft == 0:
+		result.Status = GoalOverdue
+	case saved.LessThan(expectedSaving(goal, date)):
+		result.Status = GoalBehind
+	default:
+		result.Status = GoalOnTrack
+	}
+
+	return result
+}
+
+// monthsLeft counts the months from date to the target date, both included, zero once the target date passed
+func monthsLeft(date, target time.Time) int {
+	if dateOnly(date).After(dateOnly(target)) {
+		return 0
+	}
+
+	return (target.Year()-date.Year())*12 + int(target.Month()-date.Month()) + 1
+}
+
+// expectedSaving is what a steady saving from the creation of the goal would have put aside on date
+func expectedSaving(goal Goal, date time.Time) decimal.Decimal {
+	start := dateOnly(goal.CreateAt)
+	total := dateOnly(goal.TargetDate).Sub(start)
+	elapsed := dateOnly(date).Sub(start)
+	if total <= 0 || elapsed >= total {
+		return goal.TargetAmount
+	}
+	if elapsed <= 0 {
+		return decimal.Zero
+	}
+
+	return goal.TargetAmount.Mul(decimal.NewFromInt(int64(elapsed))).Div(decimal.NewFromInt(int64(total)))
+}
+
+func dateOnly(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	decimal "github.com/shopspring/decimal"
+)
+
+// errDryRun rolls back an import once its report is built
+var errDryRun = errors.New("dry run")
+
+// ImportAccountParams contains all infos about an account read from another application
+type ImportAccountParams struct {
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	InitBalance decimal.Decimal `json:"init_balance"`
+}
+
+// ImportRecLineParams contains all infos about a scheduled operation read from another application
+type ImportRecLineParams struct {
+	Account     string          `json:"account"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Category    string          `json:"category"`
+	Amount      decimal.Decimal `json:"amount"`
+	Recurrency  string          `json:"recurrency"`
+	DueDate     time.Time       `json:"due_date"`
+}
+
+// ImportBookTxParams contains all infos to import the data of another application
+type ImportBookTxParams struct {
+	Owner    string                `json:"owner"`
+	DryRun   bool                  `json:"dry_run"`
+	Accounts []ImportAccountParams `json:"accounts"`
+	Lines    []ImportLineParams    `json:"lines"`
+	RecLines []ImportRecLineParams `json:"reclines"`
+}
+
+// ImportBookTxResult is the report of an import, what has been or would be created
+type ImportBookTxResult struct {
+	DryRun     bool       `json:"dry_run"`
+	Accounts   []Account  `json:"accounts"`
+	Categories []Category `json:"categories"`
+	Years      []Year     `json:"years"`
+	Months     []Month    `json:"months"`
+	RecLines   []Recline  `json:"reclines"`
+	Lines      int        `json:"lines"`
+	Skipped    int        `json:"skipped"`
+}
+
+// ImportBookTx creates accounts, categories, periods, reclines and lines of an import in a single transaction.
+// Accounts are matched by title; a dry run builds the same report and rolls everything back.
+func (store *SQLStore) ImportBookTx(ctx context.Context, arg ImportBookTxParams) (ImportBookTxResult, error) {
+	result := ImportBookTxResult{
+		DryRun:   arg.DryRun,
+		Accounts: []Account{},
+		RecLines: []Recline{},
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		li := newLineImporter(q, arg.Owner, true)
+		accounts := map[string]int64{}
+		var accountIDs []int64
+
+		accountID := func(title string, description string, initBalance decimal.Decimal) (int64, error) {
+			if id, ok := accounts[title]; ok {
+				return id, nil
+			}
+
+			account, err := q.GetAccountByTitle(ctx, GetAccountByTitleParams{
+				Owner: arg.Owner,
+				Title: title,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				account, err = q.CreateAccount(ctx, CreateAccountParams{
+					Owner:       arg.Owner,
+					Title:       title,
+					Description: description,
+					InitBalance: initBalance,
+				})
+			}
+			if err != nil {
+				return 0, err
+			}
+
+			accounts[title] = account.ID
+			accountIDs = append(accountIDs, account.ID)
+			return account.ID, nil
+		}
+
+		for _, account := range arg.Accounts {
+			if _, err := accountID(account.Title, account.Description, account.InitBalance); err != nil {
+				return err
+			}
+		}
+
+		for _, line := range arg.Lines {
+			id, err := accountID(line.Account, "", decimal.Zero)
+			if err != nil {
+				return err
+			}
+
+			_, _, imported, err := li.importLine(ctx, id, 0, line)
+			if err != nil {
+				return err
+			}
+			if !imported {
+				result.Skipped++
+				continue
+			}
+			result.Lines++
+		}
+
+		for _, recline := range arg.RecLines {
+			id, err := accountID(recline.Account, "", decimal.Zero)
+			if err != nil {
+				return err
+			}
+
+			// Scheduled operations already imported are kept as is
+			_, err = q.GetRecLineByTitle(ctx, GetRecLineByTitleParams{
+				Owner:     arg.Owner,
+				AccountID: id,
+				Title:     recline.Title,
+			})
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, pgx.ErrNoRows) {
+				return err
+			}
+
+			categoryID, err := li.categoryID(ctx, recline.Category, 0)
+			if err != nil {
+				return err
+			}
+
+			created, err := q.CreateRecLine(ctx, CreateRecLineParams{
+				Title:       recline.Title,
+				Owner:       arg.Owner,
+				AccountID:   id,
+				CategoryID:  categoryID,
+				Amount:      recline.Amount,
+				Description: recline.Description,
+				Recurrency:  recline.Recurrency,
+				DueDate:     recline.DueDate,
+			})
+			if err != nil {
+				return err
+			}
+			result.RecLines = append(result.RecLines, created)
+		}
+
+		// Report accounts with their balances once all lines are in
+		for _, id := range accountIDs {
+			account, err := q.GetAccount(ctx, id)
+			if err != nil {
+				return err
+			}
+			result.Accounts = append(result.Accounts, account)
+		}
+
+		result.Categories = li.createdCategories
+		result.Years = li.createdYears
+		result.Months = li.createdMonths
+
+		if arg.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+
+	if errors.Is(err, errDryRun) {
+		return result, nil
+	}
+
+	return result, err
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// ImportLineParams contains all infos about a line read from an external file
+type ImportLineParams struct {
+	Account     string          `json:"account"`
+	Title       string          `json:"title"`
+	Amount      decimal.Decimal `json:"amount"`
+	Checked     bool            `json:"checked"`
+	Description string          `json:"description"`
+	DueDate     time.Time       `json:"due_date"`
+	Category    string          `json:"category"`
+	Reference   string          `json:"reference"`
+}
+
+// ImportLinesTxParams contains all infos to import lines into an account
+type ImportLinesTxParams struct {
+	Owner      string             `json:"owner"`
+	AccountID  int64              `json:"account_id"`
+	CategoryID int64              `json:"category_id"`
+	Lines      []ImportLineParams `json:"lines"`
+}
+
+// ImportLinesTxResult contains all infos about the result of lines import
+type ImportLinesTxResult struct {
+	Lines   []Line       `json:"lines"`
+	Skipped int          `json:"skipped"`
+	Balance util.Balance `json:"balance"`
+}
+
+// ImportLinesTx creates the lines of an import in a single transaction.
+// Lines already imported in the account with the same date, amount and reference are skipped.
+func (store *SQLStore) ImportLinesTx(ctx context.Context, arg ImportLinesTxParams) (ImportLinesTxResult, error) {
+	result := ImportLinesTxResult{Lines: []Line{}}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		li := newLineImporter(q, arg.Owner, false)
+
+		for _, line := range arg.Lines {
+			created, balance, imported, err := li.importLine(ctx, arg.AccountID, arg.CategoryID, line)
+			if err != nil {
+				return err
+			}
+			if !imported {
+				result.Skipped++
+				continue
+			}
+
+			result.Balance = balance
+			result.Lines = append(result.Lines, created)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// ErrCategoryInUse is returned when deleting a category still used, without replacement
+var ErrCategoryInUse = errors.New("category is in use, a replacement category is required")
+
+// MergeCategoryTxParams contains all infos to merge a category into another one
+type MergeCategoryTxParams struct {
+	SourceID int64 `json:"source_id"`
+	TargetID int64 `json:"target_id"`
+}
+
+// MergeCategoryTxResult contains all infos about what a merge moved to the target category
+type MergeCategoryTxResult struct {
+	Category  Category `json:"category"`
+	Lines     int64    `json:"lines"`
+	RecLines  int64    `json:"reclines"`
+	Rules     int64    `json:"rules"`
+	Goals     int64    `json:"goals"`
+	Budgets   int64    `json:"budgets"`
+	Envelopes int64    `json:"envelopes"`
+	Children  int64    `json:"children"`
+}
+
+// MergeCategoryTx moves the lines, reclines, rules, goals, budgets, envelopes and children of a category to another one,
+// then deletes it
+func (store *SQLStore) MergeCategoryTx(ctx context.Context, arg MergeCategoryTxParams) (MergeCategoryTxResult, error) {
+	var result MergeCategoryTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = mergeCategory(ctx, q, arg.SourceID, arg.TargetID)
+		return err
+	})
+
+	return result, err
+}
+
+func mergeCategory(ctx context.Context, q *Queries, sourceID, targetID int64) (MergeCategoryTxResult, error) {
+	var result MergeCategoryTxResult
+
+	source, err := q.GetCategoryForUpdate(ctx, sourceID)
+	if err != nil {
+		return result, err
+	}
+
+	// The children move as well, so the tree is locked like for MoveCategoryTx
+	if err := q.LockCategoryTree(ctx, source.Owner); err != nil {
+		return result, err
+	}
+
+	// The children of the source go under the target, which must not be one of them
+	ancestors, err := q.ListCategoryAncestors(ctx, targetID)
+	if err != nil {
+		return result, err
+	}
+	if slices.Contains(ancestors, sourceID) {
+		return result, ErrCategoryCycle
+	}
+
+	result.Category, err = q.GetCategoryForUpdate(ctx, targetID)
+	if err != nil {
+		return result, err
+	}
+
+	reassign := ReassignLinesCategoryParams{TargetID: targetID, SourceID: sourceID}
+	if result.Lines, err = q.ReassignLinesCategory(ctx, reassign); err != nil {
+		return result, err
+	}
+	if result.RecLines, err = q.ReassignRecLinesCategory(ctx, ReassignRecLinesCategoryParams(reassign)); err != nil {
+		return result, err
+	}
+	if result.Rules, err = q.ReassignRulesCategory(ctx, ReassignRulesCategoryParams(reassign)); err != nil {
+		return result, err
+	}
+	if result.Goals, err = q.ReassignGoalsCategory(ctx, ReassignGoalsCategoryParams(reassign)); err != nil {
+		return result, err
+	}
+	// Budgets of the source are added to the ones the target has for the same months
+	if result.Budgets, err = q.ReassignBudgetsCategory(ctx, ReassignBudgetsCategoryParams(reassign)); err != nil {
+		return result, err
+	}
+	// Assigned amounts are summed as well, so rollovers and what is left to budget stay the same
+	if result.Envelopes, err = q.ReassignEnvelopeAssignmentsCategory(ctx, ReassignEnvelopeAssignmentsCategoryParams(reassign)); err != nil {
+		return result, err
+	}
+	if result.Children, err = q.MoveCategoryChildren(ctx, MoveCategoryChildrenParams{
+		ParentID:    &targetID,
+		OldParentID: &sourceID,
+	}); err != nil {
+		return result, err
+	}
+
+	return result, q.DeleteCategory(ctx, sourceID)
+}
+
+// DeleteCategoryTxParams contains all infos to delete a category
+type DeleteCategoryTxParams struct {
+	ID int64 `json:"id"`
+	// ReplacementID receives what uses the category, it is required when the category is in use
+	ReplacementID *int64 `json:"replacement_id"`
+}
+
+// DeleteCategoryTx deletes a category. A category used by lines, reclines, rules, budgets, envelopes or goals is merged
+// into its replacement, without replacement it is refused. The children of an unused category go to its parent.
+func (store *SQLStore) DeleteCategoryTx(ctx context.Context, arg DeleteCategoryTxParams) (MergeCategoryTxResult, error) {
+	var result MergeCategoryTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.ReplacementID != nil {
+			var err error
+			result, err = mergeCategory(ctx, q, arg.ID, *arg.ReplacementID)
+			return err
+		}
+
+		category, err := q.GetCategoryForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		usage, err := q.GetCategoryUsage(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if usage.Lines+usage.Reclines+usage.Rules+usage.Budgets+usage.Envelopes+usage.Goals > 0 {
+			return ErrCategoryInUse
+		}
+
+		if result.Children, err = q.MoveCategoryChildren(ctx, MoveCategoryChildrenParams{
+			ParentID:    category.ParentID,
+			OldParentID: &category.ID,
+		}); err != nil {
+			return err
+		}
+
+		return q.DeleteCategory(ctx, arg.ID)
+	})
+
+	return result, err
+}
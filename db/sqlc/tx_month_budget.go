@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// BudgetProgress compares a budget to the lines of its category and of the category children
+type BudgetProgress struct {
+	Budget   Budget   `json:"budget"`
+	Category Category `json:"category"`
+	// CategoryIDs lists the category and all its descendants
+	CategoryIDs []int64 `json:"category_ids"`
+	// Spent only counts checked lines, Projected counts every line of the month
+	Spent              decimal.Decimal `json:"spent"`
+	Projected          decimal.Decimal `json:"projected"`
+	Remaining          decimal.Decimal `json:"remaining"`
+	ProjectedRemaining decimal.Decimal `json:"projected_remaining"`
+}
+
+// MonthBudget is the budget report of a month
+type MonthBudget struct {
+	MonthID int64            `json:"month_id"`
+	Budgets []BudgetProgress `json:"budgets"`
+	// Totals leave out income budgets and budgets nested under another budget
+	Budgeted  decimal.Decimal `json:"budgeted"`
+	Spent     decimal.Decimal `json:"spent"`
+	Projected decimal.Decimal `json:"projected"`
+}
+
+// MonthBudgetTxParams contains all infos to compare the budgets of a month to its lines
+type MonthBudgetTxParams struct {
+	Owner   string `json:"owner"`
+	MonthID int64  `json:"month_id"`
+}
+
+// MonthBudgetTx returns the budgets applying to a month with what was spent in their categories
+func (store *SQLStore) MonthBudgetTx(ctx context.Context, arg MonthBudgetTxParams) (MonthBudget, error) {
+	result := MonthBudget{MonthID: arg.MonthID, Budgets: []BudgetProgress{}}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		budgets, err := q.ListMonthBudgets(ctx, ListMonthBudgetsParams{
+			Owner:   arg.Owner,
+			MonthID: arg.MonthID,
+		})
+		if err != nil || len(budgets) == 0 {
+			return err
+		}
+
+		categories, err := listAll(func(limit, offset int32) ([]Category, error) {
+			return q.ListCategories(ctx, ListCategoriesParams{Owner: arg.Owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		sums, err := q.SumLinesByCategory(ctx, SumLinesByCategoryParams{
+			Owner:   arg.Owner,
+			MonthID: &arg.MonthID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = BuildMonthBudget(arg.MonthID, categories, budgets, sums)
+		return nil
+	})
+
+	return result, err
+}
+
+// BuildMonthBudget rolls the sums of the lines up the category tree and compares them to the budgets.
+// Spending is counted as a positive amount, except for income categories whose budget is what is expected to come in.
+func BuildMonthBudget(monthID int64, categories []Category, budgets []Budget, sums []SumLinesByCategoryRow) MonthBudget {
+	result := MonthBudget{MonthID: monthID, Budgets: []BudgetProgress{}}
+
+	projected := make(map[int64]decimal.Decimal, len(sums))
+	checked := make(map[int64]decimal.Decimal, len(sums))
+	for _, sum := range sums {
+		projected[sum.CategoryID] = sum.Amount
+		checked[sum.CategoryID] = sum.CheckedAmount
+	}
+
+	projectedNodes := map[int64]*CategoryNode{}
+	collectNodes(BuildCategoryTree(categories, projected), projectedNodes)
+	checkedNodes := map[int64]*CategoryNode{}
+	collectNodes(BuildCategoryTree(categories, checked), checkedNodes)
+
+	budgeted := make(map[int64]bool, len(budgets))
+	for _, budget := range budgets {
+		budgeted[budget.CategoryID] = true
+	}
+
+	for _, budget := range budgets {
+		node, ok := projectedNodes[budget.CategoryID]
+		if !ok {
+			continue
+		}
+
+		sign := decimal.NewFromInt(-1)
+		if node.Kind == util.INCOME {
+			sign = decimal.NewFromInt(1)
+		}
+
+		progress := BudgetProgress{
+			Budget:      budget,
+			Category:    node.Category,
+			CategoryIDs: subtreeIDs(node, nil),
+			Spent:       checkedNodes[budget.CategoryID].Total.Mul(sign),
+			Projected:   node.Total.Mul(sign),
+		}
+		progress.Remaining = budget.Amount.Sub(progress.Spent)
+		progress.ProjectedRemaining = budget.Amount.Sub(progress.Projected)
+		result.Budgets = append(result.Budgets, progress)
+
+		if node.Kind == util.INCOME || hasBudgetedAncestor(node.Category, projectedNodes, budgeted) {
+			continue
+		}
+		result.Budgeted = result.Budgeted.Add(budget.Amount)
+		result.Spent = result.Spent.Add(progress.Spent)
+		result.Projected = result.Projected.Add(progress.Projected)
+	}
+
+	return result
+}
+
+func collectNodes(nodes []*CategoryNode, index map[int64]*CategoryNode) {
+	for _, node := range nodes {
+		index[node.ID] = node
+		collectNodes(node.Children, index)
+	}
+}
+
+func subtreeIDs(node *CategoryNode, ids []int64) []int64 {
+	ids = append(ids, node.ID)
+	for _, child := range node.Children {
+		ids = subtreeIDs(child, ids)
+	}
+
+	return ids
+}
+
+func hasBudgetedAncestor(category Category, nodes map[int64]*CategoryNode, budgeted map[int64]bool) bool {
+	for category.ParentID != nil {
+		parent, ok := nodes[*category.ParentID]
+		if !ok {
+			return false
+		}
+		if budgeted[parent.ID] {
+			return true
+		}
+		category = parent.Category
+	}
+
+	return false
+}
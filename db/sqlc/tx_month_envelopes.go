@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// Envelope is the money of a category for a month. Each category other than income is its own envelope,
+// what is left at the end of a month rolls over to the next one, overspending included.
+type Envelope struct {
+	Category Category `json:"category"`
+	// Carried is what previous months left in the envelope, negative when they overspent it
+	Carried  decimal.Decimal `json:"carried"`
+	Assigned decimal.Decimal `json:"assigned"`
+	// Activity sums the lines of the month, spending is negative
+	Activity  decimal.Decimal `json:"activity"`
+	Available decimal.Decimal `json:"available"`
+}
+
+// MonthEnvelopes is the envelope accounting of a month
+type MonthEnvelopes struct {
+	MonthID int64 `json:"month_id"`
+	// Income sums the lines of income categories in the month
+	Income   decimal.Decimal `json:"income"`
+	Assigned decimal.Decimal `json:"assigned"`
+	// ToBeBudgeted is the income of the month not assigned to an envelope yet
+	ToBeBudgeted decimal.Decimal `json:"to_be_budgeted"`
+	Envelopes    []Envelope      `json:"envelopes"`
+}
+
+// MonthEnvelopesTxParams contains all infos to read the envelopes of a month
+type MonthEnvelopesTxParams struct {
+	Owner   string `json:"owner"`
+	MonthID int64  `json:"month_id"`
+}
+
+// MonthEnvelopesTx returns the envelopes of a month with what previous months rolled over
+func (store *SQLStore) MonthEnvelopesTx(ctx context.Context, arg MonthEnvelopesTxParams) (MonthEnvelopes, error) {
+	var result MonthEnvelopes
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		categories, err := listAll(func(limit, offset int32) ([]Category, error) {
+			return q.ListCategories(ctx, ListCategoriesParams{Owner: arg.Owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		assignments, err := q.SumEnvelopeAssignments(ctx, SumEnvelopeAssignmentsParams{
+			MonthID: arg.MonthID,
+			Owner:   arg.Owner,
+		})
+		if err != nil {
+			return err
+		}
+
+		activity, err := q.SumEnvelopeActivity(ctx, SumEnvelopeActivityParams{
+			MonthID: arg.MonthID,
+			Owner:   arg.Owner,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = BuildMonthEnvelopes(arg.MonthID, categories, assignments, activity)
+		return nil
+	})
+
+	return result, err
+}
+
+// BuildMonthEnvelopes computes the envelopes of a month from the assignments and lines of the month and of the previous ones.
+// Envelopes which never had money nor lines are left out, the others keep the order of the categories.
+func BuildMonthEnvelopes(monthID int64, categories []Category, assignments []SumEnvelopeAssignmentsRow, activity []SumEnvelopeActivityRow) MonthEnvelopes {
+	result := MonthEnvelopes{MonthID: monthID, Envelopes: []Envelope{}}
+
+	assigned := make(map[int64]SumEnvelopeAssignmentsRow, len(assignments))
+	for _, row := range assignments {
+		assigned[row.CategoryID] = row
+	}
+	spent := make(map[int64]SumEnvelopeActivityRow, len(activity))
+	for _, row := range activity {
+		spent[row.CategoryID] = row
+	}
+
+	for _, category := range categories {
+		if category.Kind == util.INCOME {
+			result.Income = result.Income.Add(spent[category.ID].Amount)
+			continue
+		}
+
+		assignment, hasAssignment := assigned[category.ID]
+		lines, hasLines := spent[category.ID]
+		if !hasAssignment && !hasLines {
+			continue
+		}
+
+		envelope := Envelope{
+			Category: category,
+			Carried:  assignment.PreviousAmount.Add(lines.PreviousAmount),
+			Assigned: assignment.Amount,
+			Activity: lines.Amount,
+		}
+		envelope.Available = envelope.Carried.Add(envelope.Assigned).Add(envelope.Activity)
+		result.Envelopes = append(result.Envelopes, envelope)
+
+		result.Assigned = result.Assigned.Add(envelope.Assigned)
+	}
+
+	result.ToBeBudgeted = result.Income.Sub(result.Assigned)
+
+	return result
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// ErrCategoryCycle is returned when a category would become its own ancestor
+var ErrCategoryCycle = errors.New("category can't be moved under itself or one of its children")
+
+// MoveCategoryTxParams contains all infos to move a category in the tree
+type MoveCategoryTxParams struct {
+	ID int64 `json:"id"`
+	// ParentID is the new parent, nil moves the category to the top of the tree
+	ParentID *int64 `json:"parent_id"`
+}
+
+// MoveCategoryTx gives a new parent to a category, refusing moves which would create a cycle
+func (store *SQLStore) MoveCategoryTx(ctx context.Context, arg MoveCategoryTxParams) (Category, error) {
+	var result Category
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		category, err := q.GetCategory(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		// Lock the whole tree of the owner so concurrent moves can't build a cycle together
+		if err := q.LockCategoryTree(ctx, category.Owner); err != nil {
+			return err
+		}
+
+		if arg.ParentID != nil {
+			ancestors, err := q.ListCategoryAncestors(ctx, *arg.ParentID)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(ancestors, arg.ID) {
+				return ErrCategoryCycle
+			}
+		}
+
+		result, err = q.MoveCategory(ctx, MoveCategoryParams{
+			ID:       arg.ID,
+			ParentID: arg.ParentID,
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Groupings of a net worth
+const (
+	NetWorthByDay   = "day"
+	NetWorthByMonth = "month"
+)
+
+// NetWorthAccount is the balance of an account at the end of a period
+type NetWorthAccount struct {
+	AccountID int64           `json:"account_id"`
+	Title     string          `json:"title"`
+	Balance   decimal.Decimal `json:"balance"`
+}
+
+// NetWorthPoint is the net worth at the end of a day or a month
+type NetWorthPoint struct {
+	Date     time.Time         `json:"date"`
+	NetWorth decimal.Decimal   `json:"net_worth"`
+	Accounts []NetWorthAccount `json:"accounts"`
+}
+
+// NetWorth is the sum of the balances of every account over time.
+// Whatever they hold, all accounts count: a loan is an account with a negative balance.
+type NetWorth struct {
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	GroupBy string          `json:"group_by"`
+	Points  []NetWorthPoint `json:"points"`
+}
+
+// NetWorthTxParams contains all infos to compute the net worth of a user over a period
+type NetWorthTxParams struct {
+	Owner string    `json:"owner"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	// GroupBy is NetWorthByDay or NetWorthByMonth
+	GroupBy string `json:"group_by"`
+}
+
+// NetWorthTx returns the net worth of a user at the end of each day or month of a period
+func (store *SQLStore) NetWorthTx(ctx context.Context, arg NetWorthTxParams) (NetWorth, error) {
+	var result NetWorth
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		accounts, err := listAll(func(limit, offset int32) ([]Account, error) {
+			return q.ListAccounts(ctx, ListAccountsParams{Owner: arg.Owner, Limit: limit, Offset: offset})
+		})
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.SumLinesByAccountAndPeriod(ctx, SumLinesByAccountAndPeriodParams{
+			GroupBy:  arg.GroupBy,
+			FromDate: arg.From,
+			Owner:    arg.Owner,
+			ToDate:   arg.To,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = BuildNetWorth(arg.From, arg.To, arg.GroupBy, accounts, rows)
+		return nil
+	})
+
+	return result, err
+}
+
+// BuildNetWorth adds up the initial balance of the accounts and the sums of their lines, period after period.
+// The sums are those of the query, lines before from being summed in the first period.
+func BuildNetWorth(from, to time.Time, groupBy string, accounts []Account, rows []SumLinesByAccountAndPeriodRow) NetWorth {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	result := NetWorth{
+		From:    from,
+		To:      to,
+		GroupBy: groupBy,
+		Points:  []NetWorthPoint{},
+	}
+
+	balances := map[int64]decimal.Decimal{}
+	for _, account := range accounts {
+		balances[account.ID] = account.InitBalance
+	}
+
+	row := 0
+	for start := from; !start.After(to); {
+		end := start
+		if groupBy == NetWorthByMonth {
+			end = time.Date(start.Year(), start.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		}
+		if end.After(to) {
+			end = to
+		}
+
+		for ; row < len(rows) && !rows[row].Period.After(end); row++ {
+			balances[rows[row].AccountID] = balances[rows[row].AccountID].Add(rows[row].Amount)
+		}
+
+		point := NetWorthPoint{
+			Date:     end,
+			NetWorth: decimal.Zero,
+			Accounts: make([]NetWorthAccount, 0, len(accounts)),
+		}
+		for _, account := range accounts {
+			point.NetWorth = point.NetWorth.Add(balances[account.ID])
+			point.Accounts = append(point.Accounts, NetWorthAccount{
+				AccountID: account.ID,
+				Title:     account.Title,
+				Balance:   balances[account.ID],
+			})
+		}
+		result.Points = append(result.Points, point)
+
+		start = end.AddDate(0, 0, 1)
+	}
+
+	return result
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+
+	"github.com/moth13/finance_tracker/rules"
+	decimal "github.com/shopspring/decimal"
+)
+
+// RuleFields contains the fields of a line that rules can change
+type RuleFields struct {
+	CategoryID  int64    `json:"category_id"`
+	Payee       string   `json:"payee"`
+	Tags        []string `json:"tags"`
+	Checked     bool     `json:"checked"`
+	Description string   `json:"description"`
+}
+
+// RuleChange describes what rules changed, or would change, on a line
+type RuleChange struct {
+	LineID    int64           `json:"line_id"`
+	Title     string          `json:"title"`
+	Amount    decimal.Decimal `json:"amount"`
+	AccountID int64           `json:"account_id"`
+	Rules     []int64         `json:"rules"`
+	Before    RuleFields      `json:"before"`
+	After     RuleFields      `json:"after"`
+}
+
+// RunRulesTxParams contains all infos to run the rules of a user on existing lines
+type RunRulesTxParams struct {
+	Owner      string `json:"owner"`
+	DryRun     bool   `json:"dry_run"`
+	AccountID  *int64 `json:"account_id"`
+	MonthID    *int64 `json:"month_id"`
+	YearID     *int64 `json:"year_id"`
+	CategoryID *int64 `json:"category_id"`
+}
+
+// RunRulesTxResult contains all infos about the lines changed by the rules
+type RunRulesTxResult struct {
+	DryRun  bool         `json:"dry_run"`
+	Lines   int          `json:"lines"`
+	Changes []RuleChange `json:"changes"`
+}
+
+// RunRulesTx applies the rules of a user to the lines matching the filters.
+// On a dry run the changes are only reported, no line is written.
+func (store *SQLStore) RunRulesTx(ctx context.Context, arg RunRulesTxParams) (RunRulesTxResult, error) {
+	result := RunRulesTxResult{DryRun: arg.DryRun, Changes: []RuleChange{}}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		engine, err := loadRuleEngine(ctx, q, arg.Owner)
+		if err != nil {
+			return err
+		}
+
+		// Read every line first, changing categories would shift the pages otherwise
+		lines, err := listAll(func(limit, offset int32) ([]Line, error) {
+			return q.ListLines(ctx, ListLinesParams{
+				Owner:      arg.Owner,
+				AccountID:  arg.AccountID,
+				MonthID:    arg.MonthID,
+				YearID:     arg.YearID,
+				CategoryID: arg.CategoryID,
+				Limit:      limit,
+				Offset:     offset,
+			})
+		})
+		if err != nil {
+			return err
+		}
+		result.Lines = len(lines)
+
+		for _, line := range lines {
+			before := ruleLine(line)
+			after, matched := engine.Apply(before)
+			if !rules.Changed(before, after) {
+				continue
+			}
+
+			change := RuleChange{
+				LineID:    line.ID,
+				Title:     line.Title,
+				Amount:    line.Amount,
+				AccountID: line.AccountID,
+				Rules:     matched,
+				Before:    ruleFields(before),
+				After:     ruleFields(after),
+			}
+			result.Changes = append(result.Changes, change)
+			if arg.DryRun {
+				continue
+			}
+
+			if _, err := q.UpdateLineRuleFields(ctx, UpdateLineRuleFieldsParams{
+				ID:          line.ID,
+				CategoryID:  change.After.CategoryID,
+				Payee:       change.After.Payee,
+				Tags:        change.After.Tags,
+				Checked:     change.After.Checked,
+				Description: change.After.Description,
+			}); err != nil {
+				return err
+			}
+
+			// Checking or unchecking a line moves it in or out of the balances
+			if before.Checked != after.Checked {
+				argAdd := addMoneyTxParams{
+					Amount:      line.Amount,
+					FinalAmount: decimal.Zero,
+					AccountID:   line.AccountID,
+					MonthID:     line.MonthID,
+					YearID:      line.YearID,
+					DueDate:     line.DueDate,
+				}
+				if before.Checked {
+					argAdd.Amount = line.Amount.Neg()
+				}
+				if _, err := addMoneyTx(ctx, q, argAdd); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// loadRuleEngine compiles the rules of a user in priority order
+func loadRuleEngine(ctx context.Context, q *Queries, owner string) (*rules.Engine, error) {
+	stored, err := listAll(func(limit, offset int32) ([]Rule, error) {
+		return q.ListRules(ctx, ListRulesParams{Owner: owner, Limit: limit, Offset: offset})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	engineRules := make([]rules.Rule, 0, len(stored))
+	for _, rule := range stored {
+		engineRules = append(engineRules, EngineRule(rule))
+	}
+
+	return rules.NewEngine(engineRules)
+}
+
+// EngineRule converts a stored rule for the rules engine
+func EngineRule(rule Rule) rules.Rule {
+	engineRule := rules.Rule{
+		ID:          rule.ID,
+		MinAmount:   rule.MatchMinAmount,
+		MaxAmount:   rule.MatchMaxAmount,
+		AccountID:   rule.MatchAccountID,
+		DayOfMonth:  rule.MatchDay,
+		CategoryID:  rule.SetCategoryID,
+		Payee:       rule.SetPayee,
+		Tags:        rule.SetTags,
+		Checked:     rule.SetChecked,
+		Description: rule.SetDescription,
+	}
+	if rule.MatchTitle != nil {
+		engineRule.TitlePattern = *rule.MatchTitle
+	}
+
+	return engineRule
+}
+
+func ruleLine(line Line) rules.Line {
+	return rules.Line{
+		Title:       line.Title,
+		Amount:      line.Amount,
+		AccountID:   line.AccountID,
+		DueDate:     line.DueDate,
+		CategoryID:  line.CategoryID,
+		Payee:       line.Payee,
+		Tags:        line.Tags,
+		Checked:     line.Checked,
+		Description: line.Description,
+	}
+}
+
+func ruleFields(line rules.Line) RuleFields {
+	tags := line.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return RuleFields{
+		CategoryID:  line.CategoryID,
+		Payee:       line.Payee,
+		Tags:        tags,
+		Checked:     line.Checked,
+		Description: line.Description,
+	}
+}
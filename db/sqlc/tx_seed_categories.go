@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SeedCategoriesTxParams contains all infos to give the default categories to a user
+type SeedCategoriesTxParams struct {
+	Owner  string `json:"owner"`
+	Locale string `json:"locale"`
+	// Reset also gives back their default kind, color and icon to the default categories the user changed
+	Reset bool `json:"reset"`
+}
+
+// SeedCategoriesTxResult contains all infos about the default categories given to a user
+type SeedCategoriesTxResult struct {
+	Created  []Category `json:"created"`
+	Restored []Category `json:"restored"`
+}
+
+// SeedCategoriesTx creates the default categories a user is missing, keeping the ones already there
+func (store *SQLStore) SeedCategoriesTx(ctx context.Context, arg SeedCategoriesTxParams) (SeedCategoriesTxResult, error) {
+	var result SeedCategoriesTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		return seedCategories(ctx, q, arg, &result)
+	})
+
+	return result, err
+}
+
+func seedCategories(ctx context.Context, q *Queries, arg SeedCategoriesTxParams, result *SeedCategoriesTxResult) error {
+	result.Created = []Category{}
+	result.Restored = []Category{}
+
+	var seed func(defaults []DefaultCategory, parent *Category) error
+	seed = func(defaults []DefaultCategory, parent *Category) error {
+		for _, def := range defaults {
+			var parentID *int64
+			if parent != nil {
+				parentID = &parent.ID
+				// Children share the kind of their parent and fall back on its look
+				def.Kind = parent.Kind
+				if def.Color == "" {
+					def.Color = parent.Color
+				}
+				if def.Icon == "" {
+					def.Icon = parent.Icon
+				}
+			}
+
+			category, err := q.GetCategoryByTitle(ctx, GetCategoryByTitleParams{
+				Owner:    arg.Owner,
+				Title:    def.Title,
+				ParentID: parentID,
+			})
+			switch {
+			case errors.Is(err, pgx.ErrNoRows):
+				category, err = q.CreateCategory(ctx, CreateCategoryParams{
+					Title:    def.Title,
+					Owner:    arg.Owner,
+					ParentID: parentID,
+					Kind:     def.Kind,
+					Color:    def.Color,
+					Icon:     def.Icon,
+				})
+				if err != nil {
+					return err
+				}
+				result.Created = append(result.Created, category)
+			case err != nil:
+				return err
+			case arg.Reset && (category.Kind != def.Kind || category.Color != def.Color || category.Icon != def.Icon):
+				category, err = q.UpdateCategory(ctx, UpdateCategoryParams{
+					ID:    category.ID,
+					Title: category.Title,
+					Kind:  def.Kind,
+					Color: def.Color,
+					Icon:  def.Icon,
+				})
+				if err != nil {
+					return err
+				}
+				result.Restored = append(result.Restored, category)
+			}
+
+			if err := seed(def.Children, &category); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return seed(DefaultCategories(arg.Locale), nil)
+}
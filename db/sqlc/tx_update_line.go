@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/moth13/finance_tracker/util"
@@ -28,6 +29,12 @@ type UpdateLineTxParams struct {
 type UpdateLineTxResult struct {
 	Line    Line         `json:"line"`
 	Balance util.Balance `json:"balance"`
+	// Previous is the line as it was before the update
+	Previous Line `json:"previous"`
+	// Warnings lists the checks the line failed without being refused
+	Warnings []string `json:"warnings,omitempty"`
+	// Alerts lists the budget thresholds the update made the month of the line reach
+	Alerts []BudgetAlert `json:"alerts,omitempty"`
 }
 
 func (store *SQLStore) UpdateLineTx(ctx context.Context, arg UpdateLineTxParams) (UpdateLineTxResult, error) {
@@ -41,6 +48,7 @@ func (store *SQLStore) UpdateLineTx(ctx context.Context, arg UpdateLineTxParams)
 		if err != nil {
 			return err
 		}
+		result.Previous = line
 
 		argLine := UpdateLineParams{
 			ID:          line.ID,
@@ -92,6 +100,19 @@ func (store *SQLStore) UpdateLineTx(ctx context.Context, arg UpdateLineTxParams)
 			argLine.DueDate = *arg.DueDate
 		}
 
+		// A new amount or category must still match the kind of the category
+		if arg.Amount.Valid || arg.CategoryID != nil {
+			category, err := q.GetCategory(ctx, argLine.CategoryID)
+			if err != nil {
+				return err
+			}
+
+			result.Warnings, err = checkCategoryKind(ctx, q, line.Owner, category, argLine.Amount)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Revert previous balance for all components
 		argRevert := addMoneyTxParams{
 			Amount:      decimal.Zero,
@@ -99,6 +120,7 @@ func (store *SQLStore) UpdateLineTx(ctx context.Context, arg UpdateLineTxParams)
 			AccountID:   line.AccountID,
 			MonthID:     line.MonthID,
 			YearID:      line.YearID,
+			DueDate:     line.DueDate,
 		}
 
 		if line.Checked {
@@ -117,6 +139,7 @@ func (store *SQLStore) UpdateLineTx(ctx context.Context, arg UpdateLineTxParams)
 			AccountID:   argLine.AccountID,
 			MonthID:     argLine.MonthID,
 			YearID:      argLine.YearID,
+			DueDate:     argLine.DueDate,
 		}
 
 		if line.Checked {
@@ -136,6 +159,15 @@ func (store *SQLStore) UpdateLineTx(ctx context.Context, arg UpdateLineTxParams)
 
 		return err
 	})
+	if err != nil {
+		return result, err
+	}
+
+	// Budgets are checked once the line is committed, failing to do so doesn't undo the update
+	result.Alerts, err = store.evaluateBudgetAlerts(ctx, result.Line.Owner, result.Line.MonthID)
+	if err != nil {
+		log.Printf("cannot evaluate budget alerts of %s: %v", result.Line.Owner, err)
+	}
 
-	return result, err
+	return result, nil
 }
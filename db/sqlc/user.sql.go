@@ -17,10 +17,11 @@ INSERT INTO users (
   full_name,
   email,
   currency,
-  password_changed_at
+  password_changed_at,
+  locale
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING username, hashed_password, full_name, email, currency, password_changed_at, create_at
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING username, hashed_password, full_name, email, currency, password_changed_at, create_at, category_kind_check, locale, budget_alert_email
 `
 
 type CreateUserParams struct {
@@ -30,6 +31,7 @@ type CreateUserParams struct {
 	Email             string    `json:"email"`
 	Currency          string    `json:"currency"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
+	Locale            string    `json:"locale"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -40,6 +42,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Email,
 		arg.Currency,
 		arg.PasswordChangedAt,
+		arg.Locale,
 	)
 	var i User
 	err := row.Scan(
@@ -50,6 +53,9 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Currency,
 		&i.PasswordChangedAt,
 		&i.CreateAt,
+		&i.CategoryKindCheck,
+		&i.Locale,
+		&i.BudgetAlertEmail,
 	)
 	return i, err
 }
@@ -64,7 +70,7 @@ func (q *Queries) DeleteUser(ctx context.Context, username string) error {
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, currency, password_changed_at, create_at FROM users
+SELECT username, hashed_password, full_name, email, currency, password_changed_at, create_at, category_kind_check, locale, budget_alert_email FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -79,6 +85,112 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Currency,
 		&i.PasswordChangedAt,
 		&i.CreateAt,
+		&i.CategoryKindCheck,
+		&i.Locale,
+		&i.BudgetAlertEmail,
+	)
+	return i, err
+}
+
+const listUsernames = `-- name: ListUsernames :many
+SELECT username FROM users
+ORDER BY username
+LIMIT $1
+OFFSET $2
+`
+
+type ListUsernamesParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListUsernames(ctx context.Context, arg ListUsernamesParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, listUsernames, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, err
+		}
+		items = append(items, username)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateUserPreferences = `-- name: UpdateUserPreferences :one
+UPDATE users
+SET category_kind_check = COALESCE($1, category_kind_check),
+    budget_alert_email = COALESCE($2, budget_alert_email)
+WHERE username = $3
+RETURNING username, hashed_password, full_name, email, currency, password_changed_at, create_at, category_kind_check, locale, budget_alert_email
+`
+
+type UpdateUserPreferencesParams struct {
+	CategoryKindCheck *string `json:"category_kind_check"`
+	BudgetAlertEmail  *bool   `json:"budget_alert_email"`
+	Username          string  `json:"username"`
+}
+
+func (q *Queries) UpdateUserPreferences(ctx context.Context, arg UpdateUserPreferencesParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserPreferences, arg.CategoryKindCheck, arg.BudgetAlertEmail, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.Currency,
+		&i.PasswordChangedAt,
+		&i.CreateAt,
+		&i.CategoryKindCheck,
+		&i.Locale,
+		&i.BudgetAlertEmail,
+	)
+	return i, err
+}
+
+const updateUserProfile = `-- name: UpdateUserProfile :one
+UPDATE users
+SET full_name = $1,
+    currency = $2,
+    locale = COALESCE($3, locale)
+WHERE username = $4
+RETURNING username, hashed_password, full_name, email, currency, password_changed_at, create_at, category_kind_check, locale, budget_alert_email
+`
+
+type UpdateUserProfileParams struct {
+	FullName string  `json:"full_name"`
+	Currency string  `json:"currency"`
+	Locale   *string `json:"locale"`
+	Username string  `json:"username"`
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserProfile,
+		arg.FullName,
+		arg.Currency,
+		arg.Locale,
+		arg.Username,
+	)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.Currency,
+		&i.PasswordChangedAt,
+		&i.CreateAt,
+		&i.CategoryKindCheck,
+		&i.Locale,
+		&i.BudgetAlertEmail,
 	)
 	return i, err
 }
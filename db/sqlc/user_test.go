@@ -10,12 +10,14 @@ import (
 )
 
 func createRandomUser(t *testing.T) User {
+	currency := util.RandomCurrency()
 	arg := CreateUserParams{
 		Username:       util.RandomUsername(),
 		HashedPassword: "secret",
 		FullName:       util.RandomFullName(),
 		Email:          util.RandomEmail(),
-		Currency:       util.RandomCurrency(),
+		Currency:       currency,
+		Locale:         util.LocaleForCurrency(currency),
 	}
 
 	user, err := testStore.CreateUser(context.Background(), arg)
@@ -27,8 +29,10 @@ func createRandomUser(t *testing.T) User {
 	require.Equal(t, user.FullName, arg.FullName)
 	require.Equal(t, user.Email, arg.Email)
 	require.Equal(t, user.Currency, arg.Currency)
+	require.Equal(t, user.Locale, arg.Locale)
 	require.True(t, user.PasswordChangedAt.IsZero())
 	require.NotZero(t, user.CreateAt)
+	require.Equal(t, util.WARN, user.CategoryKindCheck)
 
 	return user
 }
@@ -78,3 +82,29 @@ func TestDeleteUser(t *testing.T) {
 	require.EqualError(t, err, pgx.ErrNoRows.Error())
 	require.Empty(t, account2)
 }
+
+func TestUpdateUserPreferences(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	check := util.ENFORCE
+	arg := UpdateUserPreferencesParams{
+		Username:          user1.Username,
+		CategoryKindCheck: &check,
+	}
+
+	user2, err := testStore.UpdateUserPreferences(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user1.Username, user2.Username)
+	require.Equal(t, util.ENFORCE, user2.CategoryKindCheck)
+	require.False(t, user2.BudgetAlertEmail)
+
+	// Preferences left out are kept
+	email := true
+	user3, err := testStore.UpdateUserPreferences(context.Background(), UpdateUserPreferencesParams{
+		Username:         user1.Username,
+		BudgetAlertEmail: &email,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.ENFORCE, user3.CategoryKindCheck)
+	require.True(t, user3.BudgetAlertEmail)
+}
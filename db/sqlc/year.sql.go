@@ -113,6 +113,34 @@ func (q *Queries) GetYear(ctx context.Context, id int64) (Year, error) {
 	return i, err
 }
 
+const getYearByDate = `-- name: GetYearByDate :one
+SELECT id, owner, title, description, balance, final_balance, start_date, end_date FROM years
+WHERE owner = $1 AND start_date <= $2 AND end_date >= $2
+ORDER BY start_date DESC
+LIMIT 1
+`
+
+type GetYearByDateParams struct {
+	Owner string    `json:"owner"`
+	Date  time.Time `json:"date"`
+}
+
+func (q *Queries) GetYearByDate(ctx context.Context, arg GetYearByDateParams) (Year, error) {
+	row := q.db.QueryRow(ctx, getYearByDate, arg.Owner, arg.Date)
+	var i Year
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Title,
+		&i.Description,
+		&i.Balance,
+		&i.FinalBalance,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
 const getYearForUpdate = `-- name: GetYearForUpdate :one
 SELECT id, owner, title, description, balance, final_balance, start_date, end_date FROM years
 WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
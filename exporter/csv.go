@@ -0,0 +1,75 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const dateLayout = "2006-01-02"
+
+// csvLocale is how numbers and fields are separated for a spreadsheet locale
+type csvLocale struct {
+	comma   rune
+	decimal string
+}
+
+var csvLocales = map[string]csvLocale{
+	"en": {comma: ',', decimal: "."},
+	"fr": {comma: ';', decimal: ","},
+	"de": {comma: ';', decimal: ","},
+	"es": {comma: ';', decimal: ","},
+	"it": {comma: ';', decimal: ","},
+}
+
+// IsSupportedLocale returns true if CSV numbers can be formatted for the locale
+func IsSupportedLocale(locale string) bool {
+	_, ok := csvLocales[strings.ToLower(locale)]
+	return ok
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	locale csvLocale
+}
+
+// NewCSVWriter writes lines as CSV, using the separators expected by the locale spreadsheets
+func NewCSVWriter(w io.Writer, locale string) (LineWriter, error) {
+	if locale == "" {
+		locale = "en"
+	}
+	l, ok := csvLocales[strings.ToLower(locale)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported locale %s", locale)
+	}
+
+	writer := &csvWriter{w: csv.NewWriter(w), locale: l}
+	writer.w.Comma = l.comma
+	if err := writer.w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (writer *csvWriter) Write(line Line) error {
+	amount := strings.Replace(line.Amount.StringFixed(2), ".", writer.locale.decimal, 1)
+	return writer.w.Write([]string{
+		strconv.FormatInt(line.ID, 10),
+		line.Date.Format(dateLayout),
+		line.Title,
+		line.Account,
+		line.Month,
+		line.Category,
+		amount,
+		strconv.FormatBool(line.Checked),
+		line.Description,
+	})
+}
+
+func (writer *csvWriter) Close() error {
+	writer.w.Flush()
+	return writer.w.Error()
+}
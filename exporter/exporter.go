@@ -0,0 +1,76 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+const (
+	CSV  = "CSV"
+	XLSX = "XLSX"
+	JSON = "JSON"
+)
+
+// Line is a line as written in an export, with its relations already resolved
+type Line struct {
+	ID           int64           `json:"id"`
+	Date         time.Time       `json:"date"`
+	Title        string          `json:"title"`
+	Account      string          `json:"account"`
+	Month        string          `json:"month"`
+	Category     string          `json:"category"`
+	CategoryKind string          `json:"category_kind"`
+	Amount       decimal.Decimal `json:"amount"`
+	Checked      bool            `json:"checked"`
+	Description  string          `json:"description"`
+}
+
+// LineWriter streams lines into an export file, Close must be called to complete it
+type LineWriter interface {
+	Write(line Line) error
+	Close() error
+}
+
+// header lists the columns written by the tabular exports
+var header = []string{"id", "date", "title", "account", "month", "category", "amount", "checked", "description"}
+
+// IsSupportedFormat returns true if the export format is supported
+func IsSupportedFormat(format string) bool {
+	switch format {
+	case CSV, XLSX, JSON:
+		return true
+	}
+
+	return false
+}
+
+// ContentType returns the MIME type of an export format
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case JSON:
+		return "application/json; charset=utf-8"
+	}
+
+	return "application/octet-stream"
+}
+
+// NewLineWriter returns a writer for the given format, locale only applies to CSV
+func NewLineWriter(format string, w io.Writer, locale string) (LineWriter, error) {
+	switch format {
+	case CSV:
+		return NewCSVWriter(w, locale)
+	case XLSX:
+		return NewXLSXWriter(w)
+	case JSON:
+		return NewJSONWriter(w)
+	}
+
+	return nil, fmt.Errorf("unsupported export format %s", format)
+}
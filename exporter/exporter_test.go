@@ -0,0 +1,114 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleLines = []Line{
+	{
+		ID:       1,
+		Date:     time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC),
+		Title:    "SuperU",
+		Account:  "Checking",
+		Month:    "March 2024",
+		Category: "Food",
+		Amount:   decimal.RequireFromString("-1234.5"),
+		Checked:  true,
+	},
+	{
+		ID:          2,
+		Date:        time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC),
+		Title:       "Salary",
+		Account:     "Checking",
+		Month:       "March 2024",
+		Category:    "Income",
+		Amount:      decimal.RequireFromString("2500"),
+		Description: "R&D <bonus>",
+	},
+}
+
+func writeLines(t *testing.T, format, locale string) []byte {
+	var buf bytes.Buffer
+	writer, err := NewLineWriter(format, &buf, locale)
+	require.NoError(t, err)
+
+	for _, line := range sampleLines {
+		require.NoError(t, writer.Write(line))
+	}
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestCSVWriter(t *testing.T) {
+	data := string(writeLines(t, CSV, "en"))
+	require.Equal(t, "id,date,title,account,month,category,amount,checked,description\n"+
+		"1,2024-03-12,SuperU,Checking,March 2024,Food,-1234.50,true,\n"+
+		"2,2024-03-28,Salary,Checking,March 2024,Income,2500.00,false,R&D <bonus>\n", data)
+
+	data = string(writeLines(t, CSV, "fr"))
+	require.Contains(t, data, "1;2024-03-12;SuperU;Checking;March 2024;Food;-1234,50;true;\n")
+
+	_, err := NewLineWriter(CSV, io.Discard, "xx")
+	require.Error(t, err)
+}
+
+func TestJSONWriter(t *testing.T) {
+	var lines []Line
+	require.NoError(t, json.Unmarshal(writeLines(t, JSON, ""), &lines))
+	require.Len(t, lines, 2)
+	require.Equal(t, "Salary", lines[1].Title)
+	require.True(t, lines[0].Amount.Equal(sampleLines[0].Amount))
+
+	var buf bytes.Buffer
+	writer, err := NewJSONWriter(&buf)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.Equal(t, "[]", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	data := writeLines(t, XLSX, "")
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[file.Name] = string(content)
+	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "xl/workbook.xml")
+	require.Contains(t, parts, "xl/styles.xml")
+
+	lines := parts["xl/worksheets/sheet1.xml"]
+	// 2024-03-12 is day 45363 of the spreadsheet calendar
+	require.Contains(t, lines, `<c r="B2" s="1"><v>45363</v></c>`)
+	require.Contains(t, lines, `<c r="G2" s="2"><v>-1234.5</v></c>`)
+	require.Contains(t, lines, `<c r="H2" t="b"><v>1</v></c>`)
+	require.Contains(t, lines, "R&amp;D &lt;bonus&gt;")
+
+	summary := parts["xl/worksheets/sheet2.xml"]
+	require.Contains(t, summary, `<c r="C2" s="2"><v>-1234.5</v></c>`)
+	require.Contains(t, summary, `<c r="B3" s="2"><v>2500</v></c>`)
+}
+
+func TestCellRef(t *testing.T) {
+	require.Equal(t, "A1", cellRef(0, 1))
+	require.Equal(t, "Z2", cellRef(25, 2))
+	require.Equal(t, "AA3", cellRef(26, 3))
+}
@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	icalDateLayout  = "20060102"
+	icalStampLayout = "20060102T150405Z"
+	// icalLineLength is the maximum length in octets of a content line before folding
+	icalLineLength = 75
+)
+
+// CalendarEvent is an all day event of an iCalendar feed
+type CalendarEvent struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	// RRule is the recurrence rule without its RRULE: prefix, empty for a single event
+	RRule string
+}
+
+// Calendar is an iCalendar feed
+type Calendar struct {
+	Name   string
+	Events []CalendarEvent
+}
+
+// WriteCalendar writes a calendar as an RFC 5545 iCalendar file
+func WriteCalendar(w io.Writer, calendar Calendar, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	stamp = stamp.UTC()
+
+	writeICalLine(bw, "BEGIN:VCALENDAR")
+	writeICalLine(bw, "VERSION:2.0")
+	writeICalLine(bw, "PRODID:-//finance_tracker//calendar//EN")
+	writeICalLine(bw, "CALSCALE:GREGORIAN")
+	writeICalLine(bw, "METHOD:PUBLISH")
+	if calendar.Name != "" {
+		writeICalLine(bw, "X-WR-CALNAME:"+icalText(calendar.Name))
+	}
+
+	for _, event := range calendar.Events {
+		writeICalLine(bw, "BEGIN:VEVENT")
+		writeICalLine(bw, "UID:"+event.UID)
+		writeICalLine(bw, "DTSTAMP:"+stamp.Format(icalStampLayout))
+		writeICalLine(bw, "DTSTART;VALUE=DATE:"+event.Date.Format(icalDateLayout))
+		writeICalLine(bw, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format(icalDateLayout))
+		if event.RRule != "" {
+			writeICalLine(bw, "RRULE:"+event.RRule)
+		}
+		writeICalLine(bw, "SUMMARY:"+icalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(bw, "DESCRIPTION:"+icalText(event.Description))
+		}
+		writeICalLine(bw, "TRANSP:TRANSPARENT")
+		writeICalLine(bw, "END:VEVENT")
+	}
+
+	writeICalLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// ICalDate formats a day as an iCalendar DATE value, as used by RRULE UNTIL
+func ICalDate(date time.Time) string {
+	return date.Format(icalDateLayout)
+}
+
+// writeICalLine writes a CRLF terminated content line, folded so no line exceeds 75 octets
+func writeICalLine(w *bufio.Writer, line string) {
+	limit := icalLineLength
+	for len(line) > limit {
+		// Never split a multi-byte character
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		fmt.Fprintf(w, "%s\r\n ", line[:cut])
+		line = line[cut:]
+		// Continuation lines start with a space which counts in their length
+		limit = icalLineLength - 1
+	}
+	fmt.Fprintf(w, "%s\r\n", line)
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// icalText escapes a TEXT value
+func icalText(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, ";", `\;`)
+	s = strings.ReplaceAll(s, ",", `\,`)
+	s = strings.ReplaceAll(s, "\r\n", `\n`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return s
+}
@@ -0,0 +1,60 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	calendar := Calendar{
+		Name: "Finance tracker",
+		Events: []CalendarEvent{
+			{
+				UID:         "recline-1@finance_tracker",
+				Date:        time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+				Summary:     "Rent -800.00 EUR (Checking)",
+				Description: "Housing; flat\nsecond line",
+				RRule:       "FREQ=MONTHLY;UNTIL=20240604",
+			},
+		},
+	}
+	stamp := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCalendar(&buf, calendar, stamp))
+
+	data := buf.String()
+	require.True(t, strings.HasPrefix(data, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	require.True(t, strings.HasSuffix(data, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	require.Contains(t, data, "UID:recline-1@finance_tracker\r\n")
+	require.Contains(t, data, "DTSTAMP:20240301T103000Z\r\n")
+	require.Contains(t, data, "DTSTART;VALUE=DATE:20240305\r\n")
+	require.Contains(t, data, "DTEND;VALUE=DATE:20240306\r\n")
+	require.Contains(t, data, "RRULE:FREQ=MONTHLY;UNTIL=20240604\r\n")
+	require.Contains(t, data, `DESCRIPTION:Housing\; flat\nsecond line`+"\r\n")
+}
+
+func TestWriteICalLineFolding(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeICalLine(w, "SUMMARY:"+strings.Repeat("é", 60))
+	require.NoError(t, w.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	for i, line := range lines {
+		require.LessOrEqual(t, len(line), icalLineLength)
+		if i > 0 {
+			require.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+
+	// Unfolding gives back the original line
+	unfolded := strings.ReplaceAll(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n ", "")
+	require.Equal(t, "SUMMARY:"+strings.Repeat("é", 60), unfolded)
+}
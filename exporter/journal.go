@@ -0,0 +1,386 @@
+package exporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+const (
+	LEDGER    = "LEDGER"
+	HLEDGER   = "HLEDGER"
+	BEANCOUNT = "BEANCOUNT"
+)
+
+// Top level accounts of a plain text accounting journal
+const (
+	journalAssets   = "Assets"
+	journalExpenses = "Expenses"
+	journalIncome   = "Income"
+	journalEquity   = "Equity"
+)
+
+// JournalAccount is an account written to a journal with its opening balance
+type JournalAccount struct {
+	Title       string
+	InitBalance decimal.Decimal
+}
+
+// Journal is everything needed to write a plain text accounting file
+type Journal struct {
+	Currency string
+	Accounts []JournalAccount
+	Lines    []Line
+}
+
+// journalPosting is one leg of a journal transaction
+type journalPosting struct {
+	account string
+	amount  decimal.Decimal
+}
+
+// journalTransaction is a balanced set of postings on a given day
+type journalTransaction struct {
+	date        time.Time
+	cleared     bool
+	title       string
+	description string
+	postings    []journalPosting
+}
+
+// IsSupportedJournalFormat returns true if the plain text accounting format is supported
+func IsSupportedJournalFormat(format string) bool {
+	switch format {
+	case LEDGER, HLEDGER, BEANCOUNT:
+		return true
+	}
+
+	return false
+}
+
+// JournalExtension returns the file extension expected by the accounting tool
+func JournalExtension(format string) string {
+	switch format {
+	case HLEDGER:
+		return "journal"
+	case BEANCOUNT:
+		return "beancount"
+	}
+
+	return "ledger"
+}
+
+// WriteJournal writes accounts, opening balances and lines as a ledger, hledger or beancount file
+func WriteJournal(w io.Writer, format string, journal Journal) error {
+	if !IsSupportedJournalFormat(format) {
+		return fmt.Errorf("unsupported export format %s", format)
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(journal.Currency))
+	if currency == "" {
+		return fmt.Errorf("journal currency is required")
+	}
+
+	names := journalNames{beancount: format == BEANCOUNT}
+	transactions := buildJournalTransactions(journal, names)
+
+	// Accounts are opened on the first day of the journal, before any posting
+	opening := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, transaction := range transactions {
+		if transaction.date.Before(opening) {
+			opening = transaction.date
+		}
+	}
+
+	accountSet := make(map[string]bool)
+	for _, account := range journal.Accounts {
+		accountSet[names.asset(account.Title)] = true
+	}
+	for _, transaction := range transactions {
+		for _, posting := range transaction.postings {
+			accountSet[posting.account] = true
+		}
+	}
+
+	var openings []journalTransaction
+	for _, account := range journal.Accounts {
+		if account.InitBalance.IsZero() {
+			continue
+		}
+		asset := names.asset(account.Title)
+		openings = append(openings, journalTransaction{
+			date:    opening,
+			cleared: true,
+			title:   "Opening balance",
+			postings: []journalPosting{
+				{account: asset, amount: account.InitBalance},
+				{account: names.opening(), amount: account.InitBalance.Neg()},
+			},
+		})
+		accountSet[names.opening()] = true
+	}
+
+	accounts := make([]string, 0, len(accountSet))
+	for account := range accountSet {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	bw := bufio.NewWriter(w)
+	if format == BEANCOUNT {
+		writeBeancountHeader(bw, currency, opening, accounts)
+	} else {
+		writeLedgerHeader(bw, currency, accounts)
+	}
+
+	for _, transaction := range append(openings, transactions...) {
+		if format == BEANCOUNT {
+			writeBeancountTransaction(bw, currency, transaction)
+		} else {
+			writeLedgerTransaction(bw, currency, transaction)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// buildJournalTransactions turns lines into transactions, pairing the two sides of transfers
+func buildJournalTransactions(journal Journal, names journalNames) []journalTransaction {
+	lines := make([]Line, len(journal.Lines))
+	copy(lines, journal.Lines)
+	sort.SliceStable(lines, func(i, j int) bool {
+		if !lines[i].Date.Equal(lines[j].Date) {
+			return lines[i].Date.Before(lines[j].Date)
+		}
+		return lines[i].ID < lines[j].ID
+	})
+
+	paired := pairTransfers(lines)
+
+	transactions := make([]journalTransaction, 0, len(lines))
+	for i, line := range lines {
+		j, ok := paired[i]
+		if ok && j < i {
+			// Already written with the other side of the transfer
+			continue
+		}
+
+		transaction := journalTransaction{
+			date:        line.Date,
+			cleared:     line.Checked,
+			title:       line.Title,
+			description: line.Description,
+		}
+
+		if ok {
+			other := lines[j]
+			transaction.cleared = line.Checked && other.Checked
+			transaction.postings = []journalPosting{
+				{account: names.asset(line.Account), amount: line.Amount},
+				{account: names.asset(other.Account), amount: other.Amount},
+			}
+		} else {
+			transaction.postings = []journalPosting{
+				{account: names.asset(line.Account), amount: line.Amount},
+				{account: names.category(line.Category, line.CategoryKind, line.Amount), amount: line.Amount.Neg()},
+			}
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions
+}
+
+// pairTransfers matches transfer lines of opposite amounts on the same day between two accounts
+func pairTransfers(lines []Line) map[int]int {
+	paired := make(map[int]int)
+	for i, line := range lines {
+		if !isTransfer(line) || line.Amount.IsZero() {
+			continue
+		}
+		if _, ok := paired[i]; ok {
+			continue
+		}
+
+		for j := i + 1; j < len(lines); j++ {
+			other := lines[j]
+			if !other.Date.Equal(line.Date) {
+				break
+			}
+			if _, ok := paired[j]; ok || !isTransfer(other) {
+				continue
+			}
+			if other.Account != line.Account && other.Amount.Equal(line.Amount.Neg()) {
+				paired[i] = j
+				paired[j] = i
+				break
+			}
+		}
+	}
+
+	return paired
+}
+
+func isTransfer(line Line) bool {
+	return line.CategoryKind == util.TRANSFER
+}
+
+func writeLedgerHeader(w *bufio.Writer, currency string, accounts []string) {
+	fmt.Fprintf(w, "commodity %s\n\n", currency)
+	for _, account := range accounts {
+		fmt.Fprintf(w, "account %s\n", account)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeLedgerTransaction(w *bufio.Writer, currency string, transaction journalTransaction) {
+	fmt.Fprint(w, transaction.date.Format(dateLayout))
+	if transaction.cleared {
+		fmt.Fprint(w, " *")
+	}
+	fmt.Fprintf(w, " %s\n", singleLine(transaction.title))
+	if description := singleLine(transaction.description); description != "" {
+		fmt.Fprintf(w, "    ; %s\n", description)
+	}
+	for _, posting := range transaction.postings {
+		fmt.Fprintf(w, "    %s  %s %s\n", posting.account, journalAmount(posting.amount), currency)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeBeancountHeader(w *bufio.Writer, currency string, opening time.Time, accounts []string) {
+	fmt.Fprintf(w, "option \"operating_currency\" %s\n\n", beancountString(currency))
+	fmt.Fprintf(w, "%s commodity %s\n\n", opening.Format(dateLayout), currency)
+	for _, account := range accounts {
+		fmt.Fprintf(w, "%s open %s\n", opening.Format(dateLayout), account)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeBeancountTransaction(w *bufio.Writer, currency string, transaction journalTransaction) {
+	flag := "!"
+	if transaction.cleared {
+		flag = "*"
+	}
+
+	fmt.Fprintf(w, "%s %s %s", transaction.date.Format(dateLayout), flag, beancountString(singleLine(transaction.title)))
+	if description := singleLine(transaction.description); description != "" {
+		fmt.Fprintf(w, " %s", beancountString(description))
+	}
+	fmt.Fprintln(w)
+	for _, posting := range transaction.postings {
+		fmt.Fprintf(w, "  %s  %s %s\n", posting.account, journalAmount(posting.amount), currency)
+	}
+	fmt.Fprintln(w)
+}
+
+// journalAmount writes cents at least, and every stored decimal when there are more
+func journalAmount(amount decimal.Decimal) string {
+	if amount.Equal(amount.Round(2)) {
+		return amount.StringFixed(2)
+	}
+
+	return amount.String()
+}
+
+// beancountString quotes a value as a beancount string literal
+func beancountString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// singleLine folds every whitespace run into a single space
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// journalNames builds account names accepted by the target accounting tool
+type journalNames struct {
+	beancount bool
+}
+
+func (names journalNames) asset(title string) string {
+	return journalAssets + ":" + names.component(title)
+}
+
+// category files income and expense categories by their kind, so refunds stay under the category they belong to.
+// Other kinds, like unpaired transfers, go under expenses or income by the sign of the amount.
+func (names journalNames) category(title, kind string, amount decimal.Decimal) string {
+	var root string
+	switch {
+	case kind == util.INCOME:
+		root = journalIncome
+	case kind == util.EXPENSE:
+		root = journalExpenses
+	case amount.IsPositive():
+		root = journalIncome
+	default:
+		root = journalExpenses
+	}
+
+	levels := []string{root}
+	for _, level := range strings.Split(title, ":") {
+		if strings.TrimSpace(level) != "" {
+			levels = append(levels, names.component(level))
+		}
+	}
+	if len(levels) == 1 {
+		levels = append(levels, names.component("Uncategorized"))
+	}
+
+	return strings.Join(levels, ":")
+}
+
+func (names journalNames) opening() string {
+	if names.beancount {
+		return journalEquity + ":Opening-Balances"
+	}
+
+	return journalEquity + ":Opening Balances"
+}
+
+// component cleans up a title so it can be used as one level of an account name
+func (names journalNames) component(title string) string {
+	title = strings.ReplaceAll(title, ":", "-")
+
+	if !names.beancount {
+		if title = singleLine(title); title == "" {
+			return "Unknown"
+		}
+		return title
+	}
+
+	// Beancount only accepts letters, digits and dashes, starting with a capital or a digit
+	var b strings.Builder
+	dash := false
+	for _, r := range title {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	component := []rune(strings.TrimRight(b.String(), "-"))
+	if len(component) == 0 {
+		return "Unknown"
+	}
+
+	component[0] = unicode.ToUpper(component[0])
+	if !unicode.IsUpper(component[0]) && !unicode.IsDigit(component[0]) {
+		return "X" + string(component)
+	}
+
+	return string(component)
+}
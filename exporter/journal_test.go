@@ -0,0 +1,167 @@
+package exporter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleJournal = Journal{
+	Currency: "eur",
+	Accounts: []JournalAccount{
+		{Title: "Compte courant", InitBalance: decimal.RequireFromString("100")},
+		{Title: "Livret A", InitBalance: decimal.Zero},
+	},
+	Lines: []Line{
+		{
+			ID:           3,
+			Date:         time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC),
+			Title:        "Savings",
+			Account:      "Livret A",
+			Category:     "Virements",
+			CategoryKind: util.TRANSFER,
+			Amount:       decimal.RequireFromString("50"),
+			Checked:      true,
+		},
+		{
+			ID:           1,
+			Date:         time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC),
+			Title:        `SuperU "Nord"`,
+			Account:      "Compte courant",
+			Category:     "Food:Groceries",
+			CategoryKind: util.EXPENSE,
+			Amount:       decimal.RequireFromString("-57.3"),
+			Checked:      true,
+			Description:  "weekly",
+		},
+		{
+			ID:           2,
+			Date:         time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC),
+			Title:        "Savings",
+			Account:      "Compte courant",
+			Category:     "Virements",
+			CategoryKind: util.TRANSFER,
+			Amount:       decimal.RequireFromString("-50"),
+			Checked:      true,
+		},
+		{
+			ID:           4,
+			Date:         time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC),
+			Title:        "Salary",
+			Account:      "Compte courant",
+			Category:     "salaire",
+			CategoryKind: util.INCOME,
+			Amount:       decimal.RequireFromString("2500"),
+		},
+	},
+}
+
+func TestWriteLedgerJournal(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJournal(&buf, HLEDGER, sampleJournal))
+
+	require.Equal(t, `commodity EUR
+
+account Assets:Compte courant
+account Assets:Livret A
+account Equity:Opening Balances
+account Expenses:Food:Groceries
+account Income:salaire
+
+2024-03-12 * Opening balance
+    Assets:Compte courant  100.00 EUR
+    Equity:Opening Balances  -100.00 EUR
+
+2024-03-12 * SuperU "Nord"
+    ; weekly
+    Assets:Compte courant  -57.30 EUR
+    Expenses:Food:Groceries  57.30 EUR
+
+2024-03-14 * Savings
+    Assets:Compte courant  -50.00 EUR
+    Assets:Livret A  50.00 EUR
+
+2024-03-28 Salary
+    Assets:Compte courant  2500.00 EUR
+    Income:salaire  -2500.00 EUR
+
+`, buf.String())
+}
+
+func TestWriteBeancountJournal(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJournal(&buf, BEANCOUNT, sampleJournal))
+
+	require.Equal(t, `option "operating_currency" "EUR"
+
+2024-03-12 commodity EUR
+
+2024-03-12 open Assets:Compte-courant
+2024-03-12 open Assets:Livret-A
+2024-03-12 open Equity:Opening-Balances
+2024-03-12 open Expenses:Food:Groceries
+2024-03-12 open Income:Salaire
+
+2024-03-12 * "Opening balance"
+  Assets:Compte-courant  100.00 EUR
+  Equity:Opening-Balances  -100.00 EUR
+
+2024-03-12 * "SuperU \"Nord\"" "weekly"
+  Assets:Compte-courant  -57.30 EUR
+  Expenses:Food:Groceries  57.30 EUR
+
+2024-03-14 * "Savings"
+  Assets:Compte-courant  -50.00 EUR
+  Assets:Livret-A  50.00 EUR
+
+2024-03-28 ! "Salary"
+  Assets:Compte-courant  2500.00 EUR
+  Income:Salaire  -2500.00 EUR
+
+`, buf.String())
+}
+
+func TestBeancountComponent(t *testing.T) {
+	names := journalNames{beancount: true}
+	require.Equal(t, "Épargne-logement", names.component("épargne  logement!"))
+	require.Equal(t, "2024-trip", names.component("2024 trip"))
+	require.Equal(t, "Unknown", names.component("  --  "))
+}
+
+func TestUnpairedTransfer(t *testing.T) {
+	journal := Journal{
+		Currency: "USD",
+		Lines: []Line{
+			{ID: 1, Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Title: "Out", Account: "Checking", Category: "Transfer", CategoryKind: util.TRANSFER, Amount: decimal.RequireFromString("-10")},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJournal(&buf, LEDGER, journal))
+	require.Contains(t, buf.String(), "    Expenses:Transfer  10.00 USD\n")
+}
+
+func TestJournalCategoryKind(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	journal := Journal{
+		Currency: "USD",
+		Lines: []Line{
+			{ID: 1, Date: date, Title: "Refund", Account: "Checking", Category: "Food", CategoryKind: util.EXPENSE, Amount: decimal.RequireFromString("12")},
+			{ID: 2, Date: date, Title: "Salary fix", Account: "Checking", Category: "Salary", CategoryKind: util.INCOME, Amount: decimal.RequireFromString("-30")},
+			{ID: 3, Date: date, Title: "Same day", Account: "Checking", Category: "Transfer", CategoryKind: util.EXPENSE, Amount: decimal.RequireFromString("-10")},
+			{ID: 4, Date: date, Title: "Same day", Account: "Savings", Category: "Transfer", CategoryKind: util.EXPENSE, Amount: decimal.RequireFromString("10")},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJournal(&buf, LEDGER, journal))
+	require.Contains(t, buf.String(), "    Expenses:Food  -12.00 USD\n")
+	require.Contains(t, buf.String(), "    Income:Salary  30.00 USD\n")
+	// A category titled like a transfer is not one when its kind says otherwise
+	require.Contains(t, buf.String(), "    Expenses:Transfer  10.00 USD\n")
+	require.Contains(t, buf.String(), "    Expenses:Transfer  -10.00 USD\n")
+}
@@ -0,0 +1,42 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+// NewJSONWriter writes lines as a JSON array, one element at a time
+func NewJSONWriter(w io.Writer) (LineWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+
+	return &jsonWriter{w: w}, nil
+}
+
+func (writer *jsonWriter) Write(line Line) error {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+
+	if writer.count > 0 {
+		if _, err := io.WriteString(writer.w, ","); err != nil {
+			return err
+		}
+	}
+	writer.count++
+
+	_, err = writer.w.Write(data)
+	return err
+}
+
+func (writer *jsonWriter) Close() error {
+	_, err := io.WriteString(writer.w, "]")
+	return err
+}
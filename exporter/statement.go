@@ -0,0 +1,206 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/moth13/finance_tracker/pdf"
+	decimal "github.com/shopspring/decimal"
+)
+
+// Statement layout, in points
+const (
+	statementMargin     = 50.0
+	statementRight      = pdf.PageWidth - statementMargin
+	statementLineHeight = 14.0
+	statementFontSize   = 9.0
+	// statementTitleWidth is the number of characters of a line title that fit its column
+	statementTitleWidth = 58
+)
+
+// Statement is the content of an account statement over a month or a year
+type Statement struct {
+	Owner    string
+	Currency string
+	Account  string
+	Period   string
+	From     time.Time
+	To       time.Time
+	// OpeningBalance only counts checked lines, as the account balance does
+	OpeningBalance decimal.Decimal
+	// OpeningFinalBalance counts every line, as the account final balance does
+	OpeningFinalBalance decimal.Decimal
+	Lines               []Line
+	GeneratedAt         time.Time
+}
+
+// StatementTotals sums the lines of a statement
+type StatementTotals struct {
+	In             decimal.Decimal
+	Out            decimal.Decimal
+	ClosingBalance decimal.Decimal
+	FinalBalance   decimal.Decimal
+}
+
+// Totals returns money in and out over the period, the closing balance of checked
+// lines and the final balance projected once every line is checked
+func (statement Statement) Totals() StatementTotals {
+	totals := StatementTotals{
+		ClosingBalance: statement.OpeningBalance,
+		FinalBalance:   statement.OpeningFinalBalance,
+	}
+
+	for _, line := range statement.Lines {
+		if line.Amount.IsNegative() {
+			totals.Out = totals.Out.Add(line.Amount)
+		} else {
+			totals.In = totals.In.Add(line.Amount)
+		}
+		if line.Checked {
+			totals.ClosingBalance = totals.ClosingBalance.Add(line.Amount)
+		}
+		totals.FinalBalance = totals.FinalBalance.Add(line.Amount)
+	}
+
+	return totals
+}
+
+// statementWriter lays out a statement, adding pages as they fill up
+type statementWriter struct {
+	doc      *pdf.Document
+	page     *pdf.Page
+	y        float64
+	currency string
+}
+
+// WriteStatement writes a statement as a PDF, lines grouped by category
+func WriteStatement(w io.Writer, statement Statement) error {
+	writer := &statementWriter{doc: pdf.New(), currency: statement.Currency}
+	writer.newPage()
+
+	writer.page.Text(statementMargin, writer.y, pdf.HelveticaBold, 18, "Account statement")
+	writer.y -= 28
+	writer.label("Account", statement.Account)
+	writer.label("Period", fmt.Sprintf("%s (%s to %s)", statement.Period,
+		statement.From.Format(dateLayout), statement.To.Format(dateLayout)))
+	writer.label("Owner", statement.Owner)
+	writer.y -= statementLineHeight
+
+	totals := statement.Totals()
+	writer.heading("Summary")
+	writer.amount("Opening balance", statement.OpeningBalance, false)
+	writer.amount("Money in", totals.In, false)
+	writer.amount("Money out", totals.Out, false)
+	writer.amount("Closing balance", totals.ClosingBalance, true)
+	writer.amount("Projected final balance", totals.FinalBalance, false)
+	writer.y -= statementLineHeight
+
+	categories := make(map[string][]Line)
+	for _, line := range statement.Lines {
+		categories[line.Category] = append(categories[line.Category], line)
+	}
+	titles := make([]string, 0, len(categories))
+	for title := range categories {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+
+	for _, title := range titles {
+		lines := categories[title]
+		sort.SliceStable(lines, func(i, j int) bool {
+			return lines[i].Date.Before(lines[j].Date)
+		})
+
+		// Keep a category heading with at least its first line
+		writer.ensureSpace(3 * statementLineHeight)
+		writer.heading(title)
+
+		subtotal := decimal.Zero
+		for _, line := range lines {
+			writer.line(line)
+			subtotal = subtotal.Add(line.Amount)
+		}
+		writer.amount("Total "+title, subtotal, true)
+		writer.y -= statementLineHeight / 2
+	}
+
+	if len(statement.Lines) == 0 {
+		writer.page.Text(statementMargin, writer.y, pdf.Helvetica, statementFontSize, "No line over this period.")
+	}
+
+	writer.footers(statement.GeneratedAt)
+
+	_, err := writer.doc.WriteTo(w)
+	return err
+}
+
+func (writer *statementWriter) newPage() {
+	writer.page = writer.doc.AddPage()
+	writer.y = pdf.PageHeight - statementMargin - 10
+}
+
+// ensureSpace starts a new page when less than height is left above the footer
+func (writer *statementWriter) ensureSpace(height float64) {
+	if writer.y-height < statementMargin+statementLineHeight {
+		writer.newPage()
+	}
+}
+
+func (writer *statementWriter) label(label, value string) {
+	writer.ensureSpace(statementLineHeight)
+	writer.page.Text(statementMargin, writer.y, pdf.HelveticaBold, 10, label)
+	writer.page.Text(statementMargin+80, writer.y, pdf.Helvetica, 10, value)
+	writer.y -= statementLineHeight
+}
+
+func (writer *statementWriter) heading(title string) {
+	writer.ensureSpace(statementLineHeight + 4)
+	writer.page.Rect(statementMargin, writer.y-4, statementRight-statementMargin, statementLineHeight, 0.9)
+	writer.page.Text(statementMargin+4, writer.y, pdf.HelveticaBold, 10, title)
+	writer.y -= statementLineHeight + 4
+}
+
+func (writer *statementWriter) amount(label string, amount decimal.Decimal, bold bool) {
+	writer.ensureSpace(statementLineHeight)
+	font := pdf.Helvetica
+	if bold {
+		font = pdf.HelveticaBold
+	}
+	writer.page.Text(statementMargin+4, writer.y, font, statementFontSize, label)
+	writer.page.TextRight(statementRight, writer.y, statementFontSize, writer.money(amount))
+	writer.y -= statementLineHeight
+}
+
+func (writer *statementWriter) line(line Line) {
+	writer.ensureSpace(statementLineHeight)
+
+	title := []rune(line.Title)
+	if len(title) > statementTitleWidth {
+		title = append(title[:statementTitleWidth-3], []rune("...")...)
+	}
+
+	writer.page.Text(statementMargin+4, writer.y, pdf.Helvetica, statementFontSize, line.Date.Format(dateLayout))
+	writer.page.Text(statementMargin+64, writer.y, pdf.Helvetica, statementFontSize, string(title))
+	if !line.Checked {
+		writer.page.Text(statementRight-150, writer.y, pdf.Helvetica, statementFontSize, "pending")
+	}
+	writer.page.TextRight(statementRight, writer.y, statementFontSize, writer.money(line.Amount))
+	writer.y -= statementLineHeight
+}
+
+// footers numbers every page once the layout is done
+func (writer *statementWriter) footers(generatedAt time.Time) {
+	pages := writer.doc.Pages()
+	for i, page := range pages {
+		y := statementMargin / 2
+		page.Line(statementMargin, y+10, statementRight, y+10, 0.5)
+		page.Text(statementMargin, y, pdf.Helvetica, 8, "Generated on "+generatedAt.Format("2006-01-02 15:04"))
+		page.TextRight(statementRight, y, 8, fmt.Sprintf("Page %d/%d", i+1, len(pages)))
+	}
+}
+
+func (writer *statementWriter) money(amount decimal.Decimal) string {
+	return amount.StringFixed(2) + " " + writer.currency
+}
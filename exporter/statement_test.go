@@ -0,0 +1,88 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleStatement = Statement{
+	Owner:               "Jose",
+	Currency:            "EUR",
+	Account:             "Compte courant",
+	Period:              "March 2024",
+	From:                time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	To:                  time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	OpeningBalance:      decimal.RequireFromString("100"),
+	OpeningFinalBalance: decimal.RequireFromString("80"),
+	Lines: []Line{
+		{
+			Date:     time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC),
+			Title:    "SuperU",
+			Category: "Food",
+			Amount:   decimal.RequireFromString("-57.3"),
+			Checked:  true,
+		},
+		{
+			Date:     time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC),
+			Title:    "Salary",
+			Category: "Income",
+			Amount:   decimal.RequireFromString("2500"),
+		},
+		{
+			Date:     time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+			Title:    "Bakery",
+			Category: "Food",
+			Amount:   decimal.RequireFromString("-4.2"),
+			Checked:  true,
+		},
+	},
+	GeneratedAt: time.Date(2024, 4, 1, 9, 30, 0, 0, time.UTC),
+}
+
+func TestStatementTotals(t *testing.T) {
+	totals := sampleStatement.Totals()
+
+	require.Equal(t, "2500", totals.In.String())
+	require.Equal(t, "-61.5", totals.Out.String())
+	require.Equal(t, "38.5", totals.ClosingBalance.String())
+	require.Equal(t, "2518.5", totals.FinalBalance.String())
+}
+
+func TestWriteStatement(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatement(&buf, sampleStatement))
+	out := buf.String()
+
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	require.Contains(t, out, "(Compte courant)")
+	require.Contains(t, out, "(Total Food)")
+	require.Contains(t, out, "(-61.50 EUR)")
+	require.Contains(t, out, "(38.50 EUR)")
+	require.Contains(t, out, "(2518.50 EUR)")
+	require.Contains(t, out, "(pending)")
+	require.Contains(t, out, "(Page 1/1)")
+}
+
+func TestWriteStatementPages(t *testing.T) {
+	statement := sampleStatement
+	statement.Lines = nil
+	for i := 0; i < 120; i++ {
+		statement.Lines = append(statement.Lines, Line{
+			Date:     statement.From.AddDate(0, 0, i%28),
+			Title:    fmt.Sprintf("Line %d", i),
+			Category: "Food",
+			Amount:   decimal.RequireFromString("-1"),
+			Checked:  true,
+		})
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatement(&buf, statement))
+	require.Contains(t, buf.String(), "(Page 3/3)")
+	require.NotContains(t, buf.String(), "(Page 4/")
+}
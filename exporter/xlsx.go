@@ -0,0 +1,271 @@
+package exporter
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Cell styles declared in xlsxStyles
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleDate
+	xlsxStyleAmount
+	xlsxStyleHeader
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet2.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="Lines" sheetId="1" r:id="rId1"/><sheet name="Categories" sheetId="2" r:id="rId2"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>` +
+	`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
+
+const xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetEnd = `</sheetData></worksheet>`
+
+// xlsxEpoch is the day spreadsheets count dates from
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// categorySummary totals the lines of a category for the summary sheet
+type categorySummary struct {
+	income  decimal.Decimal
+	expense decimal.Decimal
+	count   int64
+}
+
+type xlsxWriter struct {
+	zw         *zip.Writer
+	sheet      io.Writer
+	row        int
+	categories map[string]*categorySummary
+}
+
+// NewXLSXWriter writes lines as a spreadsheet with typed cells and a per category summary sheet
+func NewXLSXWriter(w io.Writer) (LineWriter, error) {
+	writer := &xlsxWriter{
+		zw:         zip.NewWriter(w),
+		categories: make(map[string]*categorySummary),
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		if err := writer.writePart(part.name, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := writer.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	writer.sheet = sheet
+
+	if _, err := io.WriteString(writer.sheet, xlsxSheetStart); err != nil {
+		return nil, err
+	}
+	if err := writer.writeHeader(writer.sheet, header); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (writer *xlsxWriter) Write(line Line) error {
+	writer.row++
+
+	summary, ok := writer.categories[line.Category]
+	if !ok {
+		summary = &categorySummary{}
+		writer.categories[line.Category] = summary
+	}
+	summary.count++
+	if line.Amount.IsNegative() {
+		summary.expense = summary.expense.Add(line.Amount)
+	} else {
+		summary.income = summary.income.Add(line.Amount)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, writer.row)
+	writeNumber(&b, 0, writer.row, fmt.Sprint(line.ID), xlsxStyleDefault)
+	writeNumber(&b, 1, writer.row, fmt.Sprint(xlsxDate(line.Date)), xlsxStyleDate)
+	writeString(&b, 2, writer.row, line.Title)
+	writeString(&b, 3, writer.row, line.Account)
+	writeString(&b, 4, writer.row, line.Month)
+	writeString(&b, 5, writer.row, line.Category)
+	writeNumber(&b, 6, writer.row, line.Amount.String(), xlsxStyleAmount)
+	writeBool(&b, 7, writer.row, line.Checked)
+	writeString(&b, 8, writer.row, line.Description)
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(writer.sheet, b.String())
+	return err
+}
+
+func (writer *xlsxWriter) Close() error {
+	if _, err := io.WriteString(writer.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	summary, err := writer.zw.Create("xl/worksheets/sheet2.xml")
+	if err != nil {
+		return err
+	}
+	if err := writer.writeSummary(summary); err != nil {
+		return err
+	}
+
+	return writer.zw.Close()
+}
+
+// writeSummary writes the income, expense and total of every category, sorted by title
+func (writer *xlsxWriter) writeSummary(w io.Writer) error {
+	if _, err := io.WriteString(w, xlsxSheetStart); err != nil {
+		return err
+	}
+	writer.row = 0
+	if err := writer.writeHeader(w, []string{"category", "income", "expense", "total", "count"}); err != nil {
+		return err
+	}
+
+	titles := make([]string, 0, len(writer.categories))
+	for title := range writer.categories {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+
+	for _, title := range titles {
+		summary := writer.categories[title]
+		writer.row++
+
+		var b strings.Builder
+		fmt.Fprintf(&b, `<row r="%d">`, writer.row)
+		writeString(&b, 0, writer.row, title)
+		writeNumber(&b, 1, writer.row, summary.income.String(), xlsxStyleAmount)
+		writeNumber(&b, 2, writer.row, summary.expense.String(), xlsxStyleAmount)
+		writeNumber(&b, 3, writer.row, summary.income.Add(summary.expense).String(), xlsxStyleAmount)
+		writeNumber(&b, 4, writer.row, fmt.Sprint(summary.count), xlsxStyleDefault)
+		b.WriteString(`</row>`)
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, xlsxSheetEnd)
+	return err
+}
+
+func (writer *xlsxWriter) writeHeader(w io.Writer, titles []string) error {
+	writer.row++
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, writer.row)
+	for col, title := range titles {
+		fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t>`, cellRef(col, writer.row), xlsxStyleHeader)
+		xml.EscapeText(&b, []byte(title))
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (writer *xlsxWriter) writePart(name, content string) error {
+	part, err := writer.zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(part, content)
+	return err
+}
+
+func writeString(b *strings.Builder, col, row int, value string) {
+	fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, cellRef(col, row))
+	xml.EscapeText(b, []byte(value))
+	b.WriteString(`</t></is></c>`)
+}
+
+func writeNumber(b *strings.Builder, col, row int, value string, style int) {
+	fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, cellRef(col, row), style, value)
+}
+
+func writeBool(b *strings.Builder, col, row int, value bool) {
+	v := 0
+	if value {
+		v = 1
+	}
+	fmt.Fprintf(b, `<c r="%s" t="b"><v>%d</v></c>`, cellRef(col, row), v)
+}
+
+// cellRef returns the A1 reference of a zero based column and a one based row
+func cellRef(col, row int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+
+	return fmt.Sprintf("%s%d", name, row)
+}
+
+// xlsxDate returns the spreadsheet serial number of a day
+func xlsxDate(date time.Time) int64 {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(day.Sub(xlsxEpoch).Hours() / 24)
+}
@@ -0,0 +1,152 @@
+package forecast
+
+import (
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+// Account is an account and its balance at the start of the forecast
+type Account struct {
+	ID      int64
+	Title   string
+	Balance decimal.Decimal
+}
+
+// Movement is an amount expected on an account at a date, from an unchecked line or a recline
+type Movement struct {
+	AccountID int64
+	Title     string
+	Amount    decimal.Decimal
+	Date      time.Time
+}
+
+// Recurrence is a recline, expanded into a movement for each of its occurrences
+type Recurrence struct {
+	AccountID  int64
+	Title      string
+	Amount     decimal.Decimal
+	DueDate    time.Time
+	Recurrency string
+}
+
+// Day is a balance at the end of a day
+type Day struct {
+	Date    time.Time       `json:"date"`
+	Balance decimal.Decimal `json:"balance"`
+}
+
+// AccountForecast is the daily balance of an account
+type AccountForecast struct {
+	AccountID int64           `json:"account_id"`
+	Title     string          `json:"title"`
+	Start     decimal.Decimal `json:"start"`
+	Days      []Day           `json:"days"`
+	// Lowest is the first day of the lowest balance
+	Lowest Day `json:"lowest"`
+}
+
+// Forecast is the daily balance of each account and of all of them together
+type Forecast struct {
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	Accounts []AccountForecast `json:"accounts"`
+	Days     []Day             `json:"days"`
+	Lowest   Day               `json:"lowest"`
+}
+
+// Occurrences returns the movements of a recurrence from from to to, both included.
+// A recline without supported recurrency happens once, on its due date.
+func Occurrences(recurrence Recurrence, from, to time.Time) []Movement {
+	movement := func(date time.Time) Movement {
+		return Movement{
+			AccountID: recurrence.AccountID,
+			Title:     recurrence.Title,
+			Amount:    recurrence.Amount,
+			Date:      date,
+		}
+	}
+
+	from, to = day(from), day(to)
+	if !util.IsSupportedRecurrency(recurrence.Recurrency) {
+		if day(recurrence.DueDate).Before(from) || day(recurrence.DueDate).After(to) {
+			return nil
+		}
+		return []Movement{movement(recurrence.DueDate)}
+	}
+
+	var movements []Movement
+	for n := 0; ; n++ {
+		date := util.AddRecurrency(recurrence.DueDate, recurrence.Recurrency, n)
+		if day(date).After(to) {
+			return movements
+		}
+		if !day(date).Before(from) {
+			movements = append(movements, movement(date))
+		}
+	}
+}
+
+// Project returns the daily balance of the accounts over days days starting on from.
+// Movements dated before from are late and counted on the first day.
+func Project(accounts []Account, movements []Movement, from time.Time, days int) Forecast {
+	from = day(from)
+	to := from.AddDate(0, 0, days-1)
+	result := Forecast{
+		From:     from,
+		To:       to,
+		Accounts: []AccountForecast{},
+		Days:     []Day{},
+	}
+
+	// Amounts moving each account at the end of each day
+	moves := map[int64]map[int]decimal.Decimal{}
+	for _, movement := range movements {
+		offset := max(int(day(movement.Date).Sub(from).Hours()/24), 0)
+		if offset >= days {
+			continue
+		}
+		if moves[movement.AccountID] == nil {
+			moves[movement.AccountID] = map[int]decimal.Decimal{}
+		}
+		moves[movement.AccountID][offset] = moves[movement.AccountID][offset].Add(movement.Amount)
+	}
+
+	totals := make([]decimal.Decimal, days)
+	for _, account := range accounts {
+		forecast := AccountForecast{
+			AccountID: account.ID,
+			Title:     account.Title,
+			Start:     account.Balance,
+			Days:      make([]Day, 0, days),
+		}
+
+		balance := account.Balance
+		for offset := 0; offset < days; offset++ {
+			balance = balance.Add(moves[account.ID][offset])
+			current := Day{Date: from.AddDate(0, 0, offset), Balance: balance}
+			forecast.Days = append(forecast.Days, current)
+			if offset == 0 || balance.LessThan(forecast.Lowest.Balance) {
+				forecast.Lowest = current
+			}
+			totals[offset] = totals[offset].Add(balance)
+		}
+
+		result.Accounts = append(result.Accounts, forecast)
+	}
+
+	for offset, total := range totals {
+		current := Day{Date: from.AddDate(0, 0, offset), Balance: total}
+		result.Days = append(result.Days, current)
+		if offset == 0 || total.LessThan(result.Lowest.Balance) {
+			result.Lowest = current
+		}
+	}
+
+	return result
+}
+
+func day(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,72 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestOccurrences(t *testing.T) {
+	rent := Recurrence{
+		AccountID:  1,
+		Title:      "Rent",
+		Amount:     decimal.NewFromInt(-700),
+		DueDate:    date(time.January, 31),
+		Recurrency: util.MONTHLY,
+	}
+
+	// Occurrences before the forecast are left out, the last day is included
+	movements := Occurrences(rent, date(time.February, 1), date(time.March, 31))
+	require.Len(t, movements, 2)
+	require.Equal(t, date(time.February, 29), movements[0].Date)
+	require.Equal(t, date(time.March, 31), movements[1].Date)
+	require.True(t, rent.Amount.Equal(movements[0].Amount))
+
+	// Without recurrency, a recline happens once
+	rent.Recurrency = ""
+	require.Len(t, Occurrences(rent, date(time.January, 1), date(time.March, 31)), 1)
+	require.Empty(t, Occurrences(rent, date(time.February, 1), date(time.March, 31)))
+}
+
+func TestProject(t *testing.T) {
+	accounts := []Account{
+		{ID: 1, Title: "Checking", Balance: decimal.NewFromInt(100)},
+		{ID: 2, Title: "Savings", Balance: decimal.NewFromInt(1000)},
+	}
+	movements := []Movement{
+		// Late, counted on the first day
+		{AccountID: 1, Amount: decimal.NewFromInt(-20), Date: date(time.February, 20)},
+		{AccountID: 1, Amount: decimal.NewFromInt(-150), Date: date(time.March, 3)},
+		{AccountID: 1, Amount: decimal.NewFromInt(200), Date: date(time.March, 5)},
+		{AccountID: 2, Amount: decimal.NewFromInt(-50), Date: date(time.March, 2)},
+		// After the forecast
+		{AccountID: 2, Amount: decimal.NewFromInt(-5000), Date: date(time.March, 20)},
+	}
+
+	forecast := Project(accounts, movements, date(time.March, 1), 10)
+	require.Equal(t, date(time.March, 1), forecast.From)
+	require.Equal(t, date(time.March, 10), forecast.To)
+	require.Len(t, forecast.Days, 10)
+	require.Len(t, forecast.Accounts, 2)
+
+	checking := forecast.Accounts[0]
+	require.True(t, decimal.NewFromInt(100).Equal(checking.Start))
+	require.True(t, decimal.NewFromInt(80).Equal(checking.Days[0].Balance))
+	require.Equal(t, date(time.March, 3), checking.Lowest.Date)
+	require.True(t, decimal.NewFromInt(-70).Equal(checking.Lowest.Balance))
+	require.True(t, decimal.NewFromInt(130).Equal(checking.Days[9].Balance))
+
+	savings := forecast.Accounts[1]
+	require.True(t, decimal.NewFromInt(950).Equal(savings.Days[9].Balance))
+
+	require.Equal(t, date(time.March, 3), forecast.Lowest.Date)
+	require.True(t, decimal.NewFromInt(880).Equal(forecast.Lowest.Balance))
+	require.True(t, decimal.NewFromInt(1080).Equal(forecast.Days[9].Balance))
+}
@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+const (
+	fireflyWithdrawal     = "withdrawal"
+	fireflyDeposit        = "deposit"
+	fireflyTransfer       = "transfer"
+	fireflyOpeningBalance = "opening balance"
+
+	fireflyAssetAccount = "asset account"
+)
+
+// ParseFirefly reads the transactions CSV export of Firefly III
+func ParseFirefly(r io.Reader, opts Options) (Book, error) {
+	records, err := csvRecords(r)
+	if err != nil {
+		return Book{}, err
+	}
+
+	var book Book
+	accounts := map[string]int{}
+	addAccount := func(title string) int {
+		if i, ok := accounts[title]; ok {
+			return i
+		}
+		accounts[title] = len(book.Accounts)
+		book.Accounts = append(book.Accounts, Account{Title: title})
+		return accounts[title]
+	}
+
+	for i, record := range records {
+		date, err := fireflyDate(record["date"])
+		if err != nil {
+			return Book{}, fmt.Errorf("record %d: %w", i+1, err)
+		}
+
+		amount, err := parseAmount(record["amount"])
+		if err != nil {
+			return Book{}, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		amount = amount.Abs()
+
+		source := record["source_name"]
+		destination := record["destination_name"]
+		sourceIsAsset := strings.ToLower(record["source_type"]) == fireflyAssetAccount
+		destinationIsAsset := strings.ToLower(record["destination_type"]) == fireflyAssetAccount
+
+		base := Transaction{
+			Date:        date,
+			Title:       reference(record["description"], record["group_title"]),
+			Description: record["notes"],
+			Category:    categoryPath(strings.Split(record["category"], categorySeparator), opts.FlattenCategories),
+			Reference:   reference(record["journal_id"], record["transaction_journal_id"]),
+			Checked:     strings.EqualFold(record["reconciled"], "true"),
+		}
+
+		switch strings.ToLower(record["type"]) {
+		case fireflyOpeningBalance:
+			if destinationIsAsset {
+				book.Accounts[addAccount(destination)].InitBalance = amount
+			} else if sourceIsAsset {
+				book.Accounts[addAccount(source)].InitBalance = amount.Neg()
+			}
+		case fireflyWithdrawal:
+			addAccount(source)
+			book.Transactions = append(book.Transactions, fireflyTransaction(base, source, amount.Neg(), destination))
+		case fireflyDeposit:
+			addAccount(destination)
+			book.Transactions = append(book.Transactions, fireflyTransaction(base, destination, amount, source))
+		case fireflyTransfer:
+			if sourceIsAsset {
+				addAccount(source)
+				out := fireflyTransaction(base, source, amount.Neg(), destination)
+				out.Reference += ":out"
+				book.Transactions = append(book.Transactions, out)
+			}
+			if destinationIsAsset {
+				addAccount(destination)
+				in := fireflyTransaction(base, destination, amount, source)
+				in.Reference += ":in"
+				book.Transactions = append(book.Transactions, in)
+			}
+		}
+	}
+
+	return book, nil
+}
+
+func fireflyTransaction(base Transaction, account string, amount decimal.Decimal, counterpart string) Transaction {
+	transaction := base
+	transaction.Account = account
+	transaction.Amount = amount
+	if transaction.Title == "" {
+		transaction.Title = counterpart
+	}
+	transaction.Reference = reference(transaction.Reference, transaction.Title)
+	return transaction
+}
+
+// fireflyDate reads the RFC 3339 dates of the export, keeping the local day
+func fireflyDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+
+	if len(value) >= 10 {
+		if date, err := time.Parse("2006-01-02", value[:10]); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+const fireflySample = `user_id,group_id,journal_id,created_at,updated_at,group_title,type,amount,foreign_amount,currency_code,foreign_currency_code,description,date,source_name,source_iban,source_type,destination_name,destination_iban,destination_type,reconciled,category,budget,bill,tags,notes
+1,1,1,2024-12-01T10:00:00+01:00,2024-12-01T10:00:00+01:00,,Opening balance,207.34,,EUR,,Initial balance,2024-12-01T00:00:00+01:00,Initial balance for Checking,,Initial balance account,Checking,,Asset account,true,,,,,
+1,2,2,2024-12-03T10:00:00+01:00,2024-12-03T10:00:00+01:00,,Withdrawal,-57.30,,EUR,,Groceries,2024-12-03T00:00:00+01:00,Checking,,Asset account,SuperU,,Expense account,false,Food,,,,weekly
+1,3,3,2024-12-05T10:00:00+01:00,2024-12-05T10:00:00+01:00,,Transfer,100,,EUR,,Saving,2024-12-05T00:00:00+01:00,Checking,,Asset account,Savings,,Asset account,true,,,,,
+`
+
+func TestParseFirefly(t *testing.T) {
+	book, err := ParseFirefly(strings.NewReader(fireflySample), Options{})
+	require.NoError(t, err)
+
+	require.Len(t, book.Accounts, 2)
+	require.Equal(t, "Checking", book.Accounts[0].Title)
+	require.True(t, book.Accounts[0].InitBalance.Equal(decimal.RequireFromString("207.34")))
+	require.Equal(t, "Savings", book.Accounts[1].Title)
+
+	require.Len(t, book.Transactions, 3)
+	withdrawal := book.Transactions[0]
+	require.Equal(t, "Checking", withdrawal.Account)
+	require.Equal(t, time.Date(2024, 12, 3, 0, 0, 0, 0, time.UTC), withdrawal.Date)
+	require.True(t, withdrawal.Amount.Equal(decimal.RequireFromString("-57.30")))
+	require.Equal(t, "Food", withdrawal.Category)
+	require.Equal(t, "2", withdrawal.Reference)
+	require.False(t, withdrawal.Checked)
+
+	out, in := book.Transactions[1], book.Transactions[2]
+	require.Equal(t, "Checking", out.Account)
+	require.True(t, out.Amount.Equal(decimal.RequireFromString("-100")))
+	require.Equal(t, "Savings", in.Account)
+	require.True(t, in.Amount.Equal(decimal.RequireFromString("100")))
+	require.NotEqual(t, out.Reference, in.Reference)
+}
@@ -0,0 +1,245 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+)
+
+const (
+	homeBankCleared    = 1
+	homeBankReconciled = 2
+
+	homeBankWeek  = 1
+	homeBankMonth = 2
+	homeBankYear  = 3
+
+	homeBankSplitSeparator = "||"
+)
+
+type homeBankAccount struct {
+	Key     int    `xml:"key,attr"`
+	Name    string `xml:"name,attr"`
+	Number  string `xml:"number,attr"`
+	Bank    string `xml:"bankname,attr"`
+	Initial string `xml:"initial,attr"`
+}
+
+type homeBankPayee struct {
+	Key  int    `xml:"key,attr"`
+	Name string `xml:"name,attr"`
+}
+
+type homeBankCategory struct {
+	Key    int    `xml:"key,attr"`
+	Parent int    `xml:"parent,attr"`
+	Name   string `xml:"name,attr"`
+}
+
+type homeBankOperation struct {
+	Date     int64  `xml:"date,attr"`
+	Amount   string `xml:"amount,attr"`
+	Account  int    `xml:"account,attr"`
+	Status   int    `xml:"st,attr"`
+	Payee    int    `xml:"payee,attr"`
+	Category int    `xml:"category,attr"`
+	Wording  string `xml:"wording,attr"`
+	Info     string `xml:"info,attr"`
+	SplitCat string `xml:"scat,attr"`
+	SplitAmt string `xml:"samt,attr"`
+	SplitMem string `xml:"smem,attr"`
+}
+
+type homeBankScheduled struct {
+	Amount   string `xml:"amount,attr"`
+	Account  int    `xml:"account,attr"`
+	Payee    int    `xml:"payee,attr"`
+	Category int    `xml:"category,attr"`
+	Wording  string `xml:"wording,attr"`
+	NextDate int64  `xml:"nextdate,attr"`
+	Every    int    `xml:"every,attr"`
+	Unit     int    `xml:"unit,attr"`
+}
+
+type homeBankFile struct {
+	XMLName    xml.Name            `xml:"homebank"`
+	Accounts   []homeBankAccount   `xml:"account"`
+	Payees     []homeBankPayee     `xml:"pay"`
+	Categories []homeBankCategory  `xml:"cat"`
+	Scheduled  []homeBankScheduled `xml:"fav"`
+	Operations []homeBankOperation `xml:"ope"`
+}
+
+// ParseHomeBank reads a HomeBank .xhb file
+func ParseHomeBank(r io.Reader, opts Options) (Book, error) {
+	var file homeBankFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return Book{}, fmt.Errorf("invalid HomeBank file: %w", err)
+	}
+
+	var book Book
+
+	accounts := map[int]string{}
+	for _, account := range file.Accounts {
+		initial, err := homeBankAmount(account.Initial)
+		if err != nil {
+			return Book{}, err
+		}
+		accounts[account.Key] = account.Name
+		book.Accounts = append(book.Accounts, Account{
+			Title:       account.Name,
+			Description: strings.TrimSpace(account.Bank + " " + account.Number),
+			InitBalance: initial,
+		})
+	}
+
+	payees := map[int]string{}
+	for _, payee := range file.Payees {
+		payees[payee.Key] = payee.Name
+	}
+
+	categories := homeBankCategories(file.Categories, opts.FlattenCategories)
+
+	for _, operation := range file.Operations {
+		transactions, err := operation.transactions(accounts, payees, categories)
+		if err != nil {
+			return Book{}, err
+		}
+		book.Transactions = append(book.Transactions, transactions...)
+	}
+
+	for _, scheduled := range file.Scheduled {
+		recurrency := homeBankRecurrency(scheduled.Every, scheduled.Unit)
+		if recurrency == "" || scheduled.NextDate == 0 {
+			continue
+		}
+
+		amount, err := homeBankAmount(scheduled.Amount)
+		if err != nil {
+			return Book{}, err
+		}
+
+		book.Scheduled = append(book.Scheduled, Scheduled{
+			Account:     accounts[scheduled.Account],
+			Title:       reference(payees[scheduled.Payee], scheduled.Wording),
+			Description: scheduled.Wording,
+			Category:    categories[scheduled.Category],
+			Amount:      amount,
+			Recurrency:  recurrency,
+			DueDate:     homeBankDate(scheduled.NextDate),
+		})
+	}
+
+	return book, nil
+}
+
+func (operation homeBankOperation) transactions(accounts map[int]string, payees map[int]string, categories map[int]string) ([]Transaction, error) {
+	amount, err := homeBankAmount(operation.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	date := homeBankDate(operation.Date)
+	title := reference(payees[operation.Payee], operation.Wording, operation.Info)
+	base := Transaction{
+		Account:     accounts[operation.Account],
+		Date:        date,
+		Amount:      amount,
+		Title:       title,
+		Description: operation.Wording,
+		Category:    categories[operation.Category],
+		Reference:   reference(operation.Info, fmt.Sprintf("%s/%s", title, operation.Wording)),
+		Checked:     operation.Status == homeBankCleared || operation.Status == homeBankReconciled,
+	}
+
+	if operation.SplitCat == "" {
+		return []Transaction{base}, nil
+	}
+
+	splitCategories := strings.Split(operation.SplitCat, homeBankSplitSeparator)
+	splitAmounts := strings.Split(operation.SplitAmt, homeBankSplitSeparator)
+	splitMemos := strings.Split(operation.SplitMem, homeBankSplitSeparator)
+	if len(splitAmounts) != len(splitCategories) {
+		return nil, fmt.Errorf("invalid HomeBank split on %s", date.Format("2006-01-02"))
+	}
+
+	transactions := make([]Transaction, 0, len(splitCategories))
+	for i := range splitCategories {
+		transaction := base
+		transaction.Amount, err = homeBankAmount(splitAmounts[i])
+		if err != nil {
+			return nil, err
+		}
+		key, _ := strconv.Atoi(splitCategories[i])
+		transaction.Category = categories[key]
+		if i < len(splitMemos) && splitMemos[i] != "" {
+			transaction.Description = splitMemos[i]
+		}
+		transaction.Reference = fmt.Sprintf("%s#%d", base.Reference, i+1)
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// homeBankCategories resolves each category key to its full path
+func homeBankCategories(list []homeBankCategory, flatten bool) map[int]string {
+	byKey := map[int]homeBankCategory{}
+	for _, category := range list {
+		byKey[category.Key] = category
+	}
+
+	categories := map[int]string{}
+	for _, category := range list {
+		levels := []string{category.Name}
+		seen := map[int]bool{category.Key: true}
+		for parent, ok := byKey[category.Parent]; ok && !seen[parent.Key]; parent, ok = byKey[parent.Parent] {
+			seen[parent.Key] = true
+			levels = append([]string{parent.Name}, levels...)
+		}
+		categories[category.Key] = categoryPath(levels, flatten)
+	}
+
+	return categories
+}
+
+// homeBankDate converts a GLib julian day, day 1 being January 1st of year 1
+func homeBankDate(julian int64) time.Time {
+	return time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(julian-1))
+}
+
+func homeBankAmount(value string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+
+	return amount.Round(4), nil
+}
+
+func homeBankRecurrency(every int, unit int) string {
+	if every != 1 {
+		return ""
+	}
+
+	switch unit {
+	case homeBankWeek:
+		return util.WEEKLY
+	case homeBankMonth:
+		return util.MONTHLY
+	case homeBankYear:
+		return util.ANNUAL
+	}
+
+	return ""
+}
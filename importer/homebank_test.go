@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moth13/finance_tracker/util"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+const homeBankSample = `<?xml version="1.0"?>
+<homebank v="1.4" d="050500">
+<account key="1" flags="0" pos="1" type="1" curr="1" name="Compte courant" number="0001" bankname="Bourso" initial="207.34"/>
+<pay key="1" name="SuperU"/>
+<cat key="1" flags="0" name="Alimentation"/>
+<cat key="2" parent="1" flags="0" name="Supermarché"/>
+<cat key="3" flags="0" name="Maison"/>
+<fav key="1" amount="-13.49" account="1" payee="0" category="3" wording="Netflix" nextdate="739256" every="1" unit="2"/>
+<fav key="2" amount="-5" account="1" payee="0" category="3" wording="Every two days" nextdate="739256" every="2" unit="0"/>
+<ope date="739223" amount="-57.3" account="1" st="1" payee="1" category="2" wording="Courses"/>
+<ope date="739223" amount="-30" account="1" st="0" payee="1" wording="Split" scat="2||3" samt="-20||-10" smem="Food||Soap"/>
+</homebank>`
+
+func TestParseHomeBank(t *testing.T) {
+	book, err := ParseHomeBank(strings.NewReader(homeBankSample), Options{})
+	require.NoError(t, err)
+
+	require.Len(t, book.Accounts, 1)
+	require.Equal(t, "Compte courant", book.Accounts[0].Title)
+	require.Equal(t, "Bourso 0001", book.Accounts[0].Description)
+	require.True(t, book.Accounts[0].InitBalance.Equal(decimal.RequireFromString("207.34")))
+
+	require.Len(t, book.Transactions, 3)
+	first := book.Transactions[0]
+	require.Equal(t, "Compte courant", first.Account)
+	require.Equal(t, time.Date(2024, 12, 3, 0, 0, 0, 0, time.UTC), first.Date)
+	require.Equal(t, "SuperU", first.Title)
+	require.Equal(t, "Alimentation:Supermarché", first.Category)
+	require.True(t, first.Checked)
+
+	require.Equal(t, "Alimentation:Supermarché", book.Transactions[1].Category)
+	require.True(t, book.Transactions[1].Amount.Equal(decimal.RequireFromString("-20")))
+	require.Equal(t, "Maison", book.Transactions[2].Category)
+	require.Equal(t, "Soap", book.Transactions[2].Description)
+	require.NotEqual(t, book.Transactions[1].Reference, book.Transactions[2].Reference)
+
+	require.Len(t, book.Scheduled, 1)
+	require.Equal(t, util.MONTHLY, book.Scheduled[0].Recurrency)
+	require.Equal(t, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), book.Scheduled[0].DueDate)
+	require.Equal(t, "Netflix", book.Scheduled[0].Title)
+}
+
+func TestParseHomeBankFlattenCategories(t *testing.T) {
+	book, err := ParseHomeBank(strings.NewReader(homeBankSample), Options{FlattenCategories: true})
+	require.NoError(t, err)
+	require.Equal(t, "Supermarché", book.Transactions[0].Category)
+}
@@ -0,0 +1,220 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+const (
+	QIF   = "QIF"
+	MT940 = "MT940"
+)
+
+const (
+	HOMEBANK = "HOMEBANK"
+	FIREFLY  = "FIREFLY"
+	YNAB     = "YNAB"
+)
+
+// categorySeparator joins the levels of a category path, as in Housing:Rent
+const categorySeparator = ":"
+
+// Transaction is an operation read from an external file, ready to become a line
+type Transaction struct {
+	Account     string          `json:"account"`
+	Date        time.Time       `json:"date"`
+	Amount      decimal.Decimal `json:"amount"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Category    string          `json:"category"`
+	Reference   string          `json:"reference"`
+	Checked     bool            `json:"checked"`
+}
+
+// Options tunes how ambiguous values are read from a file
+type Options struct {
+	// DayFirst reads 01/02/2024 as the 1st of February instead of January 2nd
+	DayFirst bool
+	// FlattenCategories keeps only the last level of a category path
+	FlattenCategories bool
+}
+
+// Account is an account read from another finance application
+type Account struct {
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	InitBalance decimal.Decimal `json:"init_balance"`
+}
+
+// Scheduled is a recurring operation read from another finance application
+type Scheduled struct {
+	Account     string          `json:"account"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Category    string          `json:"category"`
+	Amount      decimal.Decimal `json:"amount"`
+	Recurrency  string          `json:"recurrency"`
+	DueDate     time.Time       `json:"due_date"`
+}
+
+// Book is everything read from another finance application export
+type Book struct {
+	Accounts     []Account     `json:"accounts"`
+	Transactions []Transaction `json:"transactions"`
+	Scheduled    []Scheduled   `json:"scheduled"`
+}
+
+// IsSupportedFormat returns true if the import format is supported
+func IsSupportedFormat(format string) bool {
+	switch format {
+	case QIF, MT940:
+		return true
+	}
+
+	return false
+}
+
+// Parse reads all transactions of a file in the given format
+func Parse(format string, r io.Reader, opts Options) ([]Transaction, error) {
+	var transactions []Transaction
+	var err error
+	switch format {
+	case QIF:
+		transactions, err = ParseQIF(r, opts)
+	case MT940:
+		transactions, err = ParseMT940(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return numberRepeats(transactions), nil
+}
+
+// IsSupportedBookFormat returns true if the finance application export is supported
+func IsSupportedBookFormat(format string) bool {
+	switch format {
+	case HOMEBANK, FIREFLY, YNAB:
+		return true
+	}
+
+	return false
+}
+
+// ParseBook reads the accounts, transactions and scheduled operations of an application export
+func ParseBook(format string, r io.Reader, opts Options) (Book, error) {
+	var book Book
+	var err error
+	switch format {
+	case HOMEBANK:
+		book, err = ParseHomeBank(r, opts)
+	case FIREFLY:
+		book, err = ParseFirefly(r, opts)
+	case YNAB:
+		book, err = ParseYNAB(r, opts)
+	default:
+		return Book{}, fmt.Errorf("unsupported import format %s", format)
+	}
+	if err != nil {
+		return Book{}, err
+	}
+
+	book.Transactions = numberRepeats(book.Transactions)
+	return book, nil
+}
+
+// numberRepeats numbers the transactions sharing account, date, amount and reference within a file,
+// as two coffees bought the same day, so the dedupe of imports only skips what an earlier import created.
+// The first one keeps its reference, reimporting the same file still gives the same references.
+func numberRepeats(transactions []Transaction) []Transaction {
+	seen := make(map[string]int, len(transactions))
+	for i := range transactions {
+		transaction := &transactions[i]
+		key := strings.Join([]string{
+			transaction.Account,
+			transaction.Date.Format(time.DateOnly),
+			transaction.Amount.String(),
+			transaction.Reference,
+		}, "|")
+
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			transaction.Reference = fmt.Sprintf("%s (%d)", transaction.Reference, n)
+		}
+	}
+
+	return transactions
+}
+
+// categoryPath joins the levels of a category, or only keeps the last one when flattening
+func categoryPath(levels []string, flatten bool) string {
+	var path []string
+	for _, level := range levels {
+		if level = strings.TrimSpace(level); level != "" {
+			path = append(path, level)
+		}
+	}
+
+	if len(path) == 0 {
+		return ""
+	}
+	if flatten {
+		return path[len(path)-1]
+	}
+
+	return strings.Join(path, categorySeparator)
+}
+
+// parseAmount reads an amount written with either a dot or a comma as decimal separator.
+// A separator repeated, or a lone comma followed by three digits, groups thousands as in 1,234 or 1.234.567.
+func parseAmount(s string) (decimal.Decimal, error) {
+	s = strings.TrimSpace(s)
+	s = strings.ReplaceAll(s, " ", "")
+
+	lastDot := strings.LastIndex(s, ".")
+	lastComma := strings.LastIndex(s, ",")
+	switch {
+	case lastDot < 0 && lastComma >= 0 && (strings.Count(s, ",") > 1 || len(s)-lastComma-1 == 3):
+		// 1,234 or 1,234,567
+		s = strings.ReplaceAll(s, ",", "")
+	case lastComma < 0 && strings.Count(s, ".") > 1:
+		// 1.234.567
+		s = strings.ReplaceAll(s, ".", "")
+	case lastComma > lastDot:
+		// 1.234,56 or 1234,56
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	case lastDot > lastComma:
+		// 1,234.56 or 1234.56
+		s = strings.ReplaceAll(s, ",", "")
+	}
+
+	amount, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+
+	return amount, nil
+}
+
+// reference returns the value used to detect a transaction already imported
+func reference(ref string, fallbacks ...string) string {
+	ref = strings.TrimSpace(ref)
+	if ref != "" {
+		return ref
+	}
+
+	for _, fallback := range fallbacks {
+		if fallback = strings.TrimSpace(fallback); fallback != "" {
+			return fallback
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,40 @@
+package importer
+
+import (
+	"testing"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		value  string
+		amount string
+	}{
+		{value: "1234.56", amount: "1234.56"},
+		{value: "1234,56", amount: "1234.56"},
+		{value: "1,234.56", amount: "1234.56"},
+		{value: "1.234,56", amount: "1234.56"},
+		{value: "-1 234,56", amount: "-1234.56"},
+		{value: "1,234", amount: "1234"},
+		{value: "-1,234", amount: "-1234"},
+		{value: "1,234,567", amount: "1234567"},
+		{value: "1.234.567", amount: "1234567"},
+		{value: "1,234,567.89", amount: "1234567.89"},
+		{value: "12,5", amount: "12.5"},
+		{value: "0,50", amount: "0.5"},
+		{value: "1.234", amount: "1.234"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			amount, err := parseAmount(tc.value)
+			require.NoError(t, err)
+			require.True(t, decimal.RequireFromString(tc.amount).Equal(amount), "got %s", amount)
+		})
+	}
+
+	_, err := parseAmount("1,23,4.5.6")
+	require.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	mt940TagRegexp  = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	mt940LineRegexp = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})([^/\n]*)(?://([^\n]*))?(?:\n([\s\S]*))?$`)
+	mt940SubRegexp  = regexp.MustCompile(`\?(\d{2})`)
+)
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ParseMT940 reads the :61: statement lines of a SWIFT MT940 file,
+// completed by the :86: information field following each of them
+func ParseMT940(r io.Reader) ([]Transaction, error) {
+	fields, err := readMT940Fields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []Transaction
+	for _, field := range fields {
+		switch field.tag {
+		case "61":
+			transaction, err := parseMT940Line(field.value)
+			if err != nil {
+				return nil, err
+			}
+			transactions = append(transactions, transaction)
+		case "86":
+			if n := len(transactions); n > 0 {
+				title, description := parseMT940Information(field.value)
+				last := &transactions[n-1]
+				if title != "" {
+					last.Title = title
+				}
+				last.Description = description
+				last.Reference = reference(last.Reference, last.Title, description)
+			}
+		}
+	}
+
+	return transactions, nil
+}
+
+// readMT940Fields splits a statement into its tagged fields, a field going on until the next tag
+func readMT940Fields(r io.Reader) ([]mt940Field, error) {
+	var fields []mt940Field
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r ")
+		if line == "" || line == "-" || strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		if matches := mt940TagRegexp.FindStringSubmatch(line); matches != nil {
+			fields = append(fields, mt940Field{tag: matches[1], value: matches[2]})
+			continue
+		}
+
+		if n := len(fields); n > 0 {
+			fields[n-1].value += "\n" + line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func parseMT940Line(value string) (Transaction, error) {
+	matches := mt940LineRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return Transaction{}, fmt.Errorf("invalid :61: field %q", value)
+	}
+
+	date, err := time.Parse("060102", matches[1])
+	if err != nil {
+		return Transaction{}, fmt.Errorf("invalid :61: date %q: %w", matches[1], err)
+	}
+
+	amount, err := parseAmount(matches[5])
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	// Debits and reversals of credits take money out of the account
+	if mark := matches[3]; mark == "D" || mark == "RC" {
+		amount = amount.Neg()
+	}
+
+	customerRef := strings.TrimSpace(matches[7])
+	if strings.EqualFold(customerRef, "NONREF") {
+		customerRef = ""
+	}
+	bankRef := strings.TrimSpace(matches[8])
+	details := strings.TrimSpace(strings.ReplaceAll(matches[9], "\n", " "))
+
+	return Transaction{
+		Date:        date,
+		Amount:      amount,
+		Title:       reference(details, customerRef, matches[6]),
+		Description: details,
+		Reference:   reference(customerRef, bankRef, details),
+		Checked:     true,
+	}, nil
+}
+
+// parseMT940Information reads a :86: field, either free text or structured with ?NN subfields
+func parseMT940Information(value string) (title string, description string) {
+	value = strings.ReplaceAll(value, "\n", "")
+
+	locations := mt940SubRegexp.FindAllStringSubmatchIndex(value, -1)
+	if len(locations) == 0 {
+		description = strings.TrimSpace(value)
+		return description, description
+	}
+
+	var purpose, name []string
+	for i, location := range locations {
+		end := len(value)
+		if i+1 < len(locations) {
+			end = locations[i+1][0]
+		}
+		code, _ := strconv.Atoi(value[location[2]:location[3]])
+		content := strings.TrimSpace(value[location[1]:end])
+		switch {
+		case code >= 20 && code <= 29, code >= 60 && code <= 63:
+			purpose = append(purpose, content)
+		case code == 32 || code == 33:
+			name = append(name, content)
+		}
+	}
+
+	description = strings.Join(purpose, " ")
+	title = strings.Join(name, "")
+	if title == "" {
+		title = description
+	}
+
+	return title, description
+}
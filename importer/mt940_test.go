@@ -0,0 +1,46 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+const mt940Sample = `:20:STARTUMS
+:25:12345678/0001234567
+:28C:00001/001
+:60F:C241201EUR1000,00
+:61:2412021202D45,90NDDTINV-2024-12//8327000090031789
+Card payment
+:86:166?00SEPA DIRECT DEBIT?20Electricity?21December?32ENERGY SUPPLIER
+:61:241203C2100,00NTRFNONREF
+:86:Salary December
+:62F:C241203EUR3054,10
+-`
+
+func TestParseMT940(t *testing.T) {
+	transactions, err := ParseMT940(strings.NewReader(mt940Sample))
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+
+	debit := transactions[0]
+	require.Equal(t, time.Date(2024, 12, 2, 0, 0, 0, 0, time.UTC), debit.Date)
+	require.True(t, debit.Amount.Equal(decimal.RequireFromString("-45.90")))
+	require.Equal(t, "ENERGY SUPPLIER", debit.Title)
+	require.Equal(t, "Electricity December", debit.Description)
+	require.Equal(t, "INV-2024-12", debit.Reference)
+	require.True(t, debit.Checked)
+
+	credit := transactions[1]
+	require.True(t, credit.Amount.Equal(decimal.RequireFromString("2100")))
+	require.Equal(t, "Salary December", credit.Title)
+	require.Equal(t, "Salary December", credit.Reference)
+}
+
+func TestParseMT940InvalidLine(t *testing.T) {
+	_, err := ParseMT940(strings.NewReader(":61:notaline\n"))
+	require.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+var qifDateRegexp = regexp.MustCompile(`^(\d{1,4})[/\-.](\d{1,2})[/\-.']\s*(\d{1,4})$`)
+
+type qifSplit struct {
+	category string
+	memo     string
+	amount   decimal.Decimal
+}
+
+type qifRecord struct {
+	date     string
+	amount   string
+	payee    string
+	memo     string
+	category string
+	number   string
+	cleared  string
+	splits   []qifSplit
+}
+
+// ParseQIF reads the bank and credit card sections of a QIF file.
+// A split transaction gives one transaction per split, each with its own category.
+func ParseQIF(r io.Reader, opts Options) ([]Transaction, error) {
+	var transactions []Transaction
+	var record qifRecord
+	inTransactions := false
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r ")
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "!") {
+			header := strings.ToLower(strings.TrimSpace(line))
+			inTransactions = header == "!type:bank" || header == "!type:ccard" || header == "!type:cash"
+			record = qifRecord{}
+			continue
+		}
+
+		if !inTransactions {
+			continue
+		}
+
+		code, value := line[0], strings.TrimSpace(line[1:])
+		switch code {
+		case 'D':
+			record.date = value
+		case 'T', 'U':
+			record.amount = value
+		case 'P':
+			record.payee = value
+		case 'M':
+			record.memo = value
+		case 'L':
+			record.category = qifCategory(value, opts.FlattenCategories)
+		case 'N':
+			record.number = value
+		case 'C':
+			record.cleared = value
+		case 'S':
+			record.splits = append(record.splits, qifSplit{category: qifCategory(value, opts.FlattenCategories)})
+		case 'E':
+			if n := len(record.splits); n > 0 {
+				record.splits[n-1].memo = value
+			}
+		case '$':
+			if n := len(record.splits); n > 0 {
+				amount, err := parseAmount(value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+				}
+				record.splits[n-1].amount = amount
+			}
+		case '^':
+			parsed, err := record.transactions(opts)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			transactions = append(transactions, parsed...)
+			record = qifRecord{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (record qifRecord) transactions(opts Options) ([]Transaction, error) {
+	date, err := parseQIFDate(record.date, opts.DayFirst)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := parseAmount(record.amount)
+	if err != nil {
+		return nil, err
+	}
+
+	title := reference(record.payee, record.memo)
+	base := Transaction{
+		Date:        date,
+		Amount:      amount,
+		Title:       title,
+		Description: record.memo,
+		Category:    record.category,
+		Reference:   reference(record.number, title),
+		Checked:     record.cleared == "*" || strings.EqualFold(record.cleared, "x") || strings.EqualFold(record.cleared, "r"),
+	}
+
+	if len(record.splits) == 0 {
+		return []Transaction{base}, nil
+	}
+
+	transactions := make([]Transaction, 0, len(record.splits))
+	for i, split := range record.splits {
+		transaction := base
+		transaction.Amount = split.amount
+		transaction.Category = split.category
+		if split.memo != "" {
+			transaction.Description = split.memo
+		}
+		transaction.Reference = fmt.Sprintf("%s#%d", base.Reference, i+1)
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// qifCategory drops the class part of a category and the brackets of a transfer
+func qifCategory(value string, flatten bool) string {
+	if i := strings.Index(value, "/"); i >= 0 {
+		value = value[:i]
+	}
+	value = strings.TrimPrefix(value, "[")
+	value = strings.TrimSuffix(value, "]")
+
+	return categoryPath(strings.Split(value, categorySeparator), flatten)
+}
+
+// parseQIFDate reads dates such as 12/31/2024, 12/31'24, 31.12.2024 or 2024-12-31
+func parseQIFDate(value string, dayFirst bool) (time.Time, error) {
+	matches := qifDateRegexp.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	a, _ := strconv.Atoi(matches[1])
+	b, _ := strconv.Atoi(matches[2])
+	c, _ := strconv.Atoi(matches[3])
+
+	var year, month, day int
+	switch {
+	case len(matches[1]) == 4:
+		year, month, day = a, b, c
+	case dayFirst:
+		day, month, year = a, b, c
+	default:
+		month, day, year = a, b, c
+	}
+
+	if year < 100 {
+		// Microsoft Money writes 2000 and later years as '00
+		if year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	return date, nil
+}
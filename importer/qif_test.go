@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+const qifSample = `!Type:Bank
+D12/03'24
+T-1,234.50
+PLandlord
+NCHQ101
+LHousing:Rent
+C*
+^
+D12/14/2024
+T-100.00
+PSuperU
+MWeekly shopping
+SGroceries
+EFood
+$-80.00
+SHousehold
+$-20.00
+^
+!Type:Memorized
+PIgnored
+^
+`
+
+func TestParseQIF(t *testing.T) {
+	transactions, err := ParseQIF(strings.NewReader(qifSample), Options{})
+	require.NoError(t, err)
+	require.Len(t, transactions, 3)
+
+	rent := transactions[0]
+	require.Equal(t, time.Date(2024, 12, 3, 0, 0, 0, 0, time.UTC), rent.Date)
+	require.True(t, rent.Amount.Equal(decimal.RequireFromString("-1234.50")))
+	require.Equal(t, "Landlord", rent.Title)
+	require.Equal(t, "Housing:Rent", rent.Category)
+	require.Equal(t, "CHQ101", rent.Reference)
+	require.True(t, rent.Checked)
+
+	food := transactions[1]
+	require.Equal(t, "Groceries", food.Category)
+	require.Equal(t, "Food", food.Description)
+	require.True(t, food.Amount.Equal(decimal.RequireFromString("-80")))
+	require.Equal(t, "SuperU#1", food.Reference)
+	require.False(t, food.Checked)
+
+	household := transactions[2]
+	require.Equal(t, "Household", household.Category)
+	require.Equal(t, "Weekly shopping", household.Description)
+	require.Equal(t, "SuperU#2", household.Reference)
+}
+
+func TestParseQIFDayFirst(t *testing.T) {
+	input := "!Type:CCard\nD03/12/2024\nT-9,99\nPNetflix\nL[Savings]\n^\n"
+
+	transactions, err := ParseQIF(strings.NewReader(input), Options{DayFirst: true})
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+	require.Equal(t, time.Date(2024, 12, 3, 0, 0, 0, 0, time.UTC), transactions[0].Date)
+	require.True(t, transactions[0].Amount.Equal(decimal.RequireFromString("-9.99")))
+	require.Equal(t, "Savings", transactions[0].Category)
+}
+
+func TestParseQIFInvalidDate(t *testing.T) {
+	input := "!Type:Bank\nD2024/13/45\nT-1\n^\n"
+
+	_, err := ParseQIF(strings.NewReader(input), Options{})
+	require.Error(t, err)
+}
+
+func TestParseQIFRepeatedRecords(t *testing.T) {
+	// Two identical coffees the same day must both be imported
+	record := "D12/03/2024\nT-2.50\nPCoffee shop\n^\n"
+	transactions, err := Parse(QIF, strings.NewReader("!Type:Bank\n"+record+record), Options{})
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	require.Equal(t, "Coffee shop", transactions[0].Reference)
+	require.Equal(t, "Coffee shop (2)", transactions[1].Reference)
+
+	// Reading the file again gives the same references, so a second import skips both
+	again, err := Parse(QIF, strings.NewReader("!Type:Bank\n"+record+record), Options{})
+	require.NoError(t, err)
+	require.Equal(t, transactions[1].Reference, again[1].Reference)
+}
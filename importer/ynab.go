@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+const (
+	ynabStartingBalance = "Starting Balance"
+	ynabUncleared       = "Uncleared"
+	ynabCategoryGroup   = "category group/category"
+)
+
+// ParseYNAB reads the register CSV export of YNAB
+func ParseYNAB(r io.Reader, opts Options) (Book, error) {
+	records, err := csvRecords(r)
+	if err != nil {
+		return Book{}, err
+	}
+
+	var book Book
+	accounts := map[string]int{}
+	addAccount := func(title string) int {
+		if i, ok := accounts[title]; ok {
+			return i
+		}
+		accounts[title] = len(book.Accounts)
+		book.Accounts = append(book.Accounts, Account{Title: title})
+		return accounts[title]
+	}
+
+	for i, record := range records {
+		account := record["account"]
+		index := addAccount(account)
+
+		date, err := parseQIFDate(record["date"], opts.DayFirst)
+		if err != nil {
+			return Book{}, fmt.Errorf("record %d: %w", i+1, err)
+		}
+
+		amount, err := ynabAmount(record["inflow"], record["outflow"])
+		if err != nil {
+			return Book{}, fmt.Errorf("record %d: %w", i+1, err)
+		}
+
+		payee := record["payee"]
+		if payee == ynabStartingBalance {
+			book.Accounts[index].InitBalance = book.Accounts[index].InitBalance.Add(amount)
+			continue
+		}
+
+		levels := []string{record["category group"], record["category"]}
+		if record["category group"] == "" && record["category"] == "" {
+			levels = strings.Split(record[ynabCategoryGroup], "/")
+		}
+
+		title := reference(payee, record["memo"])
+		book.Transactions = append(book.Transactions, Transaction{
+			Account:     account,
+			Date:        date,
+			Amount:      amount,
+			Title:       title,
+			Description: record["memo"],
+			Category:    categoryPath(levels, opts.FlattenCategories),
+			Reference:   reference(fmt.Sprintf("%s/%s", title, record["memo"])),
+			Checked:     record["cleared"] != "" && record["cleared"] != ynabUncleared,
+		})
+	}
+
+	return book, nil
+}
+
+// ynabAmount combines the inflow and outflow columns, written with a currency symbol
+func ynabAmount(inflow string, outflow string) (decimal.Decimal, error) {
+	in, err := parseAmount(ynabNumber(inflow))
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	out, err := parseAmount(ynabNumber(outflow))
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return in.Sub(out), nil
+}
+
+func ynabNumber(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' || r == '-' {
+			return r
+		}
+		return -1
+	}, value)
+
+	if value == "" {
+		return "0"
+	}
+	return value
+}
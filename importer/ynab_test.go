@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+const ynabSample = "\uFEFF" + `"Account","Flag","Date","Payee","Category Group/Category","Category Group","Category","Memo","Outflow","Inflow","Cleared"
+"Checking","","12/01/2024","Starting Balance","Inflow: Ready to Assign","Inflow","Ready to Assign","","€0.00","€207.34","Reconciled"
+"Checking","","12/03/2024","SuperU","Everyday: Groceries","Everyday","Groceries","weekly","€57.30","€0.00","Cleared"
+"Checking","","12/04/2024","Employer","Inflow: Ready to Assign","Inflow","Ready to Assign","","€0.00","€2,124.98","Uncleared"
+`
+
+func TestParseYNAB(t *testing.T) {
+	book, err := ParseYNAB(strings.NewReader(ynabSample), Options{})
+	require.NoError(t, err)
+
+	require.Len(t, book.Accounts, 1)
+	require.True(t, book.Accounts[0].InitBalance.Equal(decimal.RequireFromString("207.34")))
+
+	require.Len(t, book.Transactions, 2)
+	groceries := book.Transactions[0]
+	require.Equal(t, "Checking", groceries.Account)
+	require.Equal(t, time.Date(2024, 12, 3, 0, 0, 0, 0, time.UTC), groceries.Date)
+	require.True(t, groceries.Amount.Equal(decimal.RequireFromString("-57.30")))
+	require.Equal(t, "Everyday:Groceries", groceries.Category)
+	require.True(t, groceries.Checked)
+
+	salary := book.Transactions[1]
+	require.True(t, salary.Amount.Equal(decimal.RequireFromString("2124.98")))
+	require.False(t, salary.Checked)
+}
+
+func TestParseYNABFlattenCategories(t *testing.T) {
+	book, err := ParseYNAB(strings.NewReader(ynabSample), Options{FlattenCategories: true})
+	require.NoError(t, err)
+	require.Equal(t, "Groceries", book.Transactions[0].Category)
+}
@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/moth13/finance_tracker/db/sqlc"
+)
+
+// DefaultAnomalyInterval is the time between two runs when none is configured
+const DefaultAnomalyInterval = time.Hour
+
+// usernamePageSize is the number of users read at once
+const usernamePageSize = 100
+
+// Anomalies looks for unusual charges in the lines of every user
+type Anomalies struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewAnomalies creates the job, running every interval or every DefaultAnomalyInterval without one
+func NewAnomalies(store db.Store, interval time.Duration) *Anomalies {
+	if interval <= 0 {
+		interval = DefaultAnomalyInterval
+	}
+	return &Anomalies{store: store, interval: interval}
+}
+
+// Run detects the anomalies right away, then every interval until the context is done
+func (job *Anomalies) Run(ctx context.Context) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.RunOnce(ctx); err != nil {
+			log.Println("cannot detect anomalies:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce detects the anomalies of every user, a user failing doesn't stop the others
+func (job *Anomalies) RunOnce(ctx context.Context) error {
+	for offset := int32(0); ; offset += usernamePageSize {
+		usernames, err := job.store.ListUsernames(ctx, db.ListUsernamesParams{
+			Limit:  usernamePageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, username := range usernames {
+			if _, err := job.store.DetectAnomaliesTx(ctx, db.DetectAnomaliesTxParams{Owner: username}); err != nil {
+				log.Printf("cannot detect anomalies of %s: %v", username, err)
+			}
+		}
+
+		if len(usernames) < usernamePageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnomaliesRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListUsernames(gomock.Any(), gomock.Eq(db.ListUsernamesParams{Limit: usernamePageSize, Offset: 0})).
+		Times(1).
+		Return([]string{"alice", "bob"}, nil)
+	store.EXPECT().
+		DetectAnomaliesTx(gomock.Any(), gomock.Eq(db.DetectAnomaliesTxParams{Owner: "alice"})).
+		Times(1).
+		Return(db.DetectAnomaliesTxResult{}, sql.ErrConnDone)
+	store.EXPECT().
+		DetectAnomaliesTx(gomock.Any(), gomock.Eq(db.DetectAnomaliesTxParams{Owner: "bob"})).
+		Times(1).
+		Return(db.DetectAnomaliesTxResult{}, nil)
+
+	job := NewAnomalies(store, 0)
+	require.Equal(t, DefaultAnomalyInterval, job.interval)
+	// A user failing doesn't stop the others
+	require.NoError(t, job.RunOnce(context.Background()))
+}
+
+func TestAnomaliesRunOnceListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListUsernames(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil, sql.ErrConnDone)
+	store.EXPECT().
+		DetectAnomaliesTx(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	require.ErrorIs(t, NewAnomalies(store, time.Hour).RunOnce(context.Background()), sql.ErrConnDone)
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/moth13/finance_tracker/db/sqlc"
+)
+
+// DefaultSnapshotInterval is the time between two runs when none is configured
+const DefaultSnapshotInterval = time.Hour
+
+// snapshotDays is the number of days up to today every account keeps a balance snapshot for
+const snapshotDays = 400
+
+// BalanceSnapshots writes the end of day balance of every account, older days are computed when first read
+type BalanceSnapshots struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewBalanceSnapshots creates the job, running every interval or every DefaultSnapshotInterval without one
+func NewBalanceSnapshots(store db.Store, interval time.Duration) *BalanceSnapshots {
+	if interval <= 0 {
+		interval = DefaultSnapshotInterval
+	}
+	return &BalanceSnapshots{store: store, interval: interval}
+}
+
+// Run refreshes the snapshots right away, then every interval until the context is done
+func (job *BalanceSnapshots) Run(ctx context.Context) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.RunOnce(ctx, time.Now()); err != nil {
+			log.Println("cannot refresh balance snapshots:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce computes the snapshots missing up to a date
+func (job *BalanceSnapshots) RunOnce(ctx context.Context, date time.Time) error {
+	_, err := job.store.RefreshBalanceSnapshotsTx(ctx, db.RefreshBalanceSnapshotsTxParams{
+		Date: date,
+		Days: snapshotDays,
+	})
+	return err
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/moth13/finance_tracker/db/mock"
+	db "github.com/moth13/finance_tracker/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceSnapshotsRunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	date := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		RefreshBalanceSnapshotsTx(gomock.Any(), gomock.Eq(db.RefreshBalanceSnapshotsTxParams{Date: date, Days: snapshotDays})).
+		Times(1).
+		Return(db.RefreshBalanceSnapshotsTxResult{Accounts: 2, Snapshots: 800}, nil)
+	store.EXPECT().
+		RefreshBalanceSnapshotsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.RefreshBalanceSnapshotsTxResult{}, sql.ErrConnDone)
+
+	job := NewBalanceSnapshots(store, 0)
+	require.Equal(t, DefaultSnapshotInterval, job.interval)
+	require.NoError(t, job.RunOnce(context.Background(), date))
+	require.ErrorIs(t, job.RunOnce(context.Background(), date.AddDate(0, 0, 1)), sql.ErrConnDone)
+}
+
+func TestBalanceSnapshotsRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		RefreshBalanceSnapshotsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, _ db.RefreshBalanceSnapshotsTxParams) (db.RefreshBalanceSnapshotsTxResult, error) {
+			cancel()
+			return db.RefreshBalanceSnapshotsTxResult{}, nil
+		})
+
+	// The job runs once right away and stops with its context
+	NewBalanceSnapshots(store, time.Hour).Run(ctx)
+}
@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Email is a plain text message to a single recipient
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers emails
+type Sender interface {
+	Send(email Email) error
+}
+
+// SMTPSender sends emails through an SMTP server
+type SMTPSender struct {
+	address string
+	from    string
+	auth    smtp.Auth
+}
+
+// NewSMTPSender creates a sender for an SMTP server given as host:port, the server is used without authentication when no username is given
+func NewSMTPSender(address string, from string, username string, password string) *SMTPSender {
+	sender := &SMTPSender{address: address, from: from}
+	if username != "" {
+		host := address
+		if i := strings.LastIndex(address, ":"); i >= 0 {
+			host = address[:i]
+		}
+		sender.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return sender
+}
+
+// Send delivers an email
+func (sender *SMTPSender) Send(email Email) error {
+	message := buildMessage(sender.from, email, time.Now())
+	if err := smtp.SendMail(sender.address, sender.auth, sender.from, []string{email.To}, message); err != nil {
+		return fmt.Errorf("cannot send email to %s: %w", email.To, err)
+	}
+
+	return nil
+}
+
+// buildMessage writes the headers and body of an email, line breaks in headers are dropped so they can't add headers
+func buildMessage(from string, email Email, date time.Time) []byte {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		value = strings.NewReplacer("\r", "", "\n", " ").Replace(value)
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+
+	header("From", from)
+	header("To", email.To)
+	header("Subject", email.Subject)
+	header("Date", date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(email.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return buf.Bytes()
+}
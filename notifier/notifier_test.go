@@ -0,0 +1,33 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMessage(t *testing.T) {
+	date := time.Date(2025, time.March, 14, 9, 30, 0, 0, time.UTC)
+	email := Email{
+		To:      "jose@example.com",
+		Subject: "Budget alert\r\nBcc: someone@example.com",
+		Body:    "Food reached 80%\nof its budget",
+	}
+
+	message := string(buildMessage("alerts@example.com", email, date))
+	headers, body, found := strings.Cut(message, "\r\n\r\n")
+	require.True(t, found)
+
+	require.Contains(t, headers, "From: alerts@example.com\r\n")
+	require.Contains(t, headers, "To: jose@example.com\r\n")
+	require.Contains(t, headers, "Date: Fri, 14 Mar 2025 09:30:00 +0000\r\n")
+	require.Contains(t, headers, "Content-Type: text/plain; charset=UTF-8")
+
+	// Line breaks can't smuggle headers in
+	require.Contains(t, headers, "Subject: Budget alert Bcc: someone@example.com\r\n")
+	require.NotContains(t, headers, "\r\nBcc:")
+
+	require.Equal(t, "Food reached 80%\r\nof its budget", body)
+}
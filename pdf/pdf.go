@@ -0,0 +1,185 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+// Font is one of the standard PDF fonts, available in every reader without embedding
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+	Courier
+)
+
+var fontNames = []string{"Helvetica", "Helvetica-Bold", "Courier"}
+
+// courierWidth is the advance of every Courier glyph, in thousandths of the font size
+const courierWidth = 600
+
+// Document is a PDF document built page by page
+type Document struct {
+	pages []*Page
+}
+
+// Page is a page of a document, coordinates start at the bottom left corner
+type Page struct {
+	content bytes.Buffer
+}
+
+// New creates an empty document
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage appends a blank A4 page to the document
+func (doc *Document) AddPage() *Page {
+	page := &Page{}
+	doc.pages = append(doc.pages, page)
+	return page
+}
+
+// Pages returns the pages of the document in order
+func (doc *Document) Pages() []*Page {
+	return doc.pages
+}
+
+// Text writes s with its baseline starting at x, y
+func (page *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&page.content, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		int(font)+1, number(size), number(x), number(y), escape(s))
+}
+
+// TextRight writes a Courier string so that it ends at x
+func (page *Page) TextRight(x, y float64, size float64, s string) {
+	page.Text(x-TextWidth(Courier, size, s), y, Courier, size, s)
+}
+
+// Line draws a straight line between two points
+func (page *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&page.content, "%s w %s %s m %s %s l S\n",
+		number(width), number(x1), number(y1), number(x2), number(y2))
+}
+
+// Rect fills a rectangle with a gray level between 0 (black) and 1 (white)
+func (page *Page) Rect(x, y, width, height, gray float64) {
+	fmt.Fprintf(&page.content, "q %s g %s %s %s %s re f Q\n",
+		number(gray), number(x), number(y), number(width), number(height))
+}
+
+// TextWidth returns the width of s, exact for Courier and an estimate for Helvetica
+func TextWidth(font Font, size float64, s string) float64 {
+	width := float64(courierWidth)
+	if font != Courier {
+		// Average advance of Helvetica glyphs
+		width = 556
+	}
+
+	return float64(len(encode(s))) * width * size / 1000
+}
+
+// WriteTo writes the document as a PDF file
+func (doc *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1 and 2 are the catalog and the page tree, fonts follow, then each page and its content
+	firstPage := 3 + len(fontNames)
+	kids := make([]string, len(doc.pages))
+	for i := range doc.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(doc.pages)))
+
+	var fonts []string
+	for i, name := range fontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+		fonts = append(fonts, fmt.Sprintf("/F%d %d 0 R", i+1, 3+i))
+	}
+
+	for i, page := range doc.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			number(PageWidth), number(PageHeight), strings.Join(fonts, " "), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// number writes a coordinate without a useless fractional part
+func number(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// escape encodes a string for a PDF literal string in WinAnsiEncoding
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range encode(s) {
+		switch c {
+		case '\\', '(', ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			if c < 0x20 || c > 0x7e {
+				fmt.Fprintf(&b, "\\%03o", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// winAnsi maps the characters of the 0x80-0x9F range of WinAnsiEncoding
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// encode converts UTF-8 to WinAnsiEncoding, characters it lacks become a question mark
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			out = append(out, byte(r))
+		case winAnsi[r] != 0:
+			out = append(out, winAnsi[r])
+		default:
+			out = append(out, '?')
+		}
+	}
+
+	return out
+}
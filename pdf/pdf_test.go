@@ -0,0 +1,55 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTo(t *testing.T) {
+	doc := New()
+	page := doc.AddPage()
+	page.Text(50, 800, HelveticaBold, 18, "Relevé (mars)")
+	page.TextRight(545, 780, 9, "12.50 €")
+	page.Line(50, 770, 545, 770, 0.5)
+	page.Rect(50, 750, 495, 14, 0.9)
+	doc.AddPage()
+
+	var buf bytes.Buffer
+	_, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+	out := buf.String()
+
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-1.4\n")))
+	require.Contains(t, out, "/Count 2")
+	require.Contains(t, out, "/BaseFont /Helvetica-Bold")
+	require.Contains(t, out, `(Relev\351 \(mars\)) Tj`)
+	require.Contains(t, out, `(12.50 \200) Tj`)
+	require.True(t, bytes.HasSuffix(buf.Bytes(), []byte("%%EOF\n")))
+
+	// Every xref entry must point at its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	require.NotNil(t, startxref)
+	xref, err := strconv.Atoi(startxref[1])
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buf.Bytes()[xref:], []byte("xref\n")))
+
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out, -1)
+	require.Len(t, offsets, 2+len(fontNames)+2*2)
+	for i, offset := range offsets {
+		n, err := strconv.Atoi(offset[1])
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(buf.Bytes()[n:], []byte(fmt.Sprintf("%d 0 obj", i+1))))
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	require.Equal(t, 60.0, TextWidth(Courier, 10, "0123456789"))
+	// Multi-byte characters count once
+	require.Equal(t, TextWidth(Courier, 10, "e"), TextWidth(Courier, 10, "€"))
+	require.Equal(t, "?", string(encode("✓")))
+}
@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Rule matches lines on every condition it sets and applies every action it sets.
+// A nil or empty field is neither a condition nor an action.
+type Rule struct {
+	ID int64
+
+	// Conditions
+	TitlePattern string
+	MinAmount    decimal.NullDecimal
+	MaxAmount    decimal.NullDecimal
+	AccountID    *int64
+	DayOfMonth   *int32
+
+	// Actions
+	CategoryID  *int64
+	Payee       *string
+	Tags        []string
+	Checked     *bool
+	Description *string
+}
+
+// Line holds the fields of a line that rules read or write
+type Line struct {
+	Title       string
+	Amount      decimal.Decimal
+	AccountID   int64
+	DueDate     time.Time
+	CategoryID  int64
+	Payee       string
+	Tags        []string
+	Checked     bool
+	Description string
+}
+
+// Validate checks that a rule has at least one condition and one action and that they are consistent
+func Validate(rule Rule) error {
+	if rule.TitlePattern == "" && !rule.MinAmount.Valid && !rule.MaxAmount.Valid &&
+		rule.AccountID == nil && rule.DayOfMonth == nil {
+		return errors.New("rule needs at least one condition")
+	}
+	if rule.CategoryID == nil && rule.Payee == nil && len(rule.Tags) == 0 &&
+		rule.Checked == nil && rule.Description == nil {
+		return errors.New("rule needs at least one action")
+	}
+
+	if _, err := regexp.Compile(rule.TitlePattern); err != nil {
+		return fmt.Errorf("invalid title pattern: %w", err)
+	}
+	if rule.MinAmount.Valid && rule.MaxAmount.Valid && rule.MinAmount.Decimal.GreaterThan(rule.MaxAmount.Decimal) {
+		return errors.New("minimum amount is greater than maximum amount")
+	}
+	if rule.DayOfMonth != nil && (*rule.DayOfMonth < 1 || *rule.DayOfMonth > 31) {
+		return fmt.Errorf("invalid day of month %d", *rule.DayOfMonth)
+	}
+
+	return nil
+}
+
+type compiledRule struct {
+	Rule
+	title *regexp.Regexp
+}
+
+// Engine applies the rules of a user, rules are expected in priority order
+type Engine struct {
+	rules []compiledRule
+}
+
+// NewEngine compiles rules, the first ones take precedence
+func NewEngine(rules []Rule) (*Engine, error) {
+	engine := &Engine{rules: make([]compiledRule, 0, len(rules))}
+
+	for _, rule := range rules {
+		compiled := compiledRule{Rule: rule}
+		if rule.TitlePattern != "" {
+			title, err := regexp.Compile(rule.TitlePattern)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: invalid title pattern: %w", rule.ID, err)
+			}
+			compiled.title = title
+		}
+		engine.rules = append(engine.rules, compiled)
+	}
+
+	return engine, nil
+}
+
+// Apply runs every matching rule on a line. When several rules set the same field
+// the first one wins, tags are merged. It returns the changed line and the ids of the matching rules.
+func (engine *Engine) Apply(line Line) (Line, []int64) {
+	matched := []int64{}
+	var setCategory, setPayee, setChecked, setDescription bool
+
+	// Never share the tags of the caller
+	line.Tags = slices.Clone(line.Tags)
+
+	for _, rule := range engine.rules {
+		if !rule.matches(line) {
+			continue
+		}
+		matched = append(matched, rule.ID)
+
+		if rule.CategoryID != nil && !setCategory {
+			line.CategoryID = *rule.CategoryID
+			setCategory = true
+		}
+		if rule.Payee != nil && !setPayee {
+			line.Payee = *rule.Payee
+			setPayee = true
+		}
+		if rule.Checked != nil && !setChecked {
+			line.Checked = *rule.Checked
+			setChecked = true
+		}
+		if rule.Description != nil && !setDescription {
+			line.Description = *rule.Description
+			setDescription = true
+		}
+		for _, tag := range rule.Tags {
+			if !slices.Contains(line.Tags, tag) {
+				line.Tags = append(line.Tags, tag)
+			}
+		}
+	}
+
+	return line, matched
+}
+
+func (rule compiledRule) matches(line Line) bool {
+	if rule.title != nil && !rule.title.MatchString(line.Title) {
+		return false
+	}
+	if rule.MinAmount.Valid && line.Amount.LessThan(rule.MinAmount.Decimal) {
+		return false
+	}
+	if rule.MaxAmount.Valid && line.Amount.GreaterThan(rule.MaxAmount.Decimal) {
+		return false
+	}
+	if rule.AccountID != nil && line.AccountID != *rule.AccountID {
+		return false
+	}
+	if rule.DayOfMonth != nil && line.DueDate.Day() != int(*rule.DayOfMonth) {
+		return false
+	}
+
+	return true
+}
+
+// Changed tells whether applying rules modified a line
+func Changed(before, after Line) bool {
+	return before.CategoryID != after.CategoryID || before.Payee != after.Payee ||
+		before.Checked != after.Checked || before.Description != after.Description ||
+		!slices.Equal(before.Tags, after.Tags)
+}
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func amount(s string) decimal.NullDecimal {
+	return decimal.NewNullDecimal(decimal.RequireFromString(s))
+}
+
+var sampleLine = Line{
+	Title:      "CB CARREFOUR 12/03",
+	Amount:     decimal.RequireFromString("-57.30"),
+	AccountID:  1,
+	DueDate:    time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC),
+	CategoryID: 9,
+	Tags:       []string{"card"},
+}
+
+func TestValidate(t *testing.T) {
+	valid := Rule{TitlePattern: "(?i)carrefour", CategoryID: ptr(int64(2))}
+	require.NoError(t, Validate(valid))
+
+	require.Error(t, Validate(Rule{CategoryID: ptr(int64(2))}))
+	require.Error(t, Validate(Rule{TitlePattern: "carrefour"}))
+	require.Error(t, Validate(Rule{TitlePattern: "carrefour(", CategoryID: ptr(int64(2))}))
+	require.Error(t, Validate(Rule{MinAmount: amount("10"), MaxAmount: amount("5"), Checked: ptr(true)}))
+	require.Error(t, Validate(Rule{DayOfMonth: ptr(int32(32)), Checked: ptr(true)}))
+}
+
+func TestApply(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{ID: 1, TitlePattern: "(?i)carrefour", CategoryID: ptr(int64(2)), Payee: ptr("Carrefour"), Tags: []string{"groceries"}},
+		{ID: 2, MaxAmount: amount("0"), CategoryID: ptr(int64(3)), Tags: []string{"card", "spending"}},
+		{ID: 3, AccountID: ptr(int64(2)), Checked: ptr(true)},
+		{ID: 4, DayOfMonth: ptr(int32(12)), MinAmount: amount("-100"), Description: ptr("twelfth")},
+	})
+	require.NoError(t, err)
+
+	line, matched := engine.Apply(sampleLine)
+	require.Equal(t, []int64{1, 2, 4}, matched)
+	// The first matching rule wins
+	require.Equal(t, int64(2), line.CategoryID)
+	require.Equal(t, "Carrefour", line.Payee)
+	require.Equal(t, []string{"card", "groceries", "spending"}, line.Tags)
+	require.False(t, line.Checked)
+	require.Equal(t, "twelfth", line.Description)
+	require.True(t, Changed(sampleLine, line))
+
+	// The line given is left untouched
+	require.Equal(t, []string{"card"}, sampleLine.Tags)
+	require.Equal(t, int64(9), sampleLine.CategoryID)
+}
+
+func TestApplyNoMatch(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{ID: 1, TitlePattern: "^SALARY", CategoryID: ptr(int64(2))},
+		{ID: 2, MinAmount: amount("0"), Checked: ptr(true)},
+	})
+	require.NoError(t, err)
+
+	line, matched := engine.Apply(sampleLine)
+	require.Empty(t, matched)
+	require.False(t, Changed(sampleLine, line))
+}
+
+func TestNewEngineInvalidPattern(t *testing.T) {
+	_, err := NewEngine([]Rule{{ID: 1, TitlePattern: "("}})
+	require.Error(t, err)
+}
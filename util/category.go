@@ -0,0 +1,61 @@
+package util
+
+import (
+	"regexp"
+
+	decimal "github.com/shopspring/decimal"
+)
+
+// Kinds of categories
+const (
+	INCOME   = "INCOME"
+	EXPENSE  = "EXPENSE"
+	TRANSFER = "TRANSFER"
+	SAVINGS  = "SAVINGS"
+)
+
+// Checks of line amounts against the kind of their category
+const (
+	WARN    = "WARN"
+	ENFORCE = "ENFORCE"
+)
+
+var iconKey = regexp.MustCompile(`^[a-z0-9-]{1,32}$`)
+
+// IsSupportedCategoryKind returns true if the category kind is supported
+func IsSupportedCategoryKind(kind string) bool {
+	switch kind {
+	case INCOME, EXPENSE, TRANSFER, SAVINGS:
+		return true
+	}
+
+	return false
+}
+
+// IsSupportedKindCheck returns true if the check of line amounts is supported
+func IsSupportedKindCheck(check string) bool {
+	switch check {
+	case WARN, ENFORCE:
+		return true
+	}
+
+	return false
+}
+
+// IsValidIconKey returns true if the icon key is empty or made of lowercase letters, digits and dashes
+func IsValidIconKey(icon string) bool {
+	return icon == "" || iconKey.MatchString(icon)
+}
+
+// AmountMatchesCategoryKind tells whether a line amount has the sign expected by the kind of its category.
+// Income is positive and expense negative, transfers and savings go both ways.
+func AmountMatchesCategoryKind(kind string, amount decimal.Decimal) bool {
+	switch kind {
+	case INCOME:
+		return !amount.IsNegative()
+	case EXPENSE:
+		return !amount.IsPositive()
+	}
+
+	return true
+}
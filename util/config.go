@@ -16,6 +16,15 @@ type Config struct {
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	// EmailSMTPAddress is the host:port of the SMTP server, emails are not sent without it
+	EmailSMTPAddress   string `mapstructure:"EMAIL_SMTP_ADDRESS"`
+	EmailSMTPUsername  string `mapstructure:"EMAIL_SMTP_USERNAME"`
+	EmailSMTPPassword  string `mapstructure:"EMAIL_SMTP_PASSWORD"`
+	EmailSenderAddress string `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	// BalanceSnapshotInterval is the time between two refreshes of the balance snapshots
+	BalanceSnapshotInterval time.Duration `mapstructure:"BALANCE_SNAPSHOT_INTERVAL"`
+	// AnomalyInterval is the time between two searches for unusual charges
+	AnomalyInterval time.Duration `mapstructure:"ANOMALY_INTERVAL"`
 }
 
 // LoadConfig reads configuration from file or environment variables
@@ -0,0 +1,25 @@
+package util
+
+const (
+	EN = "en"
+	FR = "fr"
+)
+
+// IsSupportedLocale returns true if the locale is supported
+func IsSupportedLocale(locale string) bool {
+	switch locale {
+	case EN, FR:
+		return true
+	}
+
+	return false
+}
+
+// LocaleForCurrency returns the locale of a user who only gave a currency
+func LocaleForCurrency(currency string) string {
+	if currency == EUR {
+		return FR
+	}
+
+	return EN
+}
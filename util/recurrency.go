@@ -1,5 +1,7 @@
 package util
 
+import "time"
+
 const (
 	WEEKLY = "WEEKLY"
 	MONTHLY = "MONTHLY"
@@ -14,4 +16,26 @@ func IsSupportedRecurrency(recurrency string) bool {
 	}
 
 	return false
-}
\ No newline at end of file
+}
+
+// AddRecurrency returns the due date n occurrences after date. Monthly and annual
+// recurrencies keep the day of month, falling back to the last day of shorter months.
+func AddRecurrency(date time.Time, recurrency string, n int) time.Time {
+	switch recurrency {
+	case WEEKLY:
+		return date.AddDate(0, 0, 7*n)
+	case MONTHLY:
+		return addMonths(date, n)
+	case ANNUAL:
+		return addMonths(date, 12*n)
+	}
+
+	return date
+}
+
+func addMonths(date time.Time, n int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(n), 1, 0, 0, 0, 0, date.Location())
+	day := min(date.Day(), first.AddDate(0, 1, -1).Day())
+
+	return time.Date(first.Year(), first.Month(), day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+}
@@ -0,0 +1,92 @@
+package charts
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func day(date string) time.Time {
+	t, _ := time.Parse("2006-01-02", date)
+	return t
+}
+
+func TestValueRange(t *testing.T) {
+	low, high := valueRange([]decimal.Decimal{decimal.NewFromInt(50), decimal.NewFromInt(-20)})
+	require.Equal(t, -20.0, low)
+	require.Equal(t, 50.0, high)
+
+	// Zero always is in the range
+	low, high = valueRange([]decimal.Decimal{decimal.NewFromInt(10), decimal.NewFromInt(30)})
+	require.Equal(t, 0.0, low)
+	require.Equal(t, 30.0, high)
+
+	// A flat range still has a height
+	low, high = valueRange(nil)
+	require.Equal(t, 0.0, low)
+	require.Equal(t, 1.0, high)
+}
+
+func TestLinePoints(t *testing.T) {
+	line := Line{ID: "test", Title: "Balance", Points: []Point{
+		{Date: day("2024-03-01"), Value: decimal.NewFromInt(100)},
+		{Date: day("2024-03-02"), Value: decimal.NewFromInt(-100)},
+		{Date: day("2024-03-03"), Value: decimal.NewFromInt(0)},
+	}}
+
+	require.Equal(t, "64.0,8.0 332.0,216.0 600.0,112.0", linePoints(line))
+	require.Equal(t, "From 100.00€ on 2024/01/03 to 0.00€ on 2024/03/03, lowest -100.00€ on 2024/02/03", lineSummary(line))
+	require.Equal(t, "No data", lineSummary(Line{}))
+}
+
+func TestBarRects(t *testing.T) {
+	bars := Bars{ID: "test", Title: "Months", Bars: []Bar{
+		{Label: "Jan 24", In: decimal.NewFromInt(200), Out: decimal.NewFromInt(100)},
+		{Label: "Feb 24", In: decimal.NewFromInt(0), Out: decimal.NewFromInt(50)},
+	}}
+
+	in, out := barRects(bars, 0)
+	require.InDelta(t, 90.8, in.X, 0.01)
+	require.InDelta(t, 8.0, in.Y, 0.01)
+	require.InDelta(t, 208.0, in.Height, 0.01)
+	require.InDelta(t, in.X+in.Width, out.X, 0.01)
+	require.InDelta(t, 104.0, out.Height, 0.01)
+
+	in, _ = barRects(bars, 1)
+	require.Zero(t, in.Height)
+
+	require.Equal(t, "2 periods from Jan 24 to Feb 24, 200.00€ in and 150.00€ out", barsSummary(bars))
+}
+
+func TestDonutSegments(t *testing.T) {
+	donut := Donut{ID: "test", Title: "Categories", Slices: []Slice{
+		{Label: "Food", Value: decimal.NewFromInt(75), Color: "#000000"},
+		{Label: "Nothing", Value: decimal.Zero},
+		{Label: "Fun", Value: decimal.NewFromInt(25)},
+	}}
+
+	segments := donutSegments(donut)
+	require.Len(t, segments, 2)
+	require.Equal(t, "#000000", segments[0].Color)
+	require.Equal(t, "75.00 25.00", segments[0].dashArray())
+	require.Equal(t, "25.00", segments[0].dashOffset())
+	require.Equal(t, palette[1], segments[1].Color)
+	require.Equal(t, "-50.00", segments[1].dashOffset())
+	require.Equal(t, "Fun: 25.00€ (25%)", segments[1].label())
+
+	require.Empty(t, donutSegments(Donut{}))
+}
+
+func TestChartsAreLabelled(t *testing.T) {
+	var buf bytes.Buffer
+	err := LineChart(Line{ID: "balances", Title: "Balance"}).Render(context.Background(), &buf)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(buf.String(), `role="img"`))
+	require.True(t, strings.Contains(buf.String(), `aria-labelledby="balances-title balances-desc"`))
+	require.True(t, strings.Contains(buf.String(), `<title id="balances-title">Balance</title>`))
+}